@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/fernandoocampo/players/internal/application"
+)
+
+func main() {
+	app := application.NewApplication()
+
+	if err := app.CanonicalizePlayers(); err != nil {
+		slog.Error("unable to canonicalize players", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
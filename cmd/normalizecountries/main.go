@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/fernandoocampo/players/internal/application"
+)
+
+func main() {
+	app := application.NewApplication()
+
+	if err := app.NormalizeCountries(); err != nil {
+		slog.Error("unable to normalize countries", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,182 @@
+package cryptos
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+type Argon2Setup struct {
+	// Memory used by the algorithm in KiB.
+	Memory uint32
+	// Iterations over the memory.
+	Iterations uint32
+	// Parallelism number of threads used by the algorithm.
+	Parallelism uint8
+	Logger      *slog.Logger
+}
+
+// Argon2 defines logic to hash passwords using argon2id algorithm,
+// hashes are encoded using the PHC string format.
+// e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+type Argon2 struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	logger      *slog.Logger
+}
+
+// argon2 parameters recommended by OWASP, they are used when no value is given.
+const (
+	defaultArgon2Memory      uint32 = 19 * 1024
+	defaultArgon2Iterations  uint32 = 2
+	defaultArgon2Parallelism uint8  = 1
+	argon2SaltLength                = 16
+	argon2KeyLength          uint32 = 32
+)
+
+var (
+	argon2idPrefix = []byte("$argon2id$")
+
+	errInvalidArgon2Hash = errors.New("invalid argon2id hash")
+)
+
+// argon2Hash contains the values of an argon2id PHC string.
+type argon2Hash struct {
+	version     int
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func NewArgon2(argon2Setup Argon2Setup) *Argon2 {
+	if argon2Setup.Memory == 0 {
+		argon2Setup.Memory = defaultArgon2Memory
+	}
+
+	if argon2Setup.Iterations == 0 {
+		argon2Setup.Iterations = defaultArgon2Iterations
+	}
+
+	if argon2Setup.Parallelism == 0 {
+		argon2Setup.Parallelism = defaultArgon2Parallelism
+	}
+
+	newArgon2 := Argon2{
+		memory:      argon2Setup.Memory,
+		iterations:  argon2Setup.Iterations,
+		parallelism: argon2Setup.Parallelism,
+		logger:      argon2Setup.Logger,
+	}
+
+	return &newArgon2
+}
+
+// Hash password using the argon2id hashing algorithm.
+func (a *Argon2) Hash(password string) ([]byte, error) {
+	salt := make([]byte, argon2SaltLength)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		a.logger.Error("generating salt for password", slog.Any("error", err.Error()))
+
+		return nil, errUnableToHashValue
+	}
+
+	newHash := argon2Hash{
+		version:     argon2.Version,
+		memory:      a.memory,
+		iterations:  a.iterations,
+		parallelism: a.parallelism,
+		salt:        salt,
+	}
+
+	newHash.key = argon2.IDKey([]byte(password), salt, a.iterations, a.memory, a.parallelism, argon2KeyLength)
+
+	return newHash.encode(), nil
+}
+
+// Verify checks if the given password matches the argon2id hashed password.
+func (a *Argon2) Verify(hashedPassword []byte, password string) bool {
+	storedHash, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return false
+	}
+
+	key := argon2.IDKey(
+		[]byte(password), storedHash.salt,
+		storedHash.iterations, storedHash.memory, storedHash.parallelism,
+		uint32(len(storedHash.key)),
+	)
+
+	return subtle.ConstantTimeCompare(storedHash.key, key) == 1
+}
+
+// NeedsRehash checks if the hashed password was not generated by argon2id
+// or its parameters are different from the current ones.
+func (a *Argon2) NeedsRehash(hashedPassword []byte) bool {
+	storedHash, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return storedHash.version != argon2.Version ||
+		storedHash.memory != a.memory ||
+		storedHash.iterations != a.iterations ||
+		storedHash.parallelism != a.parallelism
+}
+
+// Supports checks if the given hash was generated by argon2id.
+func (a *Argon2) Supports(hashedPassword []byte) bool {
+	return bytes.HasPrefix(hashedPassword, argon2idPrefix)
+}
+
+func (a argon2Hash) encode() []byte {
+	return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, a.version, a.memory, a.iterations, a.parallelism,
+		base64.RawStdEncoding.EncodeToString(a.salt),
+		base64.RawStdEncoding.EncodeToString(a.key),
+	))
+}
+
+func decodeArgon2Hash(hashedPassword []byte) (*argon2Hash, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", "salt", "hash"
+	parts := strings.Split(string(hashedPassword), "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, errInvalidArgon2Hash
+	}
+
+	var decodedHash argon2Hash
+
+	_, err := fmt.Sscanf(parts[2], "v=%d", &decodedHash.version)
+	if err != nil {
+		return nil, errInvalidArgon2Hash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&decodedHash.memory, &decodedHash.iterations, &decodedHash.parallelism)
+	if err != nil {
+		return nil, errInvalidArgon2Hash
+	}
+
+	decodedHash.salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, errInvalidArgon2Hash
+	}
+
+	decodedHash.key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(decodedHash.key) == 0 {
+		return nil, errInvalidArgon2Hash
+	}
+
+	return &decodedHash, nil
+}
@@ -0,0 +1,86 @@
+package cryptos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/adapters/cryptos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgon2HashPassword(t *testing.T) {
+	// Given
+	argon2Settings := cryptos.Argon2Setup{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		Logger:      newLogger(),
+	}
+	anArgon2 := cryptos.NewArgon2(argon2Settings)
+	rawPassword := "password1"
+
+	// When
+	hashedPassword, err := anArgon2.Hash(rawPassword)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(hashedPassword), "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.True(t, anArgon2.Supports(hashedPassword))
+}
+
+func TestArgon2VerifyPassword(t *testing.T) {
+	// Given
+	anArgon2 := cryptos.NewArgon2(cryptos.Argon2Setup{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		Logger:      newLogger(),
+	})
+	hashedPassword, err := anArgon2.Hash("password1")
+	assert.NoError(t, err)
+	// argon2id hash of "password" with salt "somesalt".
+	knownHash := []byte("$argon2id$v=19$m=1024,t=1,p=1$c29tZXNhbHQ$yOmu3JVvan3/Ck1ClA32KGI/Mo6hI1AFq6yTPFcJPiM")
+
+	// When
+	match := anArgon2.Verify(hashedPassword, "password1")
+	noMatch := anArgon2.Verify(hashedPassword, "password2")
+	knownMatch := anArgon2.Verify(knownHash, "password")
+	invalidHash := anArgon2.Verify([]byte("$argon2id$v=19$invalid"), "password1")
+
+	// Then
+	assert.True(t, match)
+	assert.False(t, noMatch)
+	assert.True(t, knownMatch)
+	assert.False(t, invalidHash)
+}
+
+func TestArgon2NeedsRehash(t *testing.T) {
+	// Given
+	oldArgon2 := cryptos.NewArgon2(cryptos.Argon2Setup{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		Logger:      newLogger(),
+	})
+	currentArgon2 := cryptos.NewArgon2(cryptos.Argon2Setup{
+		Memory:      1024,
+		Iterations:  2,
+		Parallelism: 1,
+		Logger:      newLogger(),
+	})
+	oldHash, err := oldArgon2.Hash("password1")
+	assert.NoError(t, err)
+	currentHash, err := currentArgon2.Hash("password1")
+	assert.NoError(t, err)
+	bcryptHash := []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
+
+	// When
+	oldNeedsRehash := currentArgon2.NeedsRehash(oldHash)
+	currentNeedsRehash := currentArgon2.NeedsRehash(currentHash)
+	bcryptNeedsRehash := currentArgon2.NeedsRehash(bcryptHash)
+
+	// Then
+	assert.True(t, oldNeedsRehash)
+	assert.False(t, currentNeedsRehash)
+	assert.True(t, bcryptNeedsRehash)
+}
@@ -1,6 +1,7 @@
 package cryptos
 
 import (
+	"bytes"
 	"errors"
 	"log/slog"
 
@@ -18,7 +19,11 @@ type Bcrypt struct {
 	logger *slog.Logger
 }
 
-var errUnableToHashValue = errors.New("unable to hash password")
+var (
+	bcryptPrefixes = [][]byte{[]byte("$2a$"), []byte("$2b$"), []byte("$2y$")}
+
+	errUnableToHashValue = errors.New("unable to hash password")
+)
 
 func NewBcrypt(bcryptSetup BcryptSetup) *Bcrypt {
 	if bcryptSetup.Cost < bcrypt.MinCost {
@@ -47,6 +52,35 @@ func (b *Bcrypt) Hash(password string) ([]byte, error) {
 	return hashedPasswordBytes, nil
 }
 
+// Verify checks if the given password matches the bcrypt hashed password.
+func (b *Bcrypt) Verify(hashedPassword []byte, password string) bool {
+	err := bcrypt.CompareHashAndPassword(hashedPassword, []byte(password))
+
+	return err == nil
+}
+
+// NeedsRehash checks if the hashed password was not generated by bcrypt
+// or its cost is lower than the current one.
+func (b *Bcrypt) NeedsRehash(hashedPassword []byte) bool {
+	cost, err := bcrypt.Cost(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return cost < b.cost
+}
+
+// Supports checks if the given hash was generated by bcrypt.
+func (b *Bcrypt) Supports(hashedPassword []byte) bool {
+	for _, prefix := range bcryptPrefixes {
+		if bytes.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Check if two passwords match using Bcrypt's CompareHashAndPassword
 // which return nil on success and an error on failure.
 func (b *Bcrypt) DoPasswordsMatch(hashedPassword, currPassword string) bool {
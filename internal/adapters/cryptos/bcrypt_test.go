@@ -28,6 +28,52 @@ func TestHashPassword(t *testing.T) {
 	assert.True(t, aBcrypt.DoPasswordsMatch(string(hashedPassword), rawPassword))
 }
 
+func TestVerifyPassword(t *testing.T) {
+	// Given
+	bcryptSettings := cryptos.BcryptSetup{
+		Cost:   bcrypt.MinCost,
+		Logger: newLogger(),
+	}
+	aBcrypt := cryptos.NewBcrypt(bcryptSettings)
+	hashedPassword, err := aBcrypt.Hash("password1")
+	assert.NoError(t, err)
+
+	// When
+	match := aBcrypt.Verify(hashedPassword, "password1")
+	noMatch := aBcrypt.Verify(hashedPassword, "password2")
+
+	// Then
+	assert.True(t, match)
+	assert.False(t, noMatch)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	// Given
+	oldBcrypt := cryptos.NewBcrypt(cryptos.BcryptSetup{
+		Cost:   bcrypt.MinCost,
+		Logger: newLogger(),
+	})
+	currentBcrypt := cryptos.NewBcrypt(cryptos.BcryptSetup{
+		Cost:   bcrypt.MinCost + 1,
+		Logger: newLogger(),
+	})
+	oldHash, err := oldBcrypt.Hash("password1")
+	assert.NoError(t, err)
+	currentHash, err := currentBcrypt.Hash("password1")
+	assert.NoError(t, err)
+	legacyHash := []byte("7c6a180b36896a0a8c02787eeafb0e4c")
+
+	// When
+	oldNeedsRehash := currentBcrypt.NeedsRehash(oldHash)
+	currentNeedsRehash := currentBcrypt.NeedsRehash(currentHash)
+	legacyNeedsRehash := currentBcrypt.NeedsRehash(legacyHash)
+
+	// Then
+	assert.True(t, oldNeedsRehash)
+	assert.False(t, currentNeedsRehash)
+	assert.True(t, legacyNeedsRehash)
+}
+
 func newLogger() *slog.Logger {
 	handlerOptions := &slog.HandlerOptions{
 		Level: slog.LevelDebug,
@@ -0,0 +1,84 @@
+package cryptos
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AlgorithmHasher defines the behavior of a hasher that can be selected by the composite hasher.
+type AlgorithmHasher interface {
+	// Hash hashes the given password.
+	Hash(password string) ([]byte, error)
+	// Verify checks if the given password matches the hashed password.
+	Verify(hashedPassword []byte, password string) bool
+	// NeedsRehash checks if the hashed password should be hashed again.
+	NeedsRehash(hashedPassword []byte) bool
+	// Supports checks if the hashed password was generated by this hasher.
+	Supports(hashedPassword []byte) bool
+}
+
+// supported hashing algorithms.
+const (
+	BcryptAlgorithm   = "bcrypt"
+	Argon2idAlgorithm = "argon2id"
+)
+
+type CompositeSetup struct {
+	// DefaultAlgorithm is the algorithm used to hash new passwords.
+	DefaultAlgorithm string
+	// Hashers contains the available hashers by algorithm.
+	Hashers map[string]AlgorithmHasher
+}
+
+// Composite hashes passwords with the default algorithm and verifies
+// them with the algorithm that generated the stored hash.
+type Composite struct {
+	defaultHasher AlgorithmHasher
+	hashers       []AlgorithmHasher
+}
+
+var errUnsupportedAlgorithm = errors.New("unsupported hashing algorithm")
+
+func NewComposite(compositeSetup CompositeSetup) (*Composite, error) {
+	defaultHasher, ok := compositeSetup.Hashers[compositeSetup.DefaultAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unable to create composite hasher: %w: %q", errUnsupportedAlgorithm, compositeSetup.DefaultAlgorithm)
+	}
+
+	newComposite := Composite{
+		defaultHasher: defaultHasher,
+		hashers:       make([]AlgorithmHasher, 0, len(compositeSetup.Hashers)),
+	}
+
+	for _, hasher := range compositeSetup.Hashers {
+		newComposite.hashers = append(newComposite.hashers, hasher)
+	}
+
+	return &newComposite, nil
+}
+
+// Hash password using the default algorithm.
+func (c *Composite) Hash(password string) ([]byte, error) {
+	return c.defaultHasher.Hash(password)
+}
+
+// Verify checks the password with the algorithm that generated the hashed password.
+func (c *Composite) Verify(hashedPassword []byte, password string) bool {
+	for _, hasher := range c.hashers {
+		if hasher.Supports(hashedPassword) {
+			return hasher.Verify(hashedPassword, password)
+		}
+	}
+
+	return false
+}
+
+// NeedsRehash checks if the hashed password was not generated by the default
+// algorithm or with its current settings.
+func (c *Composite) NeedsRehash(hashedPassword []byte) bool {
+	if !c.defaultHasher.Supports(hashedPassword) {
+		return true
+	}
+
+	return c.defaultHasher.NeedsRehash(hashedPassword)
+}
@@ -0,0 +1,75 @@
+package cryptos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/adapters/cryptos"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCompositeHashesWithDefaultAlgorithm(t *testing.T) {
+	// Given
+	aComposite, err := cryptos.NewComposite(compositeSetupFixture(cryptos.Argon2idAlgorithm))
+	assert.NoError(t, err)
+
+	// When
+	hashedPassword, err := aComposite.Hash("password1")
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(hashedPassword), "$argon2id$"))
+	assert.True(t, aComposite.Verify(hashedPassword, "password1"))
+	assert.False(t, aComposite.NeedsRehash(hashedPassword))
+}
+
+func TestCompositeVerifiesLegacyAlgorithm(t *testing.T) {
+	// Given
+	aComposite, err := cryptos.NewComposite(compositeSetupFixture(cryptos.Argon2idAlgorithm))
+	assert.NoError(t, err)
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password1"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	// When
+	match := aComposite.Verify(bcryptHash, "password1")
+	noMatch := aComposite.Verify(bcryptHash, "password2")
+	unknown := aComposite.Verify([]byte("7c6a180b36896a0a8c02787eeafb0e4c"), "password1")
+	needsRehash := aComposite.NeedsRehash(bcryptHash)
+
+	// Then
+	assert.True(t, match)
+	assert.False(t, noMatch)
+	assert.False(t, unknown)
+	assert.True(t, needsRehash)
+}
+
+func TestCompositeButUnsupportedAlgorithm(t *testing.T) {
+	// Given
+	setup := compositeSetupFixture("md5")
+
+	// When
+	aComposite, err := cryptos.NewComposite(setup)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, aComposite)
+}
+
+func compositeSetupFixture(defaultAlgorithm string) cryptos.CompositeSetup {
+	return cryptos.CompositeSetup{
+		DefaultAlgorithm: defaultAlgorithm,
+		Hashers: map[string]cryptos.AlgorithmHasher{
+			cryptos.BcryptAlgorithm: cryptos.NewBcrypt(cryptos.BcryptSetup{
+				Cost:   bcrypt.MinCost,
+				Logger: newLogger(),
+			}),
+			cryptos.Argon2idAlgorithm: cryptos.NewArgon2(cryptos.Argon2Setup{
+				Memory:      1024,
+				Iterations:  1,
+				Parallelism: 1,
+				Logger:      newLogger(),
+			}),
+		},
+	}
+}
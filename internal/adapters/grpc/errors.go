@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/fernandoocampo/players/internal/players"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const (
+	errorDomain               = "players"
+	playerAlreadyExistsReason = "PLAYER_ALREADY_EXISTS"
+	playerIDField             = "player_id"
+	offsetField               = "offset"
+)
+
+// errChangeStatusNotAllowed is returned to callers that are not admins and try to change the status of a player.
+var errChangeStatusNotAllowed = status.Error(codes.PermissionDenied, "only admins can change the status of players")
+
+// toStatusError translates errors returned by the player service into grpc status errors.
+// Only errors that are not known by the domain are reported as internal errors.
+func toStatusError(err error) error {
+	var alreadyExistsErr *players.PlayerAlreadyExistsError
+
+	switch {
+	case errors.Is(err, players.ErrInvalidPlayerData):
+		return newInvalidArgumentError(err.Error(), toFieldViolations(players.FieldErrors(err))...)
+	case errors.As(err, &alreadyExistsErr):
+		return newAlreadyExistsError(alreadyExistsErr)
+	case errors.Is(err, players.ErrPlayerDoesNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, players.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, players.ErrTooManyLoginAttempts):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, players.ErrPlayerVersionConflict),
+		errors.Is(err, players.ErrBatchAborted):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, players.ErrRestoreWindowExpired),
+		errors.Is(err, players.ErrInvalidStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, players.ErrPlayerIsNotActive),
+		errors.Is(err, players.ErrEmailSearchNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, players.ErrInvalidPlayerID):
+		return newInvalidPlayerIDError()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// newInvalidPlayerIDError creates an invalid argument error for a player id that is not a uuid.
+func newInvalidPlayerIDError() error {
+	violation := errdetails.BadRequest_FieldViolation{
+		Field:       playerIDField,
+		Description: "it must be a uuid",
+	}
+
+	return newInvalidArgumentError("request has invalid player id, it must be a uuid", &violation)
+}
+
+// newInvalidBatchPlayerIDError creates an invalid argument error for a player of a batch whose id is not a uuid.
+func newInvalidBatchPlayerIDError(index int) error {
+	violation := errdetails.BadRequest_FieldViolation{
+		Field:       batchPlayerField(index, playerIDField),
+		Description: "it must be a uuid",
+	}
+
+	return newInvalidArgumentError("request has invalid player id, it must be a uuid", &violation)
+}
+
+// newSearchOffsetTooLargeError creates an invalid argument error for search offsets that don't fit in the search criteria.
+func newSearchOffsetTooLargeError() error {
+	violation := errdetails.BadRequest_FieldViolation{
+		Field:       offsetField,
+		Description: fmt.Sprintf("offset is greater than %d", math.MaxUint16),
+	}
+
+	return newInvalidArgumentError(violation.GetDescription(), &violation)
+}
+
+// batchPlayerField returns the path of the field of a player of a batch.
+func batchPlayerField(index int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", players.PlayersField, index, field)
+}
+
+// newPasswordNotUpdatableError creates an invalid argument error for updates of old clients that
+// still send a password, the given field is the path of the password in the request.
+func newPasswordNotUpdatableError(field string) error {
+	violation := errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: "it cannot be updated, use ChangePassword",
+	}
+
+	return newInvalidArgumentError("request has a password, passwords are changed with ChangePassword", &violation)
+}
+
+func newInvalidArgumentError(message string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	newStatus := status.New(codes.InvalidArgument, message)
+
+	if len(violations) == 0 {
+		return newStatus.Err()
+	}
+
+	return withDetails(newStatus, &errdetails.BadRequest{FieldViolations: violations})
+}
+
+func newAlreadyExistsError(err *players.PlayerAlreadyExistsError) error {
+	newStatus := status.New(codes.AlreadyExists, err.Error())
+
+	errorInfo := errdetails.ErrorInfo{
+		Reason: playerAlreadyExistsReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			players.EmailField:    strconv.FormatBool(err.WasEmail),
+			players.NicknameField: strconv.FormatBool(err.WasNickname),
+		},
+	}
+
+	return withDetails(newStatus, &errorInfo)
+}
+
+func toFieldViolations(fieldErrors []*players.FieldError) []*errdetails.BadRequest_FieldViolation {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fieldErrors))
+
+	for _, fieldErr := range fieldErrors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Description,
+		})
+	}
+
+	return violations
+}
+
+// withDetails attaches the given details to the status, if that is not possible
+// the status is returned without details.
+func withDetails(newStatus *status.Status, details ...protoadapt.MessageV1) error {
+	statusWithDetails, err := newStatus.WithDetails(details...)
+	if err != nil {
+		return newStatus.Err()
+	}
+
+	return statusWithDetails.Err()
+}
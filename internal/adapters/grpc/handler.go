@@ -6,6 +6,7 @@ import (
 
 	"github.com/fernandoocampo/players/internal/players"
 	pb "github.com/fernandoocampo/players/pkg/pb/players"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -13,25 +14,48 @@ import (
 type PlayerService interface {
 	Create(ctx context.Context, newPlayer players.NewPlayer) (*players.Player, error)
 	Update(ctx context.Context, updatePlayer players.UpdatePlayer) (*players.Player, error)
-	Delete(ctx context.Context, playerID players.PlayerID) error
+	Delete(ctx context.Context, deletePlayer players.DeletePlayer) error
+	BatchCreate(ctx context.Context, batch players.BatchCreatePlayers) (*players.BatchResult, error)
+	BatchUpdate(ctx context.Context, batch players.BatchUpdatePlayers) (*players.BatchResult, error)
+	BatchDelete(ctx context.Context, batch players.BatchDeletePlayers) (*players.BatchResult, error)
+	Restore(ctx context.Context, playerID players.PlayerID) error
+	Get(ctx context.Context, playerID players.PlayerID) (*players.Player, error)
+	Authenticate(ctx context.Context, credentials players.Credentials) (*players.PlayerID, error)
+	GetLockState(ctx context.Context, playerID players.PlayerID) (*players.LockState, error)
+	Unlock(ctx context.Context, playerID players.PlayerID) error
+	ChangePassword(ctx context.Context, changePassword players.ChangePassword) error
+	RequestPasswordReset(ctx context.Context, request players.RequestPasswordReset) error
+	ConfirmPasswordReset(ctx context.Context, reset players.ConfirmPasswordReset) error
+	VerifyEmail(ctx context.Context, verifyEmail players.VerifyEmail) error
+	ChangeStatus(ctx context.Context, changeStatus players.ChangeStatus) error
 	List(ctx context.Context, searchCriteria players.SearchCriteria) (*players.SearchResult, error)
+	ListCountries(ctx context.Context) ([]players.CountryPlayers, error)
 }
 
 type HandlerSetup struct {
 	Service PlayerService
-	Logger  *slog.Logger
+	// AdminKey is sent by admins in the admin-key metadata, nobody is an admin if it is empty.
+	AdminKey string
+	// TrustForwardedFor takes the source of the requests from the x-forwarded-for metadata set by
+	// a gateway, only enable it if every request goes through a gateway that sets it.
+	TrustForwardedFor bool
+	Logger            *slog.Logger
 }
 
 type Handler struct {
 	pb.UnimplementedPlayerHandlerServer
-	service PlayerService
-	logger  *slog.Logger
+	service           PlayerService
+	adminKey          string
+	trustForwardedFor bool
+	logger            *slog.Logger
 }
 
 func NewHandler(setup HandlerSetup) *Handler {
 	newHandler := Handler{
-		service: setup.Service,
-		logger:  setup.Logger,
+		service:           setup.Service,
+		adminKey:          setup.AdminKey,
+		trustForwardedFor: setup.TrustForwardedFor,
+		logger:            setup.Logger,
 	}
 
 	return &newHandler
@@ -43,12 +67,14 @@ func (s *Handler) CreatePlayer(ctx context.Context, request *pb.CreatePlayerRequ
 		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
 	}
 
-	players, err := s.service.Create(ctx, toNewPlayer(request))
+	newPlayer := toNewPlayer(request, idempotencyKeyFromContext(ctx, request), sourceFromContext(ctx, s.trustForwardedFor))
+
+	player, err := s.service.Create(ctx, newPlayer)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, toStatusError(err)
 	}
 
-	return toCreatePlayerReply(players.ID), nil
+	return toCreatePlayerReply(player, request.GetMinimalReply()), nil
 }
 
 // UpdatePlayer updates a player.
@@ -58,20 +84,90 @@ func (s *Handler) UpdatePlayer(ctx context.Context, request *pb.UpdatePlayerRequ
 	}
 
 	if request.GetPlayerId() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "request missing required field: player id")
+		violation := errdetails.BadRequest_FieldViolation{
+			Field:       playerIDField,
+			Description: "it is required",
+		}
+
+		return nil, newInvalidArgumentError("request missing required field: player id", &violation)
+	}
+
+	if hasPassword(request) {
+		return nil, newPasswordNotUpdatableError(players.PasswordField)
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	player, err := s.service.Update(ctx, toUpdatePlayer(request, playerID))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toUpdatePlayerReply(player, request.GetMinimalReply()), nil
+}
+
+// ChangePassword changes the password of a player verifying its current password.
+func (s *Handler) ChangePassword(ctx context.Context, request *pb.ChangePasswordRequest) (*pb.ChangePasswordReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
 	}
 
 	playerID, err := players.StringToPlayerID(request.GetPlayerId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "request has invalid player id, it must be a uuid")
+		return nil, newInvalidPlayerIDError()
+	}
+
+	err = s.service.ChangePassword(ctx, toChangePassword(request, playerID, sourceFromContext(ctx, s.trustForwardedFor)))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return changePasswordReplyOK(), nil
+}
+
+// RequestPasswordReset sends a token to reset the password to the player with the given email.
+func (s *Handler) RequestPasswordReset(ctx context.Context, request *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	err := s.service.RequestPasswordReset(ctx, toRequestPasswordReset(request))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return requestPasswordResetReplyOK(), nil
+}
+
+// ConfirmPasswordReset resets the password of a player with a token.
+func (s *Handler) ConfirmPasswordReset(ctx context.Context, request *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	err := s.service.ConfirmPasswordReset(ctx, toConfirmPasswordReset(request))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return confirmPasswordResetReplyOK(), nil
+}
+
+// VerifyEmail verifies the email of a player with a token.
+func (s *Handler) VerifyEmail(ctx context.Context, request *pb.VerifyEmailRequest) (*pb.VerifyEmailReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
 	}
 
-	_, err = s.service.Update(ctx, toUpdatePlayer(request, playerID))
+	err := s.service.VerifyEmail(ctx, toVerifyEmail(request))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, toStatusError(err)
 	}
 
-	return updatePlayerReplyOK(), nil
+	return verifyEmailReplyOK(), nil
 }
 
 // DeletePlayer deletes a player.
@@ -82,27 +178,261 @@ func (s *Handler) DeletePlayer(ctx context.Context, request *pb.DeletePlayerRequ
 
 	playerID, err := players.StringToPlayerID(request.GetPlayerId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "request has invalid player id, it must be a uuid")
+		return nil, newInvalidPlayerIDError()
 	}
 
-	err = s.service.Delete(ctx, *playerID)
+	err = s.service.Delete(ctx, toDeletePlayer(request, playerID))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, toStatusError(err)
 	}
 
 	return deletePlayerReplyOK(), nil
 }
 
+// BatchCreatePlayers creates players in batch, the reply contains the result of every player.
+func (s *Handler) BatchCreatePlayers(ctx context.Context, request *pb.BatchCreatePlayersRequest) (*pb.BatchCreatePlayersReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	result, err := s.service.BatchCreate(ctx, toBatchCreatePlayers(request))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toBatchCreatePlayersReply(result, request.GetMinimalReply()), nil
+}
+
+// BatchUpdatePlayers updates players in batch, the reply contains the result of every player.
+func (s *Handler) BatchUpdatePlayers(ctx context.Context, request *pb.BatchUpdatePlayersRequest) (*pb.BatchUpdatePlayersReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	batch, err := toBatchUpdatePlayers(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.BatchUpdate(ctx, batch)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toBatchUpdatePlayersReply(result, request.GetMinimalReply()), nil
+}
+
+// BatchDeletePlayers deletes players in batch, the reply contains the result of every player.
+func (s *Handler) BatchDeletePlayers(ctx context.Context, request *pb.BatchDeletePlayersRequest) (*pb.BatchDeletePlayersReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	batch, err := toBatchDeletePlayers(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.BatchDelete(ctx, batch)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toBatchDeletePlayersReply(result), nil
+}
+
+// RestorePlayer restores a deleted player, only admins can restore players.
+func (s *Handler) RestorePlayer(ctx context.Context, request *pb.RestorePlayerRequest) (*pb.RestorePlayerReply, error) {
+	if request == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	if !isAdminContext(ctx, s.adminKey) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can restore players")
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	err = s.service.Restore(ctx, *playerID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return restorePlayerReplyOK(), nil
+}
+
 // SearchPlayers searches a player.
 func (s *Handler) SearchPlayers(ctx context.Context, request *pb.SearchPlayersRequest) (*pb.SearchPlayersReply, error) {
 	if request == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
 	}
 
-	result, err := s.service.List(ctx, toSearchCriteria(request))
+	err := validateSearchPagination(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.List(ctx, toSearchCriteria(request, isAdminContext(ctx, s.adminKey)))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, toStatusError(err)
 	}
 
 	return toSearchPlayerReply(result), nil
 }
+
+// GetPlayer gets a player.
+func (s *Handler) GetPlayer(ctx context.Context, request *pb.GetPlayerRequest) (*pb.GetPlayerReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	player, err := s.service.Get(ctx, *playerID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toGetPlayerReply(player), nil
+}
+
+// AuthenticatePlayer verifies player credentials. Wrong credentials are Unauthenticated, the right
+// credentials of a player that is not active are PermissionDenied.
+func (s *Handler) AuthenticatePlayer(ctx context.Context, request *pb.AuthenticatePlayerRequest) (*pb.AuthenticatePlayerReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	playerID, err := s.service.Authenticate(ctx, toCredentials(request, sourceFromContext(ctx, s.trustForwardedFor)))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toAuthenticatePlayerReply(playerID), nil
+}
+
+// GetPlayerLockState gets the lock state of a player.
+func (s *Handler) GetPlayerLockState(ctx context.Context, request *pb.GetPlayerLockStateRequest) (*pb.GetPlayerLockStateReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	if !isAdminContext(ctx, s.adminKey) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can get the lock state of players")
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	lockState, err := s.service.GetLockState(ctx, *playerID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toGetPlayerLockStateReply(lockState), nil
+}
+
+// UnlockPlayer unlocks a player, only admins can unlock players.
+func (s *Handler) UnlockPlayer(ctx context.Context, request *pb.UnlockPlayerRequest) (*pb.UnlockPlayerReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	if !isAdminContext(ctx, s.adminKey) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can unlock players")
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	err = s.service.Unlock(ctx, *playerID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return unlockPlayerReplyOK(), nil
+}
+
+// ActivatePlayer activates a disabled, suspended or banned player, only admins can change the status of players.
+func (s *Handler) ActivatePlayer(ctx context.Context, request *pb.ChangePlayerStatusRequest) (*pb.ChangePlayerStatusReply, error) {
+	return s.changePlayerStatus(ctx, request, players.StatusActive)
+}
+
+// DisablePlayer disables a player, only admins can change the status of players.
+func (s *Handler) DisablePlayer(ctx context.Context, request *pb.ChangePlayerStatusRequest) (*pb.ChangePlayerStatusReply, error) {
+	return s.changePlayerStatus(ctx, request, players.StatusDisabled)
+}
+
+// BanPlayer bans a player, only admins can change the status of players.
+func (s *Handler) BanPlayer(ctx context.Context, request *pb.ChangePlayerStatusRequest) (*pb.ChangePlayerStatusReply, error) {
+	return s.changePlayerStatus(ctx, request, players.StatusBanned)
+}
+
+// SuspendPlayer suspends a player until the given date, only admins can change the status of players.
+func (s *Handler) SuspendPlayer(ctx context.Context, request *pb.SuspendPlayerRequest) (*pb.ChangePlayerStatusReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	if !isAdminContext(ctx, s.adminKey) {
+		return nil, errChangeStatusNotAllowed
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	err = s.service.ChangeStatus(ctx, toSuspendChangeStatus(request, playerID, adminActor(sourceFromContext(ctx, s.trustForwardedFor))))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return changePlayerStatusReplyOK(), nil
+}
+
+func (s *Handler) changePlayerStatus(ctx context.Context, request *pb.ChangePlayerStatusRequest, newStatus players.Status) (*pb.ChangePlayerStatusReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	if !isAdminContext(ctx, s.adminKey) {
+		return nil, errChangeStatusNotAllowed
+	}
+
+	playerID, err := players.StringToPlayerID(request.GetPlayerId())
+	if err != nil {
+		return nil, newInvalidPlayerIDError()
+	}
+
+	err = s.service.ChangeStatus(ctx, toChangeStatus(request, playerID, newStatus, adminActor(sourceFromContext(ctx, s.trustForwardedFor))))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return changePlayerStatusReplyOK(), nil
+}
+
+// ListCountries lists the supported countries with their number of players.
+func (s *Handler) ListCountries(ctx context.Context, request *pb.ListCountriesRequest) (*pb.ListCountriesReply, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be empty")
+	}
+
+	countries, err := s.service.ListCountries(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toListCountriesReply(countries), nil
+}
@@ -2,6 +2,9 @@ package grpc_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -12,6 +15,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestCreatePlayer(t *testing.T) {
@@ -19,6 +31,7 @@ func TestCreatePlayer(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := players.ToPlayerID(uuid.New())
+	dateCreated := time.Date(2024, time.October, 10, 8, 30, 0, 0, time.UTC)
 	givenPlayerResult := players.Player{
 		ID:          playerID,
 		FirstName:   "Fernando",
@@ -26,9 +39,13 @@ func TestCreatePlayer(t *testing.T) {
 		Nickname:    "focampo",
 		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
 		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
-		Country:     "Spain",
-		DateCreated: time.Now().UTC(),
-		DateUpdated: time.Now().UTC(),
+		Country:     "ES",
+		DateCreated: dateCreated,
+		DateUpdated: dateCreated,
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+		Version: 1,
 	}
 	newPlayerRequest := newCreatePlayerFixture()
 	service := newServiceMock()
@@ -36,6 +53,18 @@ func TestCreatePlayer(t *testing.T) {
 	server := newGRPCHandler(service)
 	want := pb.CreatePlayerReply{
 		PlayerId: playerID.String(),
+		Player: &pb.Player{
+			Id:          playerID.String(),
+			Firstname:   "Fernando",
+			Lastname:    "Ocampo",
+			Nickname:    "focampo",
+			Email:       "focampo@anyemail.com",
+			Country:     "ES",
+			DateCreated: timestamppb.New(dateCreated),
+			DateUpdated: timestamppb.New(dateCreated),
+			Status:      "active",
+			Version:     1,
+		},
 	}
 
 	// When
@@ -43,7 +72,106 @@ func TestCreatePlayer(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Equal(t, &want, reply)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestCreatePlayerWithMinimalReply(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	newPlayerRequest := newCreatePlayerFixture()
+	newPlayerRequest.MinimalReply = true
+	service := newServiceMock()
+	service.On("Create", ctx, mock.AnythingOfType("players.NewPlayer")).
+		Return(&players.Player{ID: playerID, Nickname: "focampo", DateCreated: time.Now().UTC()}, nil)
+	server := newGRPCHandler(service)
+	want := pb.CreatePlayerReply{
+		PlayerId: playerID.String(),
+	}
+
+	// When
+	reply, err := server.CreatePlayer(ctx, newPlayerRequest)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestCreatePlayerWithIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		requestKey  string
+		metadataKey string
+		want        string
+	}{
+		"request_field": {
+			requestKey: "request-key",
+			want:       "request-key",
+		},
+		"metadata": {
+			metadataKey: "metadata-key",
+			want:        "metadata-key",
+		},
+		"request_field_takes_precedence": {
+			requestKey:  "request-key",
+			metadataKey: "metadata-key",
+			want:        "request-key",
+		},
+		"without_key": {},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := peer.NewContext(context.TODO(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 5678}})
+			if data.metadataKey != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", data.metadataKey))
+			}
+
+			playerID := players.ToPlayerID(uuid.New())
+			newPlayerRequest := newCreatePlayerFixture()
+			newPlayerRequest.IdempotencyKey = data.requestKey
+
+			var gotNewPlayer players.NewPlayer
+
+			service := newServiceMock()
+			service.On("Create", ctx, mock.AnythingOfType("players.NewPlayer")).
+				Run(func(args mock.Arguments) { gotNewPlayer = args.Get(1).(players.NewPlayer) }).
+				Return(&players.Player{ID: playerID}, nil)
+			server := newGRPCHandler(service)
+
+			// When
+			reply, err := server.CreatePlayer(ctx, newPlayerRequest)
+
+			// Then
+			require.NoError(st, err)
+			assert.Equal(st, playerID.String(), reply.GetPlayerId())
+			assert.Equal(st, data.want, gotNewPlayer.IdempotencyKey)
+			assert.Equal(st, "192.0.2.10", gotNewPlayer.Source)
+		})
+	}
+}
+
+func TestCreatePlayerButIdempotencyKeyReused(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayerRequest := newCreatePlayerFixture()
+	newPlayerRequest.IdempotencyKey = "request-key"
+	service := newServiceMock()
+	service.On("Create", ctx, mock.AnythingOfType("players.NewPlayer")).
+		Return(nil, fmt.Errorf("unable to create player: %w", players.ErrIdempotencyKeyReused))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.CreatePlayer(ctx, newPlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestUpdatePlayer(t *testing.T) {
@@ -51,6 +179,8 @@ func TestUpdatePlayer(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := players.ToPlayerID(uuid.New())
+	dateCreated := time.Date(2024, time.October, 10, 8, 30, 0, 0, time.UTC)
+	dateUpdated := dateCreated.Add(time.Hour)
 	givenPlayerResult := players.Player{
 		ID:          playerID,
 		FirstName:   "Fernando",
@@ -58,16 +188,35 @@ func TestUpdatePlayer(t *testing.T) {
 		Nickname:    "focampo",
 		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
 		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
-		Country:     "Spain",
-		DateCreated: time.Now().UTC(),
-		DateUpdated: time.Now().UTC(),
+		Country:     "ES",
+		DateCreated: dateCreated,
+		DateUpdated: dateUpdated,
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+		Version:      4,
+		PendingEmail: unittests.NewEmailAddress(t, "fernando@anyemail.com"),
 	}
 	updatePlayerRequest := newUpdatePlayerFixture(playerID)
 	service := newServiceMock()
 	service.On("Update", ctx, mock.AnythingOfType("players.UpdatePlayer")).Return(&givenPlayerResult, nil)
 	server := newGRPCHandler(service)
 	want := pb.UpdatePlayerReply{
-		Ok: true,
+		Ok:      true,
+		Version: 4,
+		Player: &pb.Player{
+			Id:           playerID.String(),
+			Firstname:    "Fernando",
+			Lastname:     "Ocampo",
+			Nickname:     "focampo",
+			Email:        "focampo@anyemail.com",
+			Country:      "ES",
+			DateCreated:  timestamppb.New(dateCreated),
+			DateUpdated:  timestamppb.New(dateUpdated),
+			Status:       "active",
+			Version:      4,
+			PendingEmail: "fernando@anyemail.com",
+		},
 	}
 
 	// When
@@ -75,19 +224,158 @@ func TestUpdatePlayer(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Equal(t, &want, reply)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestUpdatePlayerWithMinimalReply(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	updatePlayerRequest := newUpdatePlayerFixture(playerID)
+	updatePlayerRequest.MinimalReply = true
+	service := newServiceMock()
+	service.On("Update", ctx, mock.AnythingOfType("players.UpdatePlayer")).
+		Return(&players.Player{ID: playerID, Nickname: "focampo", Version: 4}, nil)
+	server := newGRPCHandler(service)
+	want := pb.UpdatePlayerReply{
+		Ok:      true,
+		Version: 4,
+	}
+
+	// When
+	reply, err := server.UpdatePlayer(ctx, updatePlayerRequest)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestUpdatePlayerWithUpdateMask(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	updatePlayerRequest := pb.UpdatePlayerRequest{
+		PlayerId:   playerID.String(),
+		Firstname:  proto.String(""),
+		Lastname:   proto.String("Restrepo"),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"lastname", "country"}},
+	}
+	wantUpdatePlayer := players.UpdatePlayer{
+		ID:         *playerID,
+		FirstName:  players.NewString(""),
+		LastName:   players.NewString("Restrepo"),
+		UpdateMask: []string{"lastname", "country"},
+	}
+	service := newServiceMock()
+	service.On("Update", ctx, wantUpdatePlayer).Return(&players.Player{ID: playerID, Version: 5}, nil)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.UpdatePlayer(ctx, &updatePlayerRequest)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), reply.GetVersion())
+}
+
+func TestUpdatePlayerButUnknownUpdateMaskPath(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	updatePlayerRequest := newUpdatePlayerFixture(playerID)
+	updatePlayerRequest.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"password"}}
+	service := newServiceMock()
+	service.On("Update", ctx, mock.AnythingOfType("players.UpdatePlayer")).
+		Return(nil, fmt.Errorf("unable to update player: %w", players.UpdatePlayer{UpdateMask: []string{"password"}}.Validate()))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.UpdatePlayer(ctx, updatePlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "update_mask", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestUpdatePlayerButPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	updatePlayerRequest := newUpdatePlayerFixture(playerID)
+	updatePlayerRequest.Password = "n3wp4ssword"
+	service := newServiceMock()
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.UpdatePlayer(ctx, updatePlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "password", badRequest.GetFieldViolations()[0].GetField())
+	service.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestBatchUpdatePlayersButPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	withPassword := newUpdatePlayerFixture(players.ToPlayerID(uuid.New()))
+	withPassword.Password = "n3wp4ssword"
+	request := pb.BatchUpdatePlayersRequest{
+		Players: []*pb.UpdatePlayerRequest{newUpdatePlayerFixture(players.ToPlayerID(uuid.New())), withPassword},
+	}
+	service := newServiceMock()
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.BatchUpdatePlayers(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "players[1].password", badRequest.GetFieldViolations()[0].GetField())
+	service.AssertNotCalled(t, "BatchUpdate", mock.Anything, mock.Anything)
 }
 
 func TestDeletePlayer(t *testing.T) {
 	t.Parallel()
 	// Given
 	ctx := context.TODO()
-	playerID := uuid.New().String()
+	playerID := players.ToPlayerID(uuid.New())
 	deletePlayerRequest := pb.DeletePlayerRequest{
-		PlayerId: playerID,
+		PlayerId:        playerID.String(),
+		ExpectedVersion: 3,
+	}
+	expectedDeletePlayer := players.DeletePlayer{
+		ID:              *playerID,
+		ExpectedVersion: 3,
 	}
 	service := newServiceMock()
-	service.On("Delete", ctx, mock.AnythingOfType("players.PlayerID")).Return(nil)
+	service.On("Delete", ctx, expectedDeletePlayer).Return(nil)
 	server := newGRPCHandler(service)
 	want := pb.DeletePlayerReply{
 		Ok: true,
@@ -134,63 +422,1335 @@ func TestSearchPlayer(t *testing.T) {
 	assert.Equal(t, &want, reply)
 }
 
-type MockService struct {
-	mock.Mock
-}
+func TestSearchPlayersWithFilters(t *testing.T) {
+	t.Parallel()
 
-func newServiceMock() *MockService {
-	return &MockService{}
+	testCases := map[string]struct {
+		adminKey  string
+		wantAdmin bool
+	}{
+		"admin_key": {
+			adminKey:  "any-admin-key",
+			wantAdmin: true,
+		},
+		"wrong_admin_key": {
+			adminKey:  "another-key",
+			wantAdmin: false,
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", data.adminKey))
+			createdFrom := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+			updatedTo := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+			searchRequest := pb.SearchPlayersRequest{
+				NicknamePrefix:  "foc",
+				Firstname:       "fern",
+				Lastname:        "camp",
+				Email:           "focampo@anyemail.com",
+				Fuzzy:           "fernado",
+				DateCreatedFrom: timestamppb.New(createdFrom),
+				DateUpdatedTo:   timestamppb.New(updatedTo),
+				Status:          "active",
+				Limit:           3,
+			}
+			activeStatus := players.StatusActive
+			wantCriteria := players.SearchCriteria{
+				Country:        players.NewString(""),
+				Status:         &activeStatus,
+				NicknamePrefix: players.NewString("foc"),
+				FirstName:      players.NewString("fern"),
+				LastName:       players.NewString("camp"),
+				Email:          players.NewString("focampo@anyemail.com"),
+				Fuzzy:          players.NewString("fernado"),
+				DateCreated:    players.DateRange{From: createdFrom},
+				DateUpdated:    players.DateRange{To: updatedTo},
+				Admin:          data.wantAdmin,
+				Limit:          3,
+			}
+			service := newServiceMock()
+			service.On("List", ctx, wantCriteria).Return(&players.SearchResult{Limit: 3}, nil)
+			server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+
+			// When
+			reply, err := server.SearchPlayers(ctx, &searchRequest)
+
+			// Then
+			require.NoError(st, err)
+			assert.Equal(st, uint32(3), reply.GetLimit())
+			service.AssertExpectations(st)
+		})
+	}
 }
 
-func (m *MockService) Create(ctx context.Context, newPlayer players.NewPlayer) (*players.Player, error) {
-	args := m.Called(ctx, newPlayer)
+func TestSearchPlayersSorted(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchRequest := pb.SearchPlayersRequest{
+		Country: "CO",
+		Sort: []*pb.SortKey{
+			{Field: "date_created", Direction: pb.SortDirection_SORT_DIRECTION_DESC},
+			{Field: "nickname"},
+		},
+	}
+	wantSort := []players.SortKey{
+		{Field: players.SortByDateCreated, Descending: true},
+		{Field: players.SortByNickname},
+	}
+	service := newServiceMock()
+	service.On("List", ctx, mock.MatchedBy(func(criteria players.SearchCriteria) bool {
+		return assert.ObjectsAreEqual(wantSort, criteria.Sort)
+	})).Return(&players.SearchResult{}, nil)
+	server := newGRPCHandler(service)
 
-	return args.Get(0).(*players.Player), args.Error(1)
+	// When
+	_, err := server.SearchPlayers(ctx, &searchRequest)
+
+	// Then
+	assert.NoError(t, err)
+	service.AssertExpectations(t)
 }
 
-func (m *MockService) Update(ctx context.Context, updatePlayer players.UpdatePlayer) (*players.Player, error) {
-	args := m.Called(ctx, updatePlayer)
+func TestSearchPlayersWithPageToken(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchRequest := pb.SearchPlayersRequest{
+		Country:   "CO",
+		Limit:     2,
+		PageToken: "first-page-token",
+	}
+	service := newServiceMock()
+	service.On("List", ctx, mock.MatchedBy(func(criteria players.SearchCriteria) bool {
+		return criteria.PageToken == "first-page-token"
+	})).Return(&players.SearchResult{Limit: 2, NextPageToken: "second-page-token"}, nil)
+	server := newGRPCHandler(service)
 
-	return args.Get(0).(*players.Player), args.Error(1)
+	// When
+	reply, err := server.SearchPlayers(ctx, &searchRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "second-page-token", reply.GetNextPageToken())
+	service.AssertExpectations(t)
 }
 
-func (m *MockService) Delete(ctx context.Context, playerID players.PlayerID) error {
-	args := m.Called(ctx, playerID)
+func TestSearchPlayersListingAll(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchRequest := pb.SearchPlayersRequest{
+		Limit:     50,
+		ListAll:   true,
+		SkipTotal: true,
+	}
+	service := newServiceMock()
+	service.On("List", ctx, mock.MatchedBy(func(criteria players.SearchCriteria) bool {
+		return criteria.ListAll && criteria.SkipTotal && criteria.Limit == 50
+	})).Return(&players.SearchResult{Limit: 50}, nil)
+	server := newGRPCHandler(service)
 
-	return args.Error(0)
+	// When
+	_, err := server.SearchPlayers(ctx, &searchRequest)
+
+	// Then
+	assert.NoError(t, err)
+	service.AssertExpectations(t)
 }
 
-func (m *MockService) List(ctx context.Context, searchCriteria players.SearchCriteria) (*players.SearchResult, error) {
-	args := m.Called(ctx, searchCriteria)
+func TestSearchPlayersFuzzy(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchRequest := pb.SearchPlayersRequest{
+		Fuzzy: "jraynr",
+	}
+	items := unittests.SearchPlayersResultFixture(t)[:1]
+	items[0].Score = 0.625
+	service := newServiceMock()
+	service.On("List", ctx, mock.MatchedBy(func(criteria players.SearchCriteria) bool {
+		return criteria.Fuzzy != nil && *criteria.Fuzzy == "jraynr"
+	})).Return(&players.SearchResult{Total: 1, Items: items, Limit: 5}, nil)
+	server := newGRPCHandler(service)
 
-	return args.Get(0).(*players.SearchResult), args.Error(1)
+	// When
+	reply, err := server.SearchPlayers(ctx, &searchRequest)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, reply.GetPlayerItems(), 1)
+	assert.Equal(t, "jraynor", reply.GetPlayerItems()[0].GetNickname())
+	assert.InDelta(t, 0.625, reply.GetPlayerItems()[0].GetScore(), 0.0001)
+	service.AssertExpectations(t)
 }
 
-func newGRPCHandler(service *MockService) *grpc.Handler {
-	handlerSetup := grpc.HandlerSetup{
-		Service: service,
-		Logger:  unittests.NewLogger(),
+func TestSearchPlayersByEmailButNotAdmin(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchRequest := pb.SearchPlayersRequest{
+		Email: "focampo@anyemail.com",
 	}
+	service := newServiceMock()
+	service.On("List", ctx, mock.AnythingOfType("players.SearchCriteria")).
+		Return(nil, fmt.Errorf("unable to list players: %w", players.ErrEmailSearchNotAllowed))
+	server := newGRPCHandler(service)
 
-	return grpc.NewHandler(handlerSetup)
+	// When
+	reply, err := server.SearchPlayers(ctx, &searchRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
 }
 
-func newCreatePlayerFixture() *pb.CreatePlayerRequest {
-	return &pb.CreatePlayerRequest{
-		Firstname: "Fernando",
-		Lastname:  "Ocampo",
-		Nickname:  "focampo",
-		Email:     "focampo@anyemail.com",
-		Password:  "th1s1s@dummypwd",
-		Country:   "Spain",
+func TestGetPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	dateCreated := time.Date(2024, time.October, 10, 8, 30, 0, 0, time.UTC)
+	givenPlayerResult := players.Player{
+		ID:          playerID,
+		FirstName:   "Fernando",
+		LastName:    "Ocampo",
+		Nickname:    "focampo",
+		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
+		Country:     "Spain",
+		DateCreated: dateCreated,
+		DateUpdated: dateCreated,
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+	}
+	getPlayerRequest := pb.GetPlayerRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("Get", ctx, *playerID).Return(&givenPlayerResult, nil)
+	server := newGRPCHandler(service)
+	want := pb.GetPlayerReply{
+		Player: &pb.Player{
+			Id:          playerID.String(),
+			Firstname:   "Fernando",
+			Lastname:    "Ocampo",
+			Nickname:    "focampo",
+			Email:       "focampo@anyemail.com",
+			Country:     "Spain",
+			DateCreated: timestamppb.New(dateCreated),
+			DateUpdated: timestamppb.New(dateCreated),
+			Status:      "active",
+		},
 	}
+
+	// When
+	reply, err := server.GetPlayer(ctx, &getPlayerRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply))
 }
 
-func newUpdatePlayerFixture(playerID *players.PlayerID) *pb.UpdatePlayerRequest {
-	return &pb.UpdatePlayerRequest{
-		PlayerId:  playerID.String(),
-		Firstname: "Fernando",
-		Lastname:  "Ocampo",
+func TestCreatePlayerButInvalidData(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayerRequest := newCreatePlayerFixture()
+	validationErr := fmt.Errorf("unable to create player: %w", errors.Join(players.ErrEmptyNickname, players.ErrEmptyPassword))
+	service := newServiceMock()
+	service.On("Create", ctx, mock.AnythingOfType("players.NewPlayer")).Return(nil, validationErr)
+	server := newGRPCHandler(service)
+	want := []*errdetails.BadRequest_FieldViolation{
+		{Field: "nickname", Description: "nickname is empty"},
+		{Field: "password", Description: "password is empty"},
+	}
+
+	// When
+	reply, err := server.CreatePlayer(ctx, newPlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), len(want))
+
+	for i, violation := range badRequest.GetFieldViolations() {
+		assert.True(t, proto.Equal(want[i], violation))
+	}
+}
+
+func TestCreatePlayerButAlreadyExists(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayerRequest := newCreatePlayerFixture()
+	alreadyExistsErr := players.PlayerAlreadyExistsError{WasEmail: true}
+	service := newServiceMock()
+	service.On("Create", ctx, mock.AnythingOfType("players.NewPlayer")).Return(nil, &alreadyExistsErr)
+	server := newGRPCHandler(service)
+	wantMetadata := map[string]string{
+		"email":    "true",
+		"nickname": "false",
+	}
+
+	// When
+	reply, err := server.CreatePlayer(ctx, newPlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "PLAYER_ALREADY_EXISTS", errorInfo.GetReason())
+	assert.Equal(t, wantMetadata, errorInfo.GetMetadata())
+}
+
+func TestGetPlayerButDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	getPlayerRequest := pb.GetPlayerRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("Get", ctx, *playerID).Return(nil, players.ErrPlayerDoesNotExist)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.GetPlayer(ctx, &getPlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetPlayerButInvalidID(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	getPlayerRequest := pb.GetPlayerRequest{
+		PlayerId: "invalid",
+	}
+	service := newServiceMock()
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.GetPlayer(ctx, &getPlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	service.AssertNotCalled(t, "Get")
+}
+
+func TestDeletePlayerButInfrastructureError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	deletePlayerRequest := pb.DeletePlayerRequest{
+		PlayerId: uuid.New().String(),
+	}
+	service := newServiceMock()
+	service.On("Delete", ctx, mock.AnythingOfType("players.DeletePlayer")).Return(errors.New("unable to delete player: player cannot be deleted"))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.DeletePlayer(ctx, &deletePlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUpdatePlayerButVersionConflict(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	updatePlayerRequest := newUpdatePlayerFixture(playerID)
+	updatePlayerRequest.ExpectedVersion = 2
+	service := newServiceMock()
+	service.On("Update", ctx, mock.AnythingOfType("players.UpdatePlayer")).
+		Return(nil, fmt.Errorf("unable to update player: %w", players.ErrPlayerVersionConflict))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.UpdatePlayer(ctx, updatePlayerRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestAuthenticatePlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+	givenCredentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+	service := newServiceMock()
+	service.On("Authenticate", ctx, givenCredentials).Return(playerID, nil)
+	server := newGRPCHandler(service)
+	want := pb.AuthenticatePlayerReply{
+		PlayerId: playerID.String(),
+	}
+
+	// When
+	reply, err := server.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestAuthenticatePlayerSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		trustForwardedFor bool
+		forwardedFor      []string
+		want              string
+	}{
+		"peer_address": {
+			forwardedFor: []string{"203.0.113.7"},
+			want:         "192.0.2.10",
+		},
+		"forwarded_address_set_by_the_gateway": {
+			trustForwardedFor: true,
+			forwardedFor:      []string{"203.0.113.7, 198.51.100.2"},
+			want:              "198.51.100.2",
+		},
+		"peer_address_without_forwarded_address": {
+			trustForwardedFor: true,
+			want:              "192.0.2.10",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := peer.NewContext(context.TODO(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 5678}})
+			for _, forwardedFor := range data.forwardedFor {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+			}
+
+			authenticateRequest := pb.AuthenticatePlayerRequest{
+				Login:    "focampo",
+				Password: "th1s1s@dummypwd",
+			}
+
+			var gotCredentials players.Credentials
+
+			service := newServiceMock()
+			service.On("Authenticate", ctx, mock.AnythingOfType("players.Credentials")).
+				Run(func(args mock.Arguments) { gotCredentials = args.Get(1).(players.Credentials) }).
+				Return(players.ToPlayerID(uuid.New()), nil)
+			server := grpc.NewHandler(grpc.HandlerSetup{
+				Service:           service,
+				TrustForwardedFor: data.trustForwardedFor,
+				Logger:            unittests.NewLogger(),
+			})
+
+			// When
+			_, err := server.AuthenticatePlayer(ctx, &authenticateRequest)
+
+			// Then
+			require.NoError(st, err)
+			assert.Equal(st, data.want, gotCredentials.Source)
+		})
+	}
+}
+
+func TestAuthenticatePlayerButInvalidCredentials(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    "focampo",
+		Password: "wrongpwd",
+	}
+	service := newServiceMock()
+	service.On("Authenticate", ctx, mock.AnythingOfType("players.Credentials")).Return(nil, players.ErrInvalidCredentials)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGetPlayerLockState(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	lockedUntil := time.Now().UTC().Add(time.Hour)
+	givenLockState := players.LockState{
+		FailedAttempts: 5,
+		LockedUntil:    &lockedUntil,
+	}
+	request := pb.GetPlayerLockStateRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("GetLockState", ctx, *playerID).Return(&givenLockState, nil)
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+	want := pb.GetPlayerLockStateReply{
+		Locked:         true,
+		FailedAttempts: 5,
+		LockedUntil:    timestamppb.New(lockedUntil),
+	}
+
+	// When
+	reply, err := server.GetPlayerLockState(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestUnlockPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.UnlockPlayerRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("Unlock", ctx, *playerID).Return(nil)
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+	want := pb.UnlockPlayerReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.UnlockPlayer(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestUnlockPlayerButNotAdmin(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		adminKey string
+	}{
+		"without_admin_key": {},
+		"wrong_admin_key": {
+			adminKey: "other-admin-key",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", data.adminKey))
+			request := pb.UnlockPlayerRequest{
+				PlayerId: uuid.NewString(),
+			}
+			service := newServiceMock()
+			server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+
+			// When
+			reply, err := server.UnlockPlayer(ctx, &request)
+
+			// Then
+			assert.Nil(st, reply)
+			assert.Equal(st, codes.PermissionDenied, status.Code(err))
+			service.AssertNotCalled(st, "Unlock", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticatePlayerButTooManyAttempts(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    "focampo",
+		Password: "wrongpwd",
+	}
+	service := newServiceMock()
+	service.On("Authenticate", ctx, mock.AnythingOfType("players.Credentials")).Return(nil, players.ErrTooManyLoginAttempts)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRestorePlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.RestorePlayerRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("Restore", ctx, *playerID).Return(nil)
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+	want := pb.RestorePlayerReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.RestorePlayer(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestRestorePlayerButRetentionWindowExpired(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.RestorePlayerRequest{
+		PlayerId: playerID.String(),
+	}
+	service := newServiceMock()
+	service.On("Restore", ctx, *playerID).Return(fmt.Errorf("unable to restore player: %w", players.ErrRestoreWindowExpired))
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+
+	// When
+	reply, err := server.RestorePlayer(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestSuspendPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(
+		peer.NewContext(context.TODO(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 5678}}),
+		metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	suspendedUntil := time.Date(2030, time.January, 10, 8, 30, 0, 0, time.UTC)
+	request := pb.SuspendPlayerRequest{
+		PlayerId:       playerID.String(),
+		Reason:         "abusive language",
+		SuspendedUntil: timestamppb.New(suspendedUntil),
+	}
+	expectedChangeStatus := players.ChangeStatus{
+		PlayerID:       *playerID,
+		Status:         players.StatusSuspended,
+		Reason:         "abusive language",
+		Actor:          "admin@192.0.2.10",
+		SuspendedUntil: &suspendedUntil,
+	}
+	service := newServiceMock()
+	service.On("ChangeStatus", ctx, expectedChangeStatus).Return(nil)
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+	want := pb.ChangePlayerStatusReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.SuspendPlayer(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestBanPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.ChangePlayerStatusRequest{
+		PlayerId: playerID.String(),
+		Reason:   "cheating",
+	}
+	expectedChangeStatus := players.ChangeStatus{
+		PlayerID: *playerID,
+		Status:   players.StatusBanned,
+		Reason:   "cheating",
+		Actor:    "admin",
+	}
+	service := newServiceMock()
+	service.On("ChangeStatus", ctx, expectedChangeStatus).Return(nil)
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+	want := pb.ChangePlayerStatusReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.BanPlayer(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestDisablePlayerButInvalidTransition(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", "any-admin-key"))
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.ChangePlayerStatusRequest{
+		PlayerId: playerID.String(),
+		Reason:   "requested by player",
+	}
+	service := newServiceMock()
+	service.On("ChangeStatus", ctx, mock.AnythingOfType("players.ChangeStatus")).
+		Return(fmt.Errorf("unable to change player status: %w", players.ErrInvalidStatusTransition))
+	server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+
+	// When
+	reply, err := server.DisablePlayer(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAdminRequestsButNotAdmin(t *testing.T) {
+	t.Parallel()
+
+	playerID := uuid.NewString()
+
+	testCases := map[string]struct {
+		adminKey string
+		call     func(ctx context.Context, server *grpc.Handler) (any, error)
+	}{
+		"restore_without_admin_key": {
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.RestorePlayer(ctx, &pb.RestorePlayerRequest{PlayerId: playerID})
+			},
+		},
+		"get_lock_state_with_wrong_admin_key": {
+			adminKey: "other-admin-key",
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.GetPlayerLockState(ctx, &pb.GetPlayerLockStateRequest{PlayerId: playerID})
+			},
+		},
+		"activate_without_admin_key": {
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.ActivatePlayer(ctx, &pb.ChangePlayerStatusRequest{PlayerId: playerID})
+			},
+		},
+		"disable_with_wrong_admin_key": {
+			adminKey: "other-admin-key",
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.DisablePlayer(ctx, &pb.ChangePlayerStatusRequest{PlayerId: playerID, Reason: "requested by player"})
+			},
+		},
+		"ban_without_admin_key": {
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.BanPlayer(ctx, &pb.ChangePlayerStatusRequest{PlayerId: playerID, Reason: "cheating"})
+			},
+		},
+		"suspend_without_admin_key": {
+			call: func(ctx context.Context, server *grpc.Handler) (any, error) {
+				return server.SuspendPlayer(ctx, &pb.SuspendPlayerRequest{PlayerId: playerID, Reason: "abusive language"})
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := metadata.NewIncomingContext(context.TODO(), metadata.Pairs("admin-key", data.adminKey))
+			service := newServiceMock()
+			server := newGRPCHandlerWithAdminKey(service, "any-admin-key")
+
+			// When
+			reply, err := data.call(ctx, server)
+
+			// Then
+			assert.Nil(st, reply)
+			assert.Equal(st, codes.PermissionDenied, status.Code(err))
+			service.AssertNotCalled(st, "Restore", mock.Anything, mock.Anything)
+			service.AssertNotCalled(st, "GetLockState", mock.Anything, mock.Anything)
+			service.AssertNotCalled(st, "ChangeStatus", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticatePlayerButNotActive(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+	service := newServiceMock()
+	service.On("Authenticate", ctx, mock.AnythingOfType("players.Credentials")).Return(nil, players.ErrPlayerIsNotActive)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestListCountries(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	countries := []players.CountryPlayers{
+		{Country: players.Country{Code: "CO", Alpha3: "COL", Name: "Colombia"}, Players: 3},
+		{Country: players.Country{Code: "ES", Alpha3: "ESP", Name: "Spain"}, Players: 0},
+	}
+	service := newServiceMock()
+	service.On("ListCountries", ctx).Return(countries, nil)
+	server := newGRPCHandler(service)
+	want := pb.ListCountriesReply{
+		Countries: []*pb.Country{
+			{Code: "CO", Alpha3: "COL", Name: "Colombia", PlayerCount: 3},
+			{Code: "ES", Alpha3: "ESP", Name: "Spain", PlayerCount: 0},
+		},
+	}
+
+	// When
+	reply, err := server.ListCountries(ctx, &pb.ListCountriesRequest{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply))
+}
+
+func TestSearchPlayerButInvalidCountry(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := pb.SearchPlayersRequest{
+		Country: "Narnia",
+	}
+	service := newServiceMock()
+	service.On("List", ctx, mock.AnythingOfType("players.SearchCriteria")).Return(nil, fmt.Errorf("unable to list players: %w", players.ErrInvalidCountry))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.SearchPlayers(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSearchPlayersButPaginationDoesNotFit(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request   *pb.SearchPlayersRequest
+		wantField string
+	}{
+		"limit_truncated_to_zero": {
+			request:   &pb.SearchPlayersRequest{Country: "CO", Limit: 65536},
+			wantField: "limit",
+		},
+		"limit_truncated_below_max": {
+			request:   &pb.SearchPlayersRequest{Country: "CO", Limit: 65636},
+			wantField: "limit",
+		},
+		"offset_truncated": {
+			request:   &pb.SearchPlayersRequest{Country: "CO", Offset: 65546},
+			wantField: "offset",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			service := newServiceMock()
+			server := newGRPCHandler(service)
+
+			// When
+			reply, err := server.SearchPlayers(ctx, data.request)
+
+			// Then
+			assert.Nil(st, reply)
+			assert.Equal(st, codes.InvalidArgument, status.Code(err))
+
+			details := status.Convert(err).Details()
+			require.Len(st, details, 1)
+			badRequest, ok := details[0].(*errdetails.BadRequest)
+			require.True(st, ok)
+			require.Len(st, badRequest.GetFieldViolations(), 1)
+			assert.Equal(st, data.wantField, badRequest.GetFieldViolations()[0].GetField())
+			service.AssertNotCalled(st, "List", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.ChangePasswordRequest{
+		PlayerId:        playerID.String(),
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+	wantChangePassword := players.ChangePassword{
+		PlayerID:        *playerID,
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+	service := newServiceMock()
+	service.On("ChangePassword", ctx, wantChangePassword).Return(nil)
+	server := newGRPCHandler(service)
+	want := pb.ChangePasswordReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.ChangePassword(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestChangePasswordButReused(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	request := pb.ChangePasswordRequest{
+		PlayerId:        playerID.String(),
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "th1s1s@dummypwd",
+	}
+	service := newServiceMock()
+	service.On("ChangePassword", ctx, mock.AnythingOfType("players.ChangePassword")).Return(fmt.Errorf("unable to change player password: %w", players.ErrPasswordReused))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.ChangePassword(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "new_password", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := pb.RequestPasswordResetRequest{
+		Email: "focampo@anyemail.com",
+	}
+	service := newServiceMock()
+	service.On("RequestPasswordReset", ctx, players.RequestPasswordReset{Email: "focampo@anyemail.com"}).Return(nil)
+	server := newGRPCHandler(service)
+	want := pb.RequestPasswordResetReply{
+		Ok: true,
+	}
+
+	// When
+	reply, err := server.RequestPasswordReset(ctx, &request)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, reply)
+}
+
+func TestConfirmPasswordResetButInvalidToken(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := pb.ConfirmPasswordResetRequest{
+		Token:       "expiredtoken",
+		NewPassword: "n3wpassword",
+	}
+	wantReset := players.ConfirmPasswordReset{
+		Token:       "expiredtoken",
+		NewPassword: "n3wpassword",
+	}
+	service := newServiceMock()
+	service.On("ConfirmPasswordReset", ctx, wantReset).Return(fmt.Errorf("unable to reset player password: %w", players.ErrInvalidResetToken))
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.ConfirmPasswordReset(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestVerifyEmail(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := pb.VerifyEmailRequest{
+		Token: "verificationtoken",
+	}
+	wantVerifyEmail := players.VerifyEmail{
+		Token: "verificationtoken",
+	}
+	want := pb.VerifyEmailReply{
+		Ok: true,
+	}
+	service := newServiceMock()
+	service.On("VerifyEmail", ctx, wantVerifyEmail).Return(nil)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.VerifyEmail(ctx, &request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, want.GetOk(), reply.GetOk())
+}
+
+func TestVerifyEmailButTakenByAnotherPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := pb.VerifyEmailRequest{
+		Token: "verificationtoken",
+	}
+	service := newServiceMock()
+	service.On("VerifyEmail", ctx, players.VerifyEmail{Token: "verificationtoken"}).
+		Return(&players.PlayerAlreadyExistsError{WasEmail: true})
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.VerifyEmail(ctx, &request)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestBatchCreatePlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	batchRequest := pb.BatchCreatePlayersRequest{
+		Players:      []*pb.CreatePlayerRequest{newCreatePlayerFixture(), newCreatePlayerFixture(), newCreatePlayerFixture()},
+		Mode:         pb.BatchMode_BATCH_MODE_BEST_EFFORT,
+		MinimalReply: true,
+	}
+	givenResult := players.BatchResult{
+		Items: []players.BatchItemResult{
+			{Player: &players.Player{ID: playerID, Version: 1}},
+			{Err: fmt.Errorf("unable to create player: %w", players.ErrEmptyNickname)},
+			{Err: &players.PlayerAlreadyExistsError{WasEmail: true}},
+		},
+	}
+	service := newServiceMock()
+	service.On("BatchCreate", ctx, mock.MatchedBy(func(batch players.BatchCreatePlayers) bool {
+		return len(batch.Players) == 3 && batch.Mode == players.BatchBestEffort
+	})).Return(&givenResult, nil)
+	server := newGRPCHandler(service)
+	want := pb.BatchCreatePlayersReply{
+		Ok:      false,
+		Message: "some players of the batch failed, check their results",
+		Results: []*pb.BatchItemResult{
+			{Index: 0, PlayerId: playerID.String(), Version: 1},
+			{
+				Index:   1,
+				Code:    int32(codes.InvalidArgument),
+				Message: "unable to create player: nickname is empty",
+				FieldViolations: []*pb.FieldViolation{
+					{Field: "nickname", Description: "nickname is empty"},
+				},
+			},
+			{
+				Index:           2,
+				Code:            int32(codes.AlreadyExists),
+				Message:         "player with the given email already exists",
+				FieldViolations: []*pb.FieldViolation{},
+			},
+		},
+	}
+
+	// When
+	reply, err := server.BatchCreatePlayers(ctx, &batchRequest)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&want, reply), "want: %v, got: %v", &want, reply)
+}
+
+func TestBatchDeletePlayersButInvalidPlayerID(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batchRequest := pb.BatchDeletePlayersRequest{
+		Players: []*pb.DeletePlayerRequest{
+			{PlayerId: uuid.New().String()},
+			{PlayerId: "1234"},
+		},
+	}
+	service := newServiceMock()
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.BatchDeletePlayers(ctx, &batchRequest)
+
+	// Then
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "players[1].player_id", badRequest.GetFieldViolations()[0].GetField())
+	service.AssertNotCalled(t, "BatchDelete", mock.Anything, mock.Anything)
+}
+
+func TestBatchUpdatePlayersButBatchAborted(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := players.ToPlayerID(uuid.New())
+	batchRequest := pb.BatchUpdatePlayersRequest{
+		Players: []*pb.UpdatePlayerRequest{newUpdatePlayerFixture(playerID)},
+	}
+	givenResult := players.BatchResult{
+		Items: []players.BatchItemResult{
+			{Err: players.ErrBatchAborted},
+		},
+	}
+	service := newServiceMock()
+	service.On("BatchUpdate", ctx, mock.AnythingOfType("players.BatchUpdatePlayers")).Return(&givenResult, nil)
+	server := newGRPCHandler(service)
+
+	// When
+	reply, err := server.BatchUpdatePlayers(ctx, &batchRequest)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, reply.GetOk())
+	require.Len(t, reply.GetResults(), 1)
+	assert.Equal(t, int32(codes.Aborted), reply.GetResults()[0].GetCode())
+	assert.Empty(t, reply.GetResults()[0].GetPlayerId())
+}
+
+type MockService struct {
+	mock.Mock
+}
+
+func newServiceMock() *MockService {
+	return &MockService{}
+}
+
+func (m *MockService) Create(ctx context.Context, newPlayer players.NewPlayer) (*players.Player, error) {
+	args := m.Called(ctx, newPlayer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.Player), args.Error(1)
+}
+
+func (m *MockService) Update(ctx context.Context, updatePlayer players.UpdatePlayer) (*players.Player, error) {
+	args := m.Called(ctx, updatePlayer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.Player), args.Error(1)
+}
+
+func (m *MockService) Delete(ctx context.Context, deletePlayer players.DeletePlayer) error {
+	args := m.Called(ctx, deletePlayer)
+
+	return args.Error(0)
+}
+
+func (m *MockService) BatchCreate(ctx context.Context, batch players.BatchCreatePlayers) (*players.BatchResult, error) {
+	args := m.Called(ctx, batch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.BatchResult), args.Error(1)
+}
+
+func (m *MockService) BatchUpdate(ctx context.Context, batch players.BatchUpdatePlayers) (*players.BatchResult, error) {
+	args := m.Called(ctx, batch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.BatchResult), args.Error(1)
+}
+
+func (m *MockService) BatchDelete(ctx context.Context, batch players.BatchDeletePlayers) (*players.BatchResult, error) {
+	args := m.Called(ctx, batch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.BatchResult), args.Error(1)
+}
+
+func (m *MockService) Restore(ctx context.Context, playerID players.PlayerID) error {
+	args := m.Called(ctx, playerID)
+
+	return args.Error(0)
+}
+
+func (m *MockService) List(ctx context.Context, searchCriteria players.SearchCriteria) (*players.SearchResult, error) {
+	args := m.Called(ctx, searchCriteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.SearchResult), args.Error(1)
+}
+
+func (m *MockService) Get(ctx context.Context, playerID players.PlayerID) (*players.Player, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.Player), args.Error(1)
+}
+
+func (m *MockService) Authenticate(ctx context.Context, credentials players.Credentials) (*players.PlayerID, error) {
+	args := m.Called(ctx, credentials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.PlayerID), args.Error(1)
+}
+
+func (m *MockService) GetLockState(ctx context.Context, playerID players.PlayerID) (*players.LockState, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.LockState), args.Error(1)
+}
+
+func (m *MockService) Unlock(ctx context.Context, playerID players.PlayerID) error {
+	args := m.Called(ctx, playerID)
+
+	return args.Error(0)
+}
+
+func (m *MockService) ChangePassword(ctx context.Context, changePassword players.ChangePassword) error {
+	args := m.Called(ctx, changePassword)
+
+	return args.Error(0)
+}
+
+func (m *MockService) RequestPasswordReset(ctx context.Context, request players.RequestPasswordReset) error {
+	args := m.Called(ctx, request)
+
+	return args.Error(0)
+}
+
+func (m *MockService) ConfirmPasswordReset(ctx context.Context, reset players.ConfirmPasswordReset) error {
+	args := m.Called(ctx, reset)
+
+	return args.Error(0)
+}
+
+func (m *MockService) VerifyEmail(ctx context.Context, verifyEmail players.VerifyEmail) error {
+	args := m.Called(ctx, verifyEmail)
+
+	return args.Error(0)
+}
+
+func (m *MockService) ChangeStatus(ctx context.Context, changeStatus players.ChangeStatus) error {
+	args := m.Called(ctx, changeStatus)
+
+	return args.Error(0)
+}
+
+func (m *MockService) ListCountries(ctx context.Context) ([]players.CountryPlayers, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.CountryPlayers), args.Error(1)
+}
+
+func newGRPCHandler(service *MockService) *grpc.Handler {
+	handlerSetup := grpc.HandlerSetup{
+		Service: service,
+		Logger:  unittests.NewLogger(),
+	}
+
+	return grpc.NewHandler(handlerSetup)
+}
+
+func newGRPCHandlerWithAdminKey(service *MockService, adminKey string) *grpc.Handler {
+	handlerSetup := grpc.HandlerSetup{
+		Service:  service,
+		AdminKey: adminKey,
+		Logger:   unittests.NewLogger(),
+	}
+
+	return grpc.NewHandler(handlerSetup)
+}
+
+func newCreatePlayerFixture() *pb.CreatePlayerRequest {
+	return &pb.CreatePlayerRequest{
+		Firstname: "Fernando",
+		Lastname:  "Ocampo",
+		Nickname:  "focampo",
+		Email:     "focampo@anyemail.com",
+		Password:  "th1s1s@dummypwd",
+		Country:   "Spain",
+	}
+}
+
+func newUpdatePlayerFixture(playerID *players.PlayerID) *pb.UpdatePlayerRequest {
+	return &pb.UpdatePlayerRequest{
+		PlayerId:  playerID.String(),
+		Firstname: proto.String("Fernando"),
+		Lastname:  proto.String("Ocampo"),
 	}
 }
 
@@ -1,83 +1,332 @@
 package grpc
 
 import (
+	"context"
+	"crypto/subtle"
+	"math"
+	"net"
 	"net/mail"
+	"strings"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/players"
 	pb "github.com/fernandoocampo/players/pkg/pb/players"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func toNewPlayer(pbPlayer *pb.CreatePlayerRequest) players.NewPlayer {
+// idempotencyKeyMetadata metadata key clients can use to send the idempotency key of a request.
+const idempotencyKeyMetadata = "idempotency-key"
+
+// forwardedForMetadata metadata key gateways use to send the addresses of the clients.
+const forwardedForMetadata = "x-forwarded-for"
+
+// adminKeyMetadata metadata key admins use to send the admin key.
+const adminKeyMetadata = "admin-key"
+
+// adminActorName actor of the changes made with the admin key.
+const adminActorName = "admin"
+
+func toNewPlayer(pbPlayer *pb.CreatePlayerRequest, idempotencyKey, source string) players.NewPlayer {
 	return players.NewPlayer{
-		FirstName: pbPlayer.GetFirstname(),
-		LastName:  pbPlayer.GetLastname(),
-		Nickname:  pbPlayer.GetNickname(),
-		Email:     mail.Address{Address: pbPlayer.GetEmail()},
-		Password:  pbPlayer.GetPassword(),
-		Country:   pbPlayer.GetCountry(),
+		FirstName:      pbPlayer.GetFirstname(),
+		LastName:       pbPlayer.GetLastname(),
+		Nickname:       pbPlayer.GetNickname(),
+		Email:          mail.Address{Address: pbPlayer.GetEmail()},
+		Password:       pbPlayer.GetPassword(),
+		Country:        pbPlayer.GetCountry(),
+		IdempotencyKey: idempotencyKey,
+		Source:         source,
 	}
 }
 
-func toSearchCriteria(request *pb.SearchPlayersRequest) players.SearchCriteria {
-	return players.SearchCriteria{
-		Country: players.NewString(request.GetCountry()),
-		Limit:   uint16(request.GetLimit()),
-		Offset:  uint16(request.GetOffset()),
+func toCredentials(request *pb.AuthenticatePlayerRequest, source string) players.Credentials {
+	return players.Credentials{
+		Login:    request.GetLogin(),
+		Password: request.GetPassword(),
+		Source:   source,
 	}
 }
 
-func toUpdatePlayer(pbPlayer *pb.UpdatePlayerRequest, playerID *players.PlayerID) players.UpdatePlayer {
-	var updatePlayer players.UpdatePlayer
+func toChangePassword(request *pb.ChangePasswordRequest, playerID *players.PlayerID, source string) players.ChangePassword {
+	return players.ChangePassword{
+		PlayerID:        *playerID,
+		CurrentPassword: request.GetCurrentPassword(),
+		NewPassword:     request.GetNewPassword(),
+		Source:          source,
+	}
+}
 
-	updatePlayer.ID = *playerID
+func toRequestPasswordReset(request *pb.RequestPasswordResetRequest) players.RequestPasswordReset {
+	return players.RequestPasswordReset{
+		Email: request.GetEmail(),
+	}
+}
 
-	if pbPlayer.GetCountry() != "" {
-		updatePlayer.Country = players.NewString(pbPlayer.GetCountry())
+func toConfirmPasswordReset(request *pb.ConfirmPasswordResetRequest) players.ConfirmPasswordReset {
+	return players.ConfirmPasswordReset{
+		Token:       request.GetToken(),
+		NewPassword: request.GetNewPassword(),
 	}
+}
 
-	if pbPlayer.GetEmail() != "" {
-		updatePlayer.Email = &mail.Address{Address: pbPlayer.GetEmail()}
+func toVerifyEmail(request *pb.VerifyEmailRequest) players.VerifyEmail {
+	return players.VerifyEmail{
+		Token: request.GetToken(),
+	}
+}
+
+// idempotencyKeyFromContext returns the idempotency key of the request, the request field
+// takes precedence over the metadata. It is empty if the client did not send any key.
+func idempotencyKeyFromContext(ctx context.Context, request *pb.CreatePlayerRequest) string {
+	if request.GetIdempotencyKey() != "" {
+		return request.GetIdempotencyKey()
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// isAdminContext indicates if the request was sent with the admin key. Nobody is an admin
+// if no admin key is configured.
+func isAdminContext(ctx context.Context, adminKey string) bool {
+	if adminKey == "" {
+		return false
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, adminKeyMetadata)
+	if len(values) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(values[0]), []byte(adminKey)) == 1
+}
+
+// sourceFromContext returns the host of the client that sent the request, empty if it is unknown.
+// If forwarded addresses are trusted, the source is the last address of the x-forwarded-for
+// metadata, the one the gateway appended, because clients can send any previous address.
+func sourceFromContext(ctx context.Context, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if source := lastForwardedFor(ctx); source != "" {
+			return source
+		}
+	}
+
+	clientPeer, ok := peer.FromContext(ctx)
+	if !ok || clientPeer.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(clientPeer.Addr.String())
+	if err != nil {
+		return clientPeer.Addr.String()
+	}
+
+	return host
+}
+
+// validateSearchPagination rejects limits and offsets that don't fit in the search criteria
+// instead of truncating them.
+func validateSearchPagination(request *pb.SearchPlayersRequest) error {
+	if request.GetLimit() > math.MaxUint16 {
+		return toStatusError(players.ErrSearchLimitTooLarge)
+	}
+
+	if request.GetOffset() > math.MaxUint16 {
+		return newSearchOffsetTooLargeError()
+	}
+
+	return nil
+}
+
+func toSearchCriteria(request *pb.SearchPlayersRequest, admin bool) players.SearchCriteria {
+	searchCriteria := players.SearchCriteria{
+		Country:        players.NewString(request.GetCountry()),
+		NicknamePrefix: players.NewString(request.GetNicknamePrefix()),
+		FirstName:      players.NewString(request.GetFirstname()),
+		LastName:       players.NewString(request.GetLastname()),
+		Email:          players.NewString(request.GetEmail()),
+		DateCreated:    toDateRange(request.GetDateCreatedFrom(), request.GetDateCreatedTo()),
+		DateUpdated:    toDateRange(request.GetDateUpdatedFrom(), request.GetDateUpdatedTo()),
+		Admin:          admin,
+		Sort:           toSortKeys(request.GetSort()),
+		Limit:          uint16(request.GetLimit()),
+		Offset:         uint16(request.GetOffset()),
+		PageToken:      request.GetPageToken(),
+		ListAll:        request.GetListAll(),
+		SkipTotal:      request.GetSkipTotal(),
+		Fuzzy:          players.NewString(request.GetFuzzy()),
+	}
+
+	if request.GetStatus() != "" {
+		playerStatus := players.Status(request.GetStatus())
+		searchCriteria.Status = &playerStatus
+	}
+
+	return searchCriteria
+}
+
+func toSortKeys(pbSortKeys []*pb.SortKey) []players.SortKey {
+	if len(pbSortKeys) == 0 {
+		return nil
+	}
+
+	sortKeys := make([]players.SortKey, 0, len(pbSortKeys))
+
+	for _, pbSortKey := range pbSortKeys {
+		sortKeys = append(sortKeys, players.SortKey{
+			Field:      players.SortField(pbSortKey.GetField()),
+			Descending: pbSortKey.GetDirection() == pb.SortDirection_SORT_DIRECTION_DESC,
+		})
+	}
+
+	return sortKeys
+}
+
+// toDateRange converts the given dates, a date that was not sent leaves that side of the range open.
+func toDateRange(from, to *timestamppb.Timestamp) players.DateRange {
+	var dateRange players.DateRange
+
+	if from != nil {
+		dateRange.From = from.AsTime()
 	}
 
-	if pbPlayer.GetFirstname() != "" {
-		updatePlayer.FirstName = players.NewString(pbPlayer.GetFirstname())
+	if to != nil {
+		dateRange.To = to.AsTime()
 	}
 
-	if pbPlayer.GetLastname() != "" {
-		updatePlayer.LastName = players.NewString(pbPlayer.GetLastname())
+	return dateRange
+}
+
+// adminActor returns the actor recorded for the status changes made by admins. Callers are not
+// authenticated yet, so admins are only told apart by the address they send requests from.
+func adminActor(source string) string {
+	if source == "" {
+		return adminActorName
 	}
 
-	if pbPlayer.GetNickname() != "" {
-		updatePlayer.Nickname = players.NewString(pbPlayer.GetNickname())
+	return adminActorName + "@" + source
+}
+
+func toChangeStatus(request *pb.ChangePlayerStatusRequest, playerID *players.PlayerID, newStatus players.Status, actor string) players.ChangeStatus {
+	return players.ChangeStatus{
+		PlayerID: *playerID,
+		Status:   newStatus,
+		Reason:   request.GetReason(),
+		Actor:    actor,
+	}
+}
+
+func toSuspendChangeStatus(request *pb.SuspendPlayerRequest, playerID *players.PlayerID, actor string) players.ChangeStatus {
+	changeStatus := players.ChangeStatus{
+		PlayerID: *playerID,
+		Status:   players.StatusSuspended,
+		Reason:   request.GetReason(),
+		Actor:    actor,
 	}
 
-	if pbPlayer.GetPassword() != "" {
-		updatePlayer.Password = players.NewString(pbPlayer.GetPassword())
+	if request.GetSuspendedUntil() != nil {
+		suspendedUntil := request.GetSuspendedUntil().AsTime()
+		changeStatus.SuspendedUntil = &suspendedUntil
+	}
+
+	return changeStatus
+}
+
+// toUpdatePlayer keeps the presence of the optional fields, so an empty value is not confused
+// with a field that was not sent. The domain applies the update mask.
+func toUpdatePlayer(pbPlayer *pb.UpdatePlayerRequest, playerID *players.PlayerID) players.UpdatePlayer {
+	updatePlayer := players.UpdatePlayer{
+		ID:              *playerID,
+		FirstName:       pbPlayer.Firstname,
+		LastName:        pbPlayer.Lastname,
+		Nickname:        pbPlayer.Nickname,
+		Country:         pbPlayer.Country,
+		ExpectedVersion: pbPlayer.GetExpectedVersion(),
+		UpdateMask:      pbPlayer.GetUpdateMask().GetPaths(),
+	}
+
+	if pbPlayer.Email != nil {
+		updatePlayer.Email = &mail.Address{Address: pbPlayer.GetEmail()}
 	}
 
 	return updatePlayer
 }
 
-func toCreatePlayerReply(playerID *players.PlayerID) *pb.CreatePlayerReply {
+func toDeletePlayer(request *pb.DeletePlayerRequest, playerID *players.PlayerID) players.DeletePlayer {
+	return players.DeletePlayer{
+		ID:              *playerID,
+		ExpectedVersion: request.GetExpectedVersion(),
+	}
+}
+
+// toCreatePlayerReply returns the id of the created player and the player itself unless
+// a minimal reply was requested.
+func toCreatePlayerReply(player *players.Player, minimal bool) *pb.CreatePlayerReply {
 	newReply := pb.CreatePlayerReply{
+		PlayerId: player.ID.String(),
+	}
+
+	if !minimal {
+		newReply.Player = toPBPlayer(player)
+	}
+
+	return &newReply
+}
+
+func toAuthenticatePlayerReply(playerID *players.PlayerID) *pb.AuthenticatePlayerReply {
+	newReply := pb.AuthenticatePlayerReply{
 		PlayerId: playerID.String(),
 	}
 
 	return &newReply
 }
 
-func updatePlayerReplyOK() *pb.UpdatePlayerReply {
-	return &pb.UpdatePlayerReply{
-		Ok: true,
+// toUpdatePlayerReply returns the version of the updated player and the player itself unless
+// a minimal reply was requested.
+func toUpdatePlayerReply(player *players.Player, minimal bool) *pb.UpdatePlayerReply {
+	newReply := pb.UpdatePlayerReply{
+		Ok:      true,
+		Version: player.Version,
+	}
+
+	if !minimal {
+		newReply.Player = toPBPlayer(player)
 	}
+
+	return &newReply
+}
+
+func toListCountriesReply(countries []players.CountryPlayers) *pb.ListCountriesReply {
+	newReply := pb.ListCountriesReply{
+		Countries: make([]*pb.Country, 0, len(countries)),
+	}
+
+	for _, country := range countries {
+		newReply.Countries = append(newReply.Countries, &pb.Country{
+			Code:        country.Country.Code,
+			Alpha3:      country.Country.Alpha3,
+			Name:        country.Country.Name,
+			PlayerCount: int64(country.Players),
+		})
+	}
+
+	return &newReply
 }
 
 func toSearchPlayerReply(result *players.SearchResult) *pb.SearchPlayersReply {
 	newReply := pb.SearchPlayersReply{
-		Total:       int64(result.Total),
-		Limit:       uint32(result.Limit),
-		Offset:      uint32(result.Offset),
-		PlayerItems: make([]*pb.PlayerItem, 0, len(result.Items)),
+		Total:         int64(result.Total),
+		Limit:         uint32(result.Limit),
+		Offset:        uint32(result.Offset),
+		NextPageToken: result.NextPageToken,
+		PlayerItems:   make([]*pb.PlayerItem, 0, len(result.Items)),
 	}
 
 	for _, item := range result.Items {
@@ -94,13 +343,273 @@ func toPBPlayerItem(item players.PlayerItem) *pb.PlayerItem {
 		Lastname:  item.LastName,
 		Nickname:  item.Nickname,
 		Country:   item.Country,
+		Score:     float32(item.Score),
 	}
 
 	return &newPBPlayerItem
 }
 
+func toGetPlayerReply(player *players.Player) *pb.GetPlayerReply {
+	newReply := pb.GetPlayerReply{
+		Player: toPBPlayer(player),
+	}
+
+	return &newReply
+}
+
+// toPBPlayer converts the given player to its protobuf representation, leaving the password out.
+func toPBPlayer(player *players.Player) *pb.Player {
+	newPBPlayer := pb.Player{
+		Id:            player.ID.String(),
+		Firstname:     player.FirstName,
+		Lastname:      player.LastName,
+		Nickname:      player.Nickname,
+		Email:         player.Email.Address,
+		Country:       player.Country,
+		DateCreated:   timestamppb.New(player.DateCreated),
+		DateUpdated:   timestamppb.New(player.DateUpdated),
+		Status:        string(player.Status.Current(time.Now().UTC())),
+		StatusReason:  player.Status.Reason,
+		Version:       player.Version,
+		EmailVerified: player.EmailVerified,
+	}
+
+	if player.PendingEmail != nil {
+		newPBPlayer.PendingEmail = player.PendingEmail.Address
+	}
+
+	if newPBPlayer.GetStatus() == string(players.StatusSuspended) && player.Status.SuspendedUntil != nil {
+		newPBPlayer.SuspendedUntil = timestamppb.New(*player.Status.SuspendedUntil)
+	}
+
+	return &newPBPlayer
+}
+
+func toGetPlayerLockStateReply(lockState *players.LockState) *pb.GetPlayerLockStateReply {
+	newReply := pb.GetPlayerLockStateReply{
+		Locked:         lockState.IsLocked(time.Now().UTC()),
+		FailedAttempts: int32(lockState.FailedAttempts),
+	}
+
+	if lockState.LockedUntil != nil {
+		newReply.LockedUntil = timestamppb.New(*lockState.LockedUntil)
+	}
+
+	return &newReply
+}
+
+func unlockPlayerReplyOK() *pb.UnlockPlayerReply {
+	return &pb.UnlockPlayerReply{
+		Ok: true,
+	}
+}
+
+func changePasswordReplyOK() *pb.ChangePasswordReply {
+	return &pb.ChangePasswordReply{
+		Ok: true,
+	}
+}
+
+func requestPasswordResetReplyOK() *pb.RequestPasswordResetReply {
+	return &pb.RequestPasswordResetReply{
+		Ok: true,
+	}
+}
+
+func confirmPasswordResetReplyOK() *pb.ConfirmPasswordResetReply {
+	return &pb.ConfirmPasswordResetReply{
+		Ok: true,
+	}
+}
+
+func verifyEmailReplyOK() *pb.VerifyEmailReply {
+	return &pb.VerifyEmailReply{
+		Ok: true,
+	}
+}
+
 func deletePlayerReplyOK() *pb.DeletePlayerReply {
 	return &pb.DeletePlayerReply{
 		Ok: true,
 	}
 }
+
+func restorePlayerReplyOK() *pb.RestorePlayerReply {
+	return &pb.RestorePlayerReply{
+		Ok: true,
+	}
+}
+
+func changePlayerStatusReplyOK() *pb.ChangePlayerStatusReply {
+	return &pb.ChangePlayerStatusReply{
+		Ok: true,
+	}
+}
+
+func toBatchMode(mode pb.BatchMode) players.BatchMode {
+	switch mode {
+	case pb.BatchMode_BATCH_MODE_TRANSACTIONAL:
+		return players.BatchTransactional
+	case pb.BatchMode_BATCH_MODE_BEST_EFFORT:
+		return players.BatchBestEffort
+	default:
+		// unknown modes are rejected by the service.
+		return players.BatchMode(mode.String())
+	}
+}
+
+func toBatchCreatePlayers(request *pb.BatchCreatePlayersRequest) players.BatchCreatePlayers {
+	batch := players.BatchCreatePlayers{
+		Players: make([]players.NewPlayer, 0, len(request.GetPlayers())),
+		Mode:    toBatchMode(request.GetMode()),
+	}
+
+	// idempotency keys are rejected in batches, so the source that scopes them is not needed.
+	for _, player := range request.GetPlayers() {
+		batch.Players = append(batch.Players, toNewPlayer(player, player.GetIdempotencyKey(), ""))
+	}
+
+	return batch
+}
+
+// toBatchUpdatePlayers converts the request, it returns an invalid argument error if a player id is not valid.
+func toBatchUpdatePlayers(request *pb.BatchUpdatePlayersRequest) (players.BatchUpdatePlayers, error) {
+	batch := players.BatchUpdatePlayers{
+		Players: make([]players.UpdatePlayer, 0, len(request.GetPlayers())),
+		Mode:    toBatchMode(request.GetMode()),
+	}
+
+	for index, player := range request.GetPlayers() {
+		if hasPassword(player) {
+			return players.BatchUpdatePlayers{}, newPasswordNotUpdatableError(
+				batchPlayerField(index, players.PasswordField))
+		}
+
+		playerID, err := players.StringToPlayerID(player.GetPlayerId())
+		if err != nil {
+			return players.BatchUpdatePlayers{}, newInvalidBatchPlayerIDError(index)
+		}
+
+		batch.Players = append(batch.Players, toUpdatePlayer(player, playerID))
+	}
+
+	return batch, nil
+}
+
+// hasPassword checks if an old client sent the deprecated password of the update request,
+// it is only read to reject the request because passwords are changed with ChangePassword.
+func hasPassword(request *pb.UpdatePlayerRequest) bool {
+	return request.GetPassword() != "" //nolint:staticcheck // the deprecated field is read to reject it.
+}
+
+// toBatchDeletePlayers converts the request, it returns an invalid argument error if a player id is not valid.
+func toBatchDeletePlayers(request *pb.BatchDeletePlayersRequest) (players.BatchDeletePlayers, error) {
+	batch := players.BatchDeletePlayers{
+		Players: make([]players.DeletePlayer, 0, len(request.GetPlayers())),
+		Mode:    toBatchMode(request.GetMode()),
+	}
+
+	for index, player := range request.GetPlayers() {
+		playerID, err := players.StringToPlayerID(player.GetPlayerId())
+		if err != nil {
+			return players.BatchDeletePlayers{}, newInvalidBatchPlayerIDError(index)
+		}
+
+		batch.Players = append(batch.Players, toDeletePlayer(player, playerID))
+	}
+
+	return batch, nil
+}
+
+func toBatchCreatePlayersReply(result *players.BatchResult, minimal bool) *pb.BatchCreatePlayersReply {
+	return &pb.BatchCreatePlayersReply{
+		Ok:      !result.Failed(),
+		Message: batchReplyMessage(result),
+		Results: toBatchItemResults(result, true, minimal),
+	}
+}
+
+func toBatchUpdatePlayersReply(result *players.BatchResult, minimal bool) *pb.BatchUpdatePlayersReply {
+	return &pb.BatchUpdatePlayersReply{
+		Ok:      !result.Failed(),
+		Message: batchReplyMessage(result),
+		Results: toBatchItemResults(result, true, minimal),
+	}
+}
+
+func toBatchDeletePlayersReply(result *players.BatchResult) *pb.BatchDeletePlayersReply {
+	return &pb.BatchDeletePlayersReply{
+		Ok:      !result.Failed(),
+		Message: batchReplyMessage(result),
+		Results: toBatchItemResults(result, false, true),
+	}
+}
+
+func batchReplyMessage(result *players.BatchResult) string {
+	if result.Failed() {
+		return "some players of the batch failed, check their results"
+	}
+
+	return ""
+}
+
+// toBatchItemResults returns the result of every player of the batch. The version of the players is
+// returned for creations and updates, and the players themselves unless a minimal reply was requested.
+func toBatchItemResults(result *players.BatchResult, withVersion, minimal bool) []*pb.BatchItemResult {
+	itemResults := make([]*pb.BatchItemResult, 0, len(result.Items))
+
+	for index, item := range result.Items {
+		itemResult := pb.BatchItemResult{
+			Index: int32(index),
+		}
+
+		if item.Err != nil {
+			itemStatus := status.Convert(toStatusError(item.Err))
+			itemResult.Code = int32(itemStatus.Code())
+			itemResult.Message = itemStatus.Message()
+			itemResult.FieldViolations = toPBFieldViolations(players.FieldErrors(item.Err))
+			itemResults = append(itemResults, &itemResult)
+
+			continue
+		}
+
+		itemResult.PlayerId = item.Player.ID.String()
+
+		if withVersion {
+			itemResult.Version = item.Player.Version
+		}
+
+		if withVersion && !minimal {
+			itemResult.Player = toPBPlayer(item.Player)
+		}
+
+		itemResults = append(itemResults, &itemResult)
+	}
+
+	return itemResults
+}
+
+func toPBFieldViolations(fieldErrors []*players.FieldError) []*pb.FieldViolation {
+	violations := make([]*pb.FieldViolation, 0, len(fieldErrors))
+
+	for _, fieldErr := range fieldErrors {
+		violations = append(violations, &pb.FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Description,
+		})
+	}
+
+	return violations
+}
+
+// lastForwardedFor returns the last address of the x-forwarded-for metadata, empty if there is none.
+func lastForwardedFor(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, forwardedForMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+
+	addresses := strings.Split(values[len(values)-1], ",")
+
+	return strings.TrimSpace(addresses[len(addresses)-1])
+}
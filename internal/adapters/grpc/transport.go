@@ -96,7 +96,8 @@ func (s *Server) Health() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err = client.SearchPlayers(ctx, &pb.SearchPlayersRequest{})
+	// lists a single player without counting them, so the check reaches the database cheaply.
+	_, err = client.SearchPlayers(ctx, &pb.SearchPlayersRequest{ListAll: true, Limit: 1, SkipTotal: true})
 	if err != nil {
 		return resourceName, errUnhealthy
 	}
@@ -9,7 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestE2ECreatePlayer(t *testing.T) {
@@ -29,9 +33,13 @@ func TestE2ECreatePlayer(t *testing.T) {
 	reply, err := grpcClient.client.CreatePlayer(ctx, request)
 
 	// Then
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.NotNil(t, reply)
 	assert.NotEmpty(t, reply.PlayerId)
+	assert.Equal(t, reply.GetPlayerId(), reply.GetPlayer().GetId())
+	assert.Equal(t, request.GetNickname(), reply.GetPlayer().GetNickname())
+	assert.Equal(t, "active", reply.GetPlayer().GetStatus())
+	assert.Equal(t, int64(1), reply.GetPlayer().GetVersion())
 }
 
 // UpdatePlayer updates a player.
@@ -56,15 +64,21 @@ func TestE2EUpdatePlayer(t *testing.T) {
 
 	updateRequest := pb.UpdatePlayerRequest{
 		PlayerId: createReply.PlayerId,
-		Lastname: "e2eupdated",
+		Lastname: proto.String("e2eupdated"),
 	}
 
 	// when
 	reply, err := grpcClient.client.UpdatePlayer(ctx, &updateRequest)
 
 	// Then
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.True(t, reply.Ok)
+	assert.Equal(t, "e2eupdated", reply.GetPlayer().GetLastname())
+	assert.Equal(t, reply.GetVersion(), reply.GetPlayer().GetVersion())
+
+	getReply, err := grpcClient.client.GetPlayer(ctx, &pb.GetPlayerRequest{PlayerId: createReply.GetPlayerId()})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(getReply.GetPlayer(), reply.GetPlayer()))
 }
 
 // DeletePlayer deletes a player.
@@ -96,6 +110,46 @@ func TestE2EDeletePlayer(t *testing.T) {
 	assert.True(t, reply.Ok)
 }
 
+func TestE2ERestorePlayer(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	createRequest := e2etests.RandomPBCreatePlayerFixture()
+
+	grpcClient := createPlayerClient(t)
+	defer grpcClient.Close(t)
+
+	createReply, err := grpcClient.client.CreatePlayer(ctx, createRequest)
+	require.NoError(t, err)
+
+	_, err = grpcClient.client.DeletePlayer(ctx, &pb.DeletePlayerRequest{
+		PlayerId: createReply.PlayerId,
+	})
+	require.NoError(t, err)
+
+	restoreRequest := pb.RestorePlayerRequest{
+		PlayerId: createReply.PlayerId,
+	}
+	adminCtx := metadata.AppendToOutgoingContext(ctx, "admin-key", e2etests.GRPCServerParametersFixture(t).AdminKey)
+
+	// when
+	reply, err := grpcClient.client.RestorePlayer(adminCtx, &restoreRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, reply.Ok)
+
+	getReply, err := grpcClient.client.GetPlayer(ctx, &pb.GetPlayerRequest{
+		PlayerId: createReply.PlayerId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, createReply.PlayerId, getReply.GetPlayer().GetId())
+}
+
 // SearchPlayers searches a player.
 func TestE2ESearchPlayers(t *testing.T) {
 	if !*e2etests.E2ETest {
@@ -136,6 +190,144 @@ func TestE2ESearchPlayers(t *testing.T) {
 	assert.Equal(t, 3, len(reply.PlayerItems))
 }
 
+func TestE2EListCountries(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	request := e2etests.RandomPBCreatePlayerFixture()
+	request.Country = "Colombia"
+
+	grpcClient := createPlayerClient(t)
+	defer grpcClient.Close(t)
+
+	_, err := grpcClient.client.CreatePlayer(ctx, request)
+	require.NoError(t, err)
+
+	// When
+	reply, err := grpcClient.client.ListCountries(ctx, &pb.ListCountriesRequest{})
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, reply.GetCountries(), 249)
+
+	for _, country := range reply.GetCountries() {
+		if country.GetCode() == "CO" {
+			assert.GreaterOrEqual(t, country.GetPlayerCount(), int64(1))
+		}
+	}
+}
+
+// GetPlayer gets a player.
+func TestE2EGetPlayer(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	createRequest := e2etests.RandomPBCreatePlayerFixture()
+
+	grpcClient := createPlayerClient(t)
+	defer grpcClient.Close(t)
+
+	createReply, err := grpcClient.client.CreatePlayer(ctx, createRequest)
+	require.NoError(t, err)
+
+	getRequest := pb.GetPlayerRequest{
+		PlayerId: createReply.PlayerId,
+	}
+
+	// when
+	reply, err := grpcClient.client.GetPlayer(ctx, &getRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, createReply.PlayerId, reply.GetPlayer().GetId())
+	assert.Equal(t, createRequest.Nickname, reply.GetPlayer().GetNickname())
+	assert.Equal(t, createRequest.Email, reply.GetPlayer().GetEmail())
+	assert.NotNil(t, reply.GetPlayer().GetDateCreated())
+}
+
+// AuthenticatePlayer authenticates a player.
+func TestE2EAuthenticatePlayer(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	createRequest := e2etests.RandomPBCreatePlayerFixture()
+
+	grpcClient := createPlayerClient(t)
+	defer grpcClient.Close(t)
+
+	createReply, err := grpcClient.client.CreatePlayer(ctx, createRequest)
+	require.NoError(t, err)
+
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    createRequest.Email,
+		Password: createRequest.Password,
+	}
+
+	// when
+	reply, err := grpcClient.client.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, createReply.PlayerId, reply.GetPlayerId())
+}
+
+func TestE2EChangePassword(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	createRequest := e2etests.RandomPBCreatePlayerFixture()
+
+	grpcClient := createPlayerClient(t)
+	defer grpcClient.Close(t)
+
+	createReply, err := grpcClient.client.CreatePlayer(ctx, createRequest)
+	require.NoError(t, err)
+
+	changePasswordRequest := pb.ChangePasswordRequest{
+		PlayerId:        createReply.PlayerId,
+		CurrentPassword: createRequest.Password,
+		NewPassword:     "n3w" + createRequest.Password,
+	}
+
+	reusePasswordRequest := pb.ChangePasswordRequest{
+		PlayerId:        createReply.PlayerId,
+		CurrentPassword: changePasswordRequest.NewPassword,
+		NewPassword:     createRequest.Password,
+	}
+
+	authenticateRequest := pb.AuthenticatePlayerRequest{
+		Login:    createRequest.Email,
+		Password: changePasswordRequest.NewPassword,
+	}
+
+	// when
+	reply, err := grpcClient.client.ChangePassword(ctx, &changePasswordRequest)
+	_, errReuse := grpcClient.client.ChangePassword(ctx, &reusePasswordRequest)
+	authenticateReply, errAuthenticate := grpcClient.client.AuthenticatePlayer(ctx, &authenticateRequest)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, reply.GetOk())
+	assert.Equal(t, codes.InvalidArgument, status.Code(errReuse))
+	assert.NoError(t, errAuthenticate)
+	assert.Equal(t, createReply.PlayerId, authenticateReply.GetPlayerId())
+}
+
 func createPlayerClient(t *testing.T) *playerClient {
 	t.Helper()
 
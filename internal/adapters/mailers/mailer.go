@@ -0,0 +1,72 @@
+package mailers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/players"
+)
+
+type MailerSetup struct {
+	Logger *slog.Logger
+	// FilePath file where mails are appended, only the recipient and subject of the
+	// mails are logged if it is empty.
+	FilePath string
+}
+
+// Mailer is a stand-in for a mail delivery service to use locally, it writes
+// the mails in a file or in the log instead of sending them.
+type Mailer struct {
+	logger   *slog.Logger
+	filePath string
+	mutex    sync.Mutex
+}
+
+// mailFilePermissions only the owner can read the mails, they contain secrets like reset tokens.
+const mailFilePermissions = 0o600
+
+func NewMailer(setup MailerSetup) *Mailer {
+	newMailer := Mailer{
+		logger:   setup.Logger,
+		filePath: setup.FilePath,
+	}
+
+	return &newMailer
+}
+
+// Send writes the mail in the file of the mailer. Without file the mail is only logged, its
+// body is left out because it contains secrets like reset tokens.
+func (m *Mailer) Send(_ context.Context, mail players.Mail) error {
+	if m.filePath == "" {
+		m.logger.Info("sending mail",
+			slog.String("to", mail.To),
+			slog.String("subject", mail.Subject))
+
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	file, err := os.OpenFile(m.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, mailFilePermissions)
+	if err != nil {
+		m.logger.Error("opening mail file", slog.String("file", m.filePath), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to send mail: %w", err)
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().UTC().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+	if err != nil {
+		m.logger.Error("writing mail file", slog.String("file", m.filePath), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to send mail: %w", err)
+	}
+
+	return nil
+}
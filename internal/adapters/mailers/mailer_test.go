@@ -0,0 +1,93 @@
+package mailers_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/adapters/mailers"
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendWritesMailsInFile(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	filePath := filepath.Join(t.TempDir(), "mails.txt")
+	mails := []players.Mail{
+		{
+			To:      "focampo@anyemail.com",
+			Subject: "Reset your password",
+			Body:    "use this token to reset your password: abc",
+		},
+		{
+			To:      "jraynor@anyemail.com",
+			Subject: "Reset your password",
+			Body:    "use this token to reset your password: xyz",
+		},
+	}
+
+	mailer := mailers.NewMailer(mailers.MailerSetup{
+		Logger:   unittests.NewLogger(),
+		FilePath: filePath,
+	})
+
+	// When
+	for _, mail := range mails {
+		err := mailer.Send(ctx, mail)
+		require.NoError(t, err)
+	}
+
+	// Then
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	for _, mail := range mails {
+		assert.Contains(t, string(got), "To: "+mail.To+"\nSubject: "+mail.Subject+"\n\n"+mail.Body)
+	}
+}
+
+func TestSendWithoutFileDoesNotLogTheBody(t *testing.T) {
+	t.Parallel()
+	// Given
+	var logs bytes.Buffer
+
+	mailer := mailers.NewMailer(mailers.MailerSetup{
+		Logger: slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	mail := players.Mail{
+		To:      "focampo@anyemail.com",
+		Subject: "Reset your password",
+		Body:    "use this token to reset your password: abc",
+	}
+
+	// When
+	err := mailer.Send(context.TODO(), mail)
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), mail.To)
+	assert.Contains(t, logs.String(), mail.Subject)
+	assert.NotContains(t, logs.String(), mail.Body)
+}
+
+func TestSendButFileCannotBeOpened(t *testing.T) {
+	t.Parallel()
+	// Given
+	mailer := mailers.NewMailer(mailers.MailerSetup{
+		Logger:   unittests.NewLogger(),
+		FilePath: t.TempDir(),
+	})
+
+	// When
+	err := mailer.Send(context.TODO(), players.Mail{To: "focampo@anyemail.com"})
+
+	// Then
+	assert.ErrorContains(t, err, "unable to send mail")
+}
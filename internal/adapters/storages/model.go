@@ -1,9 +1,13 @@
 package storages
 
 import (
+	"database/sql"
 	"fmt"
 	"net/mail"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fernandoocampo/players/internal/players"
 	"github.com/google/uuid"
@@ -19,6 +23,24 @@ type dbPlayer struct {
 	Country     string    `db:"country"`
 	DateCreated time.Time `db:"date_created"`
 	DateUpdated time.Time `db:"date_updated"`
+	// FailedLoginAttempts consecutive failed authentications.
+	FailedLoginAttempts int          `db:"failed_login_attempts"`
+	LockedUntil         sql.NullTime `db:"locked_until"`
+	DateDeleted         sql.NullTime `db:"date_deleted"`
+	// status lifecycle.
+	Status            string         `db:"status"`
+	StatusReason      sql.NullString `db:"status_reason"`
+	StatusActor       sql.NullString `db:"status_actor"`
+	SuspendedUntil    sql.NullTime   `db:"suspended_until"`
+	DateStatusChanged sql.NullTime   `db:"date_status_changed"`
+	// Version is incremented on every change of the player.
+	Version int64 `db:"version"`
+	// email verification.
+	EmailVerified bool           `db:"email_verified"`
+	PendingEmail  sql.NullString `db:"pending_email"`
+	// canonical forms to compare nicknames and emails, they are not read.
+	CanonicalNickname string `db:"canonical_nickname"`
+	CanonicalEmail    string `db:"canonical_email"`
 }
 
 type dbPlayerItem struct {
@@ -27,6 +49,12 @@ type dbPlayerItem struct {
 	LastName  string    `db:"lastname"`
 	Nickname  string    `db:"nickname"`
 	Country   string    `db:"country"`
+	// columns the players can be sorted by, they build the cursor of the last player of a page.
+	CanonicalNickname string       `db:"canonical_nickname"`
+	Status            string       `db:"status"`
+	DateCreated       sql.NullTime `db:"date_created"`
+	DateUpdated       sql.NullTime `db:"date_updated"`
+	Score             float64      `db:"score"`
 }
 
 type filterBuilder struct {
@@ -35,18 +63,77 @@ type filterBuilder struct {
 	filters        []string
 	queryArgs      []interface{}
 	countArgs      []interface{}
+	// score expression of the relevance of players for fuzzy searches, empty for other searches.
+	score string
+	// orderColumns sort the players of the query.
+	orderColumns []orderColumn
+}
+
+// orderColumn sorts players by a sort field or by the score of fuzzy searches.
+type orderColumn struct {
+	field      players.SortField
+	expression string
+	descending bool
 }
 
 // player columns.
 const (
-	countryColumn = "country"
+	countryColumn     = "country"
+	statusColumn      = "status"
+	firstNameColumn   = "firstname"
+	lastNameColumn    = "lastname"
+	dateCreatedColumn = "date_created"
+	dateUpdatedColumn = "date_updated"
+	nicknameColumn    = "nickname"
+)
+
+// lookup forms of the nickname and email of players. Players created before the canonical
+// forms that could not get them yet are compared by the lower case form of their values.
+const (
+	lookupNicknameExpression = "COALESCE(canonical_nickname, lower(nickname))"
+	lookupEmailExpression    = "COALESCE(canonical_email, lower(email))"
 )
 
+// scoreField sorts players by the score of fuzzy searches, it is not a sort field clients can use.
+const scoreField players.SortField = "score"
+
+// noScore is selected as score of searches that are not fuzzy.
+const noScore = "0::real"
+
+// idColumn sorts players with the same values of the sort keys, so pages are stable.
+const idColumn = "id"
+
+// sortColumns columns that sort players by each sort field. Nicknames are sorted by
+// their lookup form, like they are compared, which is also the value of the page cursors.
+var sortColumns = map[players.SortField]string{
+	players.SortByNickname:    lookupNicknameExpression,
+	players.SortByFirstName:   firstNameColumn,
+	players.SortByLastName:    lastNameColumn,
+	players.SortByCountry:     countryColumn,
+	players.SortByStatus:      statusColumn,
+	players.SortByDateCreated: dateCreatedColumn,
+	players.SortByDateUpdated: dateUpdatedColumn,
+}
+
+// notDeletedCondition hides deleted players.
+const notDeletedCondition = "date_deleted IS NULL"
+
 const (
-	equalsOperator = "="
-	bsonInOperator = "@>"
-	whereOperator  = "WHERE"
-	andOperator    = "AND"
+	equalsOperator         = "="
+	likeOperator           = "LIKE"
+	ilikeOperator          = "ILIKE"
+	greaterOrEqualOperator = ">="
+	lessOrEqualOperator    = "<="
+	greaterOperator        = ">"
+	lessOperator           = "<"
+	orOperator             = "OR"
+	similarOperator        = "%"
+	bsonInOperator         = "@>"
+	whereOperator          = "WHERE"
+	andOperator            = "AND"
+	orderByOperator        = "ORDER BY"
+	ascendingOrder         = "ASC"
+	descendingOrder        = "DESC"
 )
 
 func (d *dbPlayer) toPlayer() players.Player {
@@ -60,6 +147,91 @@ func (d *dbPlayer) toPlayer() players.Player {
 		Country:     d.Country,
 		DateCreated: d.DateCreated.UTC(),
 		DateUpdated: d.DateUpdated.UTC(),
+		DateDeleted: fromNullTime(d.DateDeleted),
+		LockState: players.LockState{
+			FailedAttempts: d.FailedLoginAttempts,
+			LockedUntil:    fromNullTime(d.LockedUntil),
+		},
+		Status: players.PlayerStatus{
+			Status:         players.Status(d.Status),
+			Reason:         d.StatusReason.String,
+			Actor:          d.StatusActor.String,
+			SuspendedUntil: fromNullTime(d.SuspendedUntil),
+			DateChanged:    fromNullTime(d.DateStatusChanged),
+		},
+		Version:       d.Version,
+		EmailVerified: d.EmailVerified,
+		PendingEmail:  fromNullEmail(d.PendingEmail),
+	}
+}
+
+// dbIdempotencyReply is the player replied to a create request with an idempotency key, it is
+// stored as JSON so its format does not depend on the fields of players.Player.
+type dbIdempotencyReply struct {
+	ID            uuid.UUID `json:"id"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Nickname      string    `json:"nickname"`
+	Email         string    `json:"email"`
+	Country       string    `json:"country"`
+	Status        string    `json:"status"`
+	Version       int64     `json:"version"`
+	EmailVerified bool      `json:"email_verified"`
+	DateCreated   time.Time `json:"date_created"`
+	DateUpdated   time.Time `json:"date_updated"`
+}
+
+func toDBIdempotencyReply(player *players.Player) dbIdempotencyReply {
+	return dbIdempotencyReply{
+		ID:            uuid.UUID(*player.ID),
+		FirstName:     player.FirstName,
+		LastName:      player.LastName,
+		Nickname:      player.Nickname,
+		Email:         player.Email.Address,
+		Country:       player.Country,
+		Status:        string(player.Status.Status),
+		Version:       player.Version,
+		EmailVerified: player.EmailVerified,
+		DateCreated:   player.DateCreated,
+		DateUpdated:   player.DateUpdated,
+	}
+}
+
+func (d *dbIdempotencyReply) toPlayer() players.Player {
+	return players.Player{
+		ID:          players.ToPlayerID(d.ID),
+		FirstName:   d.FirstName,
+		LastName:    d.LastName,
+		Nickname:    d.Nickname,
+		Email:       mail.Address{Address: d.Email},
+		Country:     d.Country,
+		DateCreated: d.DateCreated.UTC(),
+		DateUpdated: d.DateUpdated.UTC(),
+		Status: players.PlayerStatus{
+			Status: players.Status(d.Status),
+		},
+		Version:       d.Version,
+		EmailVerified: d.EmailVerified,
+	}
+}
+
+// columns returns the destinations to scan the columns of selectPlayerSQL.
+func (d *dbPlayer) columns() []any {
+	// id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated,
+	// failed_login_attempts,locked_until,date_deleted,
+	// status,status_reason,status_actor,suspended_until,date_status_changed,version,
+	// email_verified,pending_email
+	return []any{
+		&d.ID, &d.FirstName,
+		&d.LastName, &d.Nickname,
+		&d.Email, &d.Password,
+		&d.Country, &d.DateCreated,
+		&d.DateUpdated, &d.FailedLoginAttempts,
+		&d.LockedUntil, &d.DateDeleted,
+		&d.Status, &d.StatusReason,
+		&d.StatusActor, &d.SuspendedUntil,
+		&d.DateStatusChanged, &d.Version,
+		&d.EmailVerified, &d.PendingEmail,
 	}
 }
 
@@ -70,6 +242,58 @@ func (d dbPlayerItem) toPlayerItem() players.PlayerItem {
 		LastName:  d.LastName,
 		Nickname:  d.Nickname,
 		Country:   d.Country,
+		Score:     d.Score,
+	}
+}
+
+// columns returns the destinations to scan the columns of selectByFilterSQL.
+func (d *dbPlayerItem) columns() []any {
+	// id,firstname,lastname,nickname,country,lookup nickname,status,date_created,date_updated,score
+	return []any{
+		&d.ID, &d.FirstName,
+		&d.LastName, &d.Nickname,
+		&d.Country, &d.CanonicalNickname,
+		&d.Status, &d.DateCreated,
+		&d.DateUpdated, &d.Score,
+	}
+}
+
+// toCursor returns the position of the player in the results sorted by the given columns.
+func (d dbPlayerItem) toCursor(columns []orderColumn) *players.Cursor {
+	cursor := players.Cursor{
+		Values: make([]string, 0, len(columns)),
+		ID:     d.ID.String(),
+	}
+
+	for _, column := range columns {
+		cursor.Values = append(cursor.Values, d.sortValue(column.field))
+	}
+
+	return &cursor
+}
+
+// sortValue returns the value of the column that sorts players by the given field.
+func (d dbPlayerItem) sortValue(field players.SortField) string {
+	switch field {
+	case players.SortByNickname:
+		return d.CanonicalNickname
+	case players.SortByFirstName:
+		return d.FirstName
+	case players.SortByLastName:
+		return d.LastName
+	case players.SortByCountry:
+		return d.Country
+	case players.SortByStatus:
+		return d.Status
+	case players.SortByDateCreated:
+		return d.DateCreated.Time.UTC().Format(time.RFC3339Nano)
+	case players.SortByDateUpdated:
+		return d.DateUpdated.Time.UTC().Format(time.RFC3339Nano)
+	case scoreField:
+		// scores are real numbers, the shortest representation of the float32 reads the same score.
+		return strconv.FormatFloat(float64(float32(d.Score)), 'g', -1, 32)
+	default:
+		return ""
 	}
 }
 
@@ -86,8 +310,21 @@ func (f *filterBuilder) addCondition(field, operator string, value interface{})
 	return f.addFilter(newStatement, value, isHint)
 }
 
+// addStaticCondition adds a condition without arguments.
+func (f *filterBuilder) addStaticCondition(statement string) *filterBuilder {
+	condition := whereOperator
+
+	if len(f.filters) > 0 {
+		condition = " " + andOperator
+	}
+
+	f.filters = append(f.filters, fmt.Sprintf("%s %s", condition, statement))
+
+	return f
+}
+
 func (f *filterBuilder) addFilter(statement string, value interface{}, isHint bool) *filterBuilder {
-	index := len(f.filters) + 1
+	index := len(f.queryArgs) + 1
 	statement = fmt.Sprintf("%s $%d", statement, index)
 	f.filters = append(f.filters, statement)
 
@@ -100,6 +337,266 @@ func (f *filterBuilder) addFilter(statement string, value interface{}, isHint bo
 	return f
 }
 
+// addDateRange adds the conditions to filter the dates of the column between the range.
+func (f *filterBuilder) addDateRange(column string, dateRange players.DateRange) *filterBuilder {
+	if !dateRange.From.IsZero() {
+		f.addCondition(column, greaterOrEqualOperator, dateRange.From.UTC())
+	}
+
+	if !dateRange.To.IsZero() {
+		f.addCondition(column, lessOrEqualOperator, dateRange.To.UTC())
+	}
+
+	return f
+}
+
+// setOrderColumns sets the columns that sort the players: the score of fuzzy searches ranks
+// them first and then the given sort keys. Sort keys the storage does not know are ignored,
+// the service only accepts supported fields.
+func (f *filterBuilder) setOrderColumns(sortKeys []players.SortKey) *filterBuilder {
+	f.orderColumns = make([]orderColumn, 0, len(sortKeys)+1)
+
+	if f.score != "" {
+		f.orderColumns = append(f.orderColumns, orderColumn{field: scoreField, expression: f.score, descending: true})
+	}
+
+	for _, sortKey := range sortKeys {
+		column, ok := sortColumns[sortKey.Field]
+		if !ok {
+			continue
+		}
+
+		f.orderColumns = append(f.orderColumns, orderColumn{
+			field:      sortKey.Field,
+			expression: column,
+			descending: sortKey.Descending,
+		})
+	}
+
+	return f
+}
+
+// addOrderBy sorts the players by the order columns and then by id.
+func (f *filterBuilder) addOrderBy() *filterBuilder {
+	orderBy := make([]string, 0, len(f.orderColumns)+1)
+
+	for _, column := range f.orderColumns {
+		orderBy = append(orderBy, fmt.Sprintf("%s %s", column.expression, sortOrder(column.descending)))
+	}
+
+	orderBy = append(orderBy, fmt.Sprintf("%s %s", idColumn, ascendingOrder))
+
+	f.filters = append(f.filters, fmt.Sprintf(" %s %s", orderByOperator, strings.Join(orderBy, ", ")))
+
+	return f
+}
+
+func sortOrder(descending bool) string {
+	if descending {
+		return descendingOrder
+	}
+
+	return ascendingOrder
+}
+
+// addKeysetCondition returns the players that follow the cursor in the results sorted by the order
+// columns and then by id, e.g. (a > $1) OR (a = $1 AND id > $2) for the column a in ascending order.
+// It only applies to the query, the count includes the players before the cursor too.
+func (f *filterBuilder) addKeysetCondition(after *players.Cursor) *filterBuilder {
+	columns := make([]string, 0, len(f.orderColumns)+1)
+	operators := make([]string, 0, len(f.orderColumns)+1)
+	placeholders := make([]string, 0, len(f.orderColumns)+1)
+
+	for index, column := range f.orderColumns {
+		if index == len(after.Values) {
+			break
+		}
+
+		columns = append(columns, column.expression)
+		operators = append(operators, keysetOperator(column.descending))
+		placeholders = append(placeholders, f.addQueryArg(after.Values[index]))
+	}
+
+	columns = append(columns, idColumn)
+	operators = append(operators, keysetOperator(false))
+	placeholders = append(placeholders, f.addQueryArg(after.ID))
+
+	alternatives := make([]string, 0, len(columns))
+
+	for index := range columns {
+		conditions := make([]string, 0, index+1)
+
+		for previous := range index {
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", columns[previous], equalsOperator, placeholders[previous]))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", columns[index], operators[index], placeholders[index]))
+		alternatives = append(alternatives, "("+strings.Join(conditions, " "+andOperator+" ")+")")
+	}
+
+	return f.addStaticCondition("(" + strings.Join(alternatives, " "+orOperator+" ") + ")")
+}
+
+// addFuzzyCondition finds the players whose nickname, first or last name look like the text and
+// sets the expression of their score. Trigram similarity finds them even with typos, without
+// pg_trgm they must contain the text and the score is the share of the value the text covers.
+func (f *filterBuilder) addFuzzyCondition(text string, trigramSupport bool) *filterBuilder {
+	fuzzyColumns := []string{nicknameColumn, firstNameColumn, lastNameColumn}
+	conditions := make([]string, 0, len(fuzzyColumns))
+	scores := make([]string, 0, len(fuzzyColumns))
+
+	if trigramSupport {
+		placeholder := f.addArg(text)
+
+		for _, column := range fuzzyColumns {
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", column, similarOperator, placeholder))
+			scores = append(scores, fmt.Sprintf("similarity(%s, %s)", column, placeholder))
+		}
+	} else {
+		placeholder := f.addArg(containsPattern(text))
+
+		for _, column := range fuzzyColumns {
+			condition := fmt.Sprintf("%s %s %s", column, ilikeOperator, placeholder)
+			conditions = append(conditions, condition)
+			scores = append(scores, fmt.Sprintf(
+				"CASE WHEN %s THEN %d::real / char_length(%s) ELSE 0 END", condition, utf8.RuneCountInString(text), column,
+			))
+		}
+	}
+
+	f.score = "GREATEST(" + strings.Join(scores, ", ") + ")::real"
+
+	return f.addStaticCondition("(" + strings.Join(conditions, " "+orOperator+" ") + ")")
+}
+
+// addArg adds an argument used by the query and the count and returns its placeholder.
+// It can only be used before the count statement is built.
+func (f *filterBuilder) addArg(value any) string {
+	f.countArgs = append(f.countArgs, value)
+
+	return f.addQueryArg(value)
+}
+
+// scoreColumn returns the expression selected as score of the players.
+func (f *filterBuilder) scoreColumn() string {
+	if f.score == "" {
+		return noScore
+	}
+
+	return f.score
+}
+
+// addQueryArg adds an argument only used by the query and returns its placeholder.
+func (f *filterBuilder) addQueryArg(value any) string {
+	f.queryArgs = append(f.queryArgs, value)
+
+	return fmt.Sprintf("$%d", len(f.queryArgs))
+}
+
+// limitWithNextPage returns the limit plus the first player of the next page.
+func limitWithNextPage(limit uint16) int {
+	return int(limit) + 1
+}
+
+// keysetOperator returns the operator that finds the values after the cursor.
+func keysetOperator(descending bool) string {
+	if descending {
+		return lessOperator
+	}
+
+	return greaterOperator
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, backslash is the default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// prefixPattern returns a LIKE pattern that matches values starting with the given text.
+func prefixPattern(text string) string {
+	return likeEscaper.Replace(text) + "%"
+}
+
+// containsPattern returns a LIKE pattern that matches values containing the given text.
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+// insertColumns number of columns set by createPlayerSQL.
+const insertColumns = 11
+
+// insertValues returns the values of the columns set by createPlayerSQL.
+func (d *dbPlayer) insertValues() []any {
+	return []any{
+		d.ID.String(), d.FirstName, d.LastName,
+		d.Nickname, d.Email, d.Password, d.Country,
+		d.DateCreated, d.DateUpdated,
+		d.CanonicalNickname, d.CanonicalEmail,
+	}
+}
+
+// updateValues returns the values of the parameters of updatePlayerSQL.
+func updateValues(player *players.Player) []any {
+	return []any{
+		player.FirstName, player.LastName,
+		player.Nickname, player.Email.Address, player.Country, player.Password,
+		player.DateUpdated, player.ID.String(), player.Version,
+		players.CanonicalNickname(player.Nickname), players.CanonicalEmail(player.Email.Address),
+		toNullEmail(player.PendingEmail),
+	}
+}
+
+// valuesPlaceholders returns the placeholders of a multi-row insert, e.g. ($1, $2), ($3, $4).
+func valuesPlaceholders(rows, columns int) string {
+	var placeholders strings.Builder
+
+	for row := range rows {
+		if row > 0 {
+			placeholders.WriteString(", ")
+		}
+
+		placeholders.WriteString("(")
+
+		for column := 1; column <= columns; column++ {
+			if column > 1 {
+				placeholders.WriteString(", ")
+			}
+
+			fmt.Fprintf(&placeholders, "$%d", row*columns+column)
+		}
+
+		placeholders.WriteString(")")
+	}
+
+	return placeholders.String()
+}
+
+// toCanonicalKeys returns the canonical nicknames and emails of the given filters, empty ones are left out.
+func toCanonicalKeys(filters []players.PlayerFilter) ([]string, []string) {
+	nicknames := make([]string, 0, len(filters))
+	emails := make([]string, 0, len(filters))
+
+	for _, filter := range filters {
+		if filter.Nickname != "" {
+			nicknames = append(nicknames, players.CanonicalNickname(filter.Nickname))
+		}
+
+		if filter.Email != "" {
+			emails = append(emails, players.CanonicalEmail(filter.Email))
+		}
+	}
+
+	return nicknames, emails
+}
+
+func toStringIDs(playerIDs []players.PlayerID) []string {
+	ids := make([]string, 0, len(playerIDs))
+
+	for _, playerID := range playerIDs {
+		ids = append(ids, playerID.String())
+	}
+
+	return ids
+}
+
 func toDBPlayer(player *players.Player) dbPlayer {
 	return dbPlayer{
 		ID:          uuid.UUID(*player.ID),
@@ -111,6 +608,9 @@ func toDBPlayer(player *players.Player) dbPlayer {
 		Country:     player.Country,
 		DateCreated: player.DateCreated,
 		DateUpdated: player.DateUpdated,
+		// canonical forms are defined by the domain.
+		CanonicalNickname: players.CanonicalNickname(player.Nickname),
+		CanonicalEmail:    players.CanonicalEmail(player.Email.Address),
 	}
 }
 
@@ -123,3 +623,49 @@ func toPlayerItems(dbPlayerItems []dbPlayerItem) []players.PlayerItem {
 
 	return result
 }
+
+func fromNullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	utcTime := value.Time.UTC()
+
+	return &utcTime
+}
+
+func toNullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func fromNullEmail(value sql.NullString) *mail.Address {
+	if !value.Valid {
+		return nil
+	}
+
+	return &mail.Address{Address: value.String}
+}
+
+func toNullEmail(value *mail.Address) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+
+	return toNullString(value.Address)
+}
+
+func toNullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *value, Valid: true}
+}
+
+func toNullUUID(playerID *players.PlayerID) uuid.NullUUID {
+	if playerID == nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: uuid.UUID(*playerID), Valid: true}
+}
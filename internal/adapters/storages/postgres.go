@@ -2,9 +2,23 @@ package storages
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
-	_ "github.com/lib/pq" // just to load drivers
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/lib/pq"
+)
+
+// uniqueViolationCode postgres error code for unique constraint violations.
+const uniqueViolationCode = "23505"
+
+// unique indexes on the player nickname and email, the canonical ones compare players
+// by the canonical forms defined by the domain.
+const (
+	activeNicknameIndex          = "players_active_nickname_idx"
+	activeEmailIndex             = "players_active_email_idx"
+	activeCanonicalNicknameIndex = "players_active_canonical_nickname_idx"
+	activeCanonicalEmailIndex    = "players_active_canonical_email_idx"
 )
 
 // Parameters contains postgres information.
@@ -62,3 +76,22 @@ func CloseConnection(conn *sql.DB) error {
 
 	return nil
 }
+
+// toPlayerAlreadyExistsError translates a unique violation on the player nickname or email
+// into a PlayerAlreadyExistsError, it returns nil for any other error. Postgres reports one
+// violation at a time, so only one of the flags is set.
+func toPlayerAlreadyExistsError(err error) *players.PlayerAlreadyExistsError {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != uniqueViolationCode {
+		return nil
+	}
+
+	switch pqErr.Constraint {
+	case activeNicknameIndex, activeCanonicalNicknameIndex:
+		return &players.PlayerAlreadyExistsError{WasNickname: true}
+	case activeEmailIndex, activeCanonicalEmailIndex:
+		return &players.PlayerAlreadyExistsError{WasEmail: true}
+	default:
+		return nil
+	}
+}
@@ -3,12 +3,17 @@ package storages
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/mail"
+	"sync"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/players"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type StorageSetup struct {
@@ -20,13 +25,29 @@ type StorageSetup struct {
 type Storage struct {
 	db     *sql.DB
 	logger *slog.Logger
+	// trigramSupport indicates if pg_trgm was found, once it is found it is not looked up again.
+	trigramSupport bool
+	// trigramCheckedAt date pg_trgm was last looked up without finding it.
+	trigramCheckedAt time.Time
+	// trigramFallbackLogged indicates if the fallback to ILIKE was already logged.
+	trigramFallbackLogged bool
+	trigramMutex          sync.Mutex
 }
 
+// trigramCheckInterval time fuzzy searches use ILIKE before pg_trgm is looked up again,
+// so an extension installed while the service runs is used without a restart.
+const trigramCheckInterval = time.Minute
+
 // Queries.
 const (
 	createPlayerSQL = `INSERT INTO players(
-	id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated) 
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated,canonical_nickname,canonical_email) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	createPlayersSQL = `INSERT INTO players(
+	id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated,canonical_nickname,canonical_email) 
+	VALUES %s`
+	// a nickname or email that does not change keeps its canonical form, so players without
+	// one because it collides with another player can still be updated.
 	updatePlayerSQL = `UPDATE players 
 	SET firstname = $1,
 	lastname = $2,
@@ -34,28 +55,200 @@ const (
 	email = $4,
 	country = $5, 
 	usrpwd = $6,
-	date_updated = $7 
-	WHERE id = $8`
-	deletePlayerSQL = "DELETE FROM players WHERE id = $1"
-	selectByIDSQL   = `SELECT id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated 
-	FROM players 
+	date_updated = $7,
+	canonical_nickname = CASE WHEN nickname = $3 THEN canonical_nickname ELSE $10 END,
+	canonical_email = CASE WHEN email = $4 THEN canonical_email ELSE $11 END,
+	pending_email = $12,
+	version = version + 1 
+	WHERE id = $8 AND version = $9 AND date_deleted IS NULL`
+	deletePlayerSQL = `UPDATE players 
+	SET date_deleted = $1,
+	version = version + 1 
+	WHERE id = $2 AND version = $3 AND date_deleted IS NULL`
+	restorePlayerSQL = `UPDATE players 
+	SET date_deleted = NULL,
+	version = version + 1 
+	WHERE id = $1 AND date_deleted IS NOT NULL`
+	purgeDeletedPlayersSQL = `DELETE FROM players 
+	WHERE id IN (SELECT id FROM players WHERE date_deleted < $1 LIMIT $2) 
+	RETURNING id`
+	selectPlayerSQL = `SELECT id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated,
+	failed_login_attempts,locked_until,date_deleted,
+	status,status_reason,status_actor,suspended_until,date_status_changed,version,
+	email_verified,pending_email 
+	FROM players `
+	selectByIDSQL        = selectPlayerSQL + "WHERE id = $1 AND date_deleted IS NULL"
+	selectByIDsSQL       = selectPlayerSQL + "WHERE id = ANY($1) AND date_deleted IS NULL"
+	selectDeletedByIDSQL = selectPlayerSQL + "WHERE id = $1 AND date_deleted IS NOT NULL"
+	// players without canonical forms that collide with each other are told apart by
+	// the login exactly as they typed it.
+	selectByEmailOrNicknameSQL = selectPlayerSQL + `WHERE (` + lookupEmailExpression + ` = $1 OR ` + lookupNicknameExpression + ` = $2) 
+	AND date_deleted IS NULL 
+	ORDER BY ` + lookupEmailExpression + ` = $1 DESC, (email = $3 OR nickname = $3) DESC, date_created 
+	LIMIT 1`
+	selectByNicknameAndEmail = `SELECT (SELECT COUNT(*) FROM players WHERE ` + lookupNicknameExpression + ` = $1 AND date_deleted IS NULL) AS count_nickname, 
+	(SELECT COUNT(*) FROM players WHERE ` + lookupEmailExpression + ` = $2 AND date_deleted IS NULL) AS count_email`
+	selectByNicknameAndEmailAndID = `SELECT (SELECT COUNT(*) FROM players WHERE ` + lookupNicknameExpression + ` = $1 AND id <> $3 AND date_deleted IS NULL) AS count_nickname, 
+	(SELECT COUNT(*) FROM players WHERE ` + lookupEmailExpression + ` = $2 AND id <> $3 AND date_deleted IS NULL) AS count_email`
+	selectKeysByNicknamesOrEmailsSQL = `SELECT id, ` + lookupNicknameExpression + `, ` + lookupEmailExpression + ` FROM players 
+	WHERE (` + lookupNicknameExpression + ` = ANY($1) OR ` + lookupEmailExpression + ` = ANY($2)) AND date_deleted IS NULL`
+	selectByFilterSQL     = "SELECT id, firstname, lastname, nickname, country, " + lookupNicknameExpression + ", status, date_created, date_updated, %s FROM players %s;"
+	trigramInstalledSQL   = "SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')"
+	countByFilterSQL      = "SELECT COUNT(id) FROM players %s;"
+	createLoginAttemptSQL = `INSERT INTO login_attempts(
+	player_id,source,succeeded,date_created) 
+	VALUES ($1, $2, $3, $4)`
+	countFailedLoginAttemptsBySourceSQL = `SELECT COUNT(*) FROM login_attempts 
+	WHERE source = $1 AND succeeded = FALSE AND date_created >= $2`
+	incrementFailedLoginAttemptsSQL = `UPDATE players 
+	SET failed_login_attempts = CASE WHEN locked_until IS NOT NULL AND locked_until <= $2 THEN 1 ELSE failed_login_attempts + 1 END,
+	locked_until = CASE WHEN locked_until IS NOT NULL AND locked_until <= $2 THEN NULL ELSE locked_until END 
+	WHERE id = $1 
+	RETURNING failed_login_attempts`
+	updateLockStateSQL = `UPDATE players 
+	SET failed_login_attempts = $1,
+	locked_until = $2 
+	WHERE id = $3`
+	updatePasswordSQL = `UPDATE players 
+	SET usrpwd = $1 
+	WHERE id = $2`
+	changePasswordSQL = `UPDATE players 
+	SET usrpwd = $1,
+	date_updated = $2,
+	version = version + 1,
+	failed_login_attempts = CASE WHEN $5 THEN 0 ELSE failed_login_attempts END,
+	locked_until = CASE WHEN $5 THEN NULL ELSE locked_until END 
+	WHERE id = $3 AND version = $4 AND date_deleted IS NULL`
+	createPasswordHistorySQL = `INSERT INTO player_password_history(
+	player_id,usrpwd,date_created) 
+	VALUES ($1, $2, $3)`
+	trimPasswordHistorySQL = `DELETE FROM player_password_history 
+	WHERE player_id = $1 AND id NOT IN (
+		SELECT id FROM player_password_history WHERE player_id = $1 ORDER BY id DESC LIMIT $2
+	)`
+	createPasswordResetTokenSQL = `INSERT INTO password_reset_tokens(
+	token_hash,player_id,date_expires,date_created) 
+	VALUES ($1, $2, $3, $4)`
+	deleteExpiredPasswordResetTokensSQL = `DELETE FROM password_reset_tokens 
+	WHERE player_id = $1 AND date_expires <= $2`
+	selectPasswordResetTokenSQL = `SELECT token_hash, player_id, date_expires, date_created 
+	FROM password_reset_tokens 
+	WHERE token_hash = $1`
+	countPasswordResetTokensSQL = `SELECT COUNT(*) FROM password_reset_tokens 
+	WHERE player_id = $1 AND date_expires > $2`
+	consumePasswordResetTokenSQL = `DELETE FROM password_reset_tokens 
+	WHERE token_hash = $1 AND player_id = $2`
+	deletePasswordResetTokensSQL = `DELETE FROM password_reset_tokens 
+	WHERE player_id = $1`
+	createEmailVerificationTokenSQL = `INSERT INTO email_verification_tokens(
+	token_hash,player_id,email,date_expires,date_created) 
+	VALUES ($1, $2, $3, $4, $5)`
+	deleteExpiredEmailVerificationTokensSQL = `DELETE FROM email_verification_tokens 
+	WHERE player_id = $1 AND date_expires <= $2`
+	selectEmailVerificationTokenSQL = `SELECT token_hash, player_id, email, date_expires, date_created 
+	FROM email_verification_tokens 
+	WHERE token_hash = $1`
+	consumeEmailVerificationTokenSQL = `DELETE FROM email_verification_tokens 
+	WHERE token_hash = $1 AND player_id = $2`
+	verifyEmailSQL = `UPDATE players 
+	SET email = $1,
+	canonical_email = $2,
+	email_verified = TRUE,
+	pending_email = $3,
+	date_updated = $4,
+	version = version + 1 
+	WHERE id = $5 AND version = $6 AND date_deleted IS NULL`
+	deleteExpiredIdempotencyKeySQL = `DELETE FROM idempotency_keys 
+	WHERE source = $1 AND idempotency_key = $2 AND date_expires <= $3`
+	createIdempotencyKeySQL = `INSERT INTO idempotency_keys(
+	source,idempotency_key,fingerprint,usrpwd,player_id,reply,date_expires,date_created) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+	ON CONFLICT (source, idempotency_key) DO NOTHING`
+	selectIdempotencyKeySQL = `SELECT source, idempotency_key, fingerprint, usrpwd, reply, date_expires, date_created 
+	FROM idempotency_keys 
+	WHERE source = $1 AND idempotency_key = $2`
+	deletePlayerIdempotencyKeysSQL = `DELETE FROM idempotency_keys 
+	WHERE player_id = $1`
+	deleteExpiredIdempotencyKeysSQL = `DELETE FROM idempotency_keys WHERE date_expires <= $1`
+	selectPasswordHistorySQL        = `SELECT usrpwd FROM player_password_history 
+	WHERE player_id = $1 
+	ORDER BY id DESC 
+	LIMIT $2`
+	changeStatusSQL = `UPDATE players 
+	SET status = $1,
+	status_reason = $2,
+	status_actor = $3,
+	suspended_until = $4,
+	date_status_changed = $5,
+	version = version + 1 
+	WHERE id = $6 AND status = $7 AND date_deleted IS NULL`
+	createStatusChangeSQL = `INSERT INTO player_status_changes(
+	player_id,from_status,to_status,reason,actor,suspended_until,date_created) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	expireSuspensionsSQL = `WITH expired AS (
+		UPDATE players 
+		SET status = $1,
+		status_reason = $2,
+		status_actor = $3,
+		suspended_until = NULL,
+		date_status_changed = $4,
+		version = version + 1 
+		WHERE status = $5 AND suspended_until <= $4 
+		RETURNING id
+	) 
+	INSERT INTO player_status_changes(player_id,from_status,to_status,reason,actor,date_created) 
+	SELECT id, $5, $1, $2, $3, $4 FROM expired 
+	RETURNING player_id`
+	countPlayersByCountrySQL = `SELECT country, COUNT(*) FROM players 
+	WHERE date_deleted IS NULL 
+	GROUP BY country`
+	selectStoredCountriesSQL = "SELECT DISTINCT country FROM players ORDER BY country"
+	replaceCountrySQL        = `UPDATE players 
+	SET country = $1,
+	date_updated = $3,
+	version = version + 1 
+	WHERE country = $2`
+	selectWithoutCanonicalKeysSQL = `SELECT id, nickname, email FROM players 
+	WHERE canonical_nickname IS NULL OR canonical_email IS NULL 
+	ORDER BY date_created, id`
+	updateCanonicalKeysSQL = `UPDATE players 
+	SET canonical_nickname = $2,
+	canonical_email = $3 
 	WHERE id = $1`
-	selectByNicknameAndEmail = `SELECT (SELECT COUNT(*) FROM players WHERE nickname = $1) AS count_nickname, 
-	(SELECT COUNT(*) FROM players WHERE email = $2) AS count_email`
-	selectByNicknameAndEmailAndID = `SELECT (SELECT COUNT(*) FROM players WHERE nickname = $1 AND id <> $3) AS count_nickname, 
-	(SELECT COUNT(*) FROM players WHERE email = $2 AND id <> $3) AS count_email`
-	selectByFilterSQL = "SELECT id, firstname, lastname, nickname, country FROM players %s;"
-	countByFilterSQL  = "SELECT COUNT(id) FROM players %s;"
 )
 
 // Error messages.
 var (
-	errPlayerCannotBeStored  = errors.New("player cannot be stored")
-	errPlayerCannotBeUpdated = errors.New("player cannot be updated")
-	errPlayerCannotBeDeleted = errors.New("player cannot be deleted")
-	errPlayerCannotBeRead    = errors.New("player cannot be read in the database")
-	errPlayersCannotBeRead   = errors.New("players cannot be read in the database")
-	errUnableToSearchPlayers = errors.New("unable to search players")
+	errPlayerCannotBeStored           = errors.New("player cannot be stored")
+	errPlayersCannotBeStored          = errors.New("players cannot be stored")
+	errPlayersCannotBeUpdated         = errors.New("players cannot be updated")
+	errPlayersCannotBeDeleted         = errors.New("players cannot be deleted")
+	errPlayerCannotBeUpdated          = errors.New("player cannot be updated")
+	errPlayerCannotBeDeleted          = errors.New("player cannot be deleted")
+	errPlayerCannotBeRestored         = errors.New("player cannot be restored")
+	errPlayersCannotBePurged          = errors.New("deleted players cannot be purged")
+	errPlayerCannotBeRead             = errors.New("player cannot be read in the database")
+	errPlayersCannotBeRead            = errors.New("players cannot be read in the database")
+	errUnableToSearchPlayers          = errors.New("unable to search players")
+	errLoginAttemptCannotBeStored     = errors.New("login attempt cannot be stored")
+	errLoginAttemptsCannotBeRead      = errors.New("login attempts cannot be read in the database")
+	errLockStateCannotBeUpdated       = errors.New("player lock state cannot be updated")
+	errPasswordCannotBeUpdated        = errors.New("player password cannot be updated")
+	errPasswordCannotBeChanged        = errors.New("player password cannot be changed")
+	errPasswordHistoryCannotBeRead    = errors.New("player password history cannot be read in the database")
+	errIdempotencyKeyCannotBeSaved    = errors.New("idempotency key cannot be saved in the database")
+	errIdempotencyKeyCannotBeRead     = errors.New("idempotency key cannot be read in the database")
+	errIdempotencyKeyCannotBePurged   = errors.New("expired idempotency keys cannot be purged in the database")
+	errResetTokenCannotBeSaved        = errors.New("password reset token cannot be saved in the database")
+	errResetTokenCannotBeRead         = errors.New("password reset token cannot be read in the database")
+	errVerificationTokenCannotBeSaved = errors.New("email verification token cannot be saved in the database")
+	errVerificationTokenCannotBeRead  = errors.New("email verification token cannot be read in the database")
+	errEmailCannotBeVerified          = errors.New("player email cannot be verified in the database")
+	errStatusCannotBeChanged          = errors.New("player status cannot be changed")
+	errSuspensionsCannotExpire        = errors.New("player suspensions cannot be expired")
+	errCountriesCannotBeRead          = errors.New("player countries cannot be read in the database")
+	errCountryCannotBeReplaced        = errors.New("player country cannot be replaced")
+	errCanonicalKeysCannotBeSaved     = errors.New("player canonical nickname and email cannot be saved")
 )
 
 // NewPlayerRepository creates a new player repository that will use a rdb.
@@ -85,11 +278,15 @@ func (s *Storage) Save(ctx context.Context, newPlayer players.Player) error {
 
 	player := toDBPlayer(&newPlayer)
 
-	_, err = stmt.ExecContext(ctx,
-		player.ID.String(), player.FirstName, player.LastName,
-		player.Nickname, player.Email, player.Password, player.Country,
-		player.DateCreated, player.DateUpdated,
-	)
+	_, err = stmt.ExecContext(ctx, player.insertValues()...)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email or nickname already stored",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return alreadyExistsErr
+	}
+
 	if err != nil {
 		s.logger.Error("executing insert to store player",
 			slog.String("player_id", player.ID.String()),
@@ -101,8 +298,41 @@ func (s *Storage) Save(ctx context.Context, newPlayer players.Player) error {
 	return nil
 }
 
-// Update player in the player repository.
-func (s *Storage) Update(ctx context.Context, player players.Player) error {
+// SaveAll persists the new players with a single multi-row insert, none of them is stored if any fails.
+func (s *Storage) SaveAll(ctx context.Context, newPlayers []players.Player) error {
+	s.logger.Debug("storing players", slog.Int("players", len(newPlayers)))
+
+	values := make([]any, 0, len(newPlayers)*insertColumns)
+
+	for index := range newPlayers {
+		player := toDBPlayer(&newPlayers[index])
+		values = append(values, player.insertValues()...)
+	}
+
+	query := fmt.Sprintf(createPlayersSQL, valuesPlaceholders(len(newPlayers), insertColumns))
+
+	_, err := s.db.ExecContext(ctx, query, values...)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email or nickname already stored",
+			slog.String("error", err.Error()))
+
+		return alreadyExistsErr
+	}
+
+	if err != nil {
+		s.logger.Error("executing insert to store players",
+			slog.Int("players", len(newPlayers)),
+			slog.String("error", err.Error()))
+
+		return errPlayersCannotBeStored
+	}
+
+	return nil
+}
+
+// Update player in the player repository if it still has the given version,
+// it returns false if the player was changed or deleted in the meantime.
+func (s *Storage) Update(ctx context.Context, player players.Player) (bool, error) {
 	s.logger.Debug("updating player", slog.String("player_id", player.ID.String()))
 
 	stmt, err := s.db.Prepare(updatePlayerSQL)
@@ -111,29 +341,110 @@ func (s *Storage) Update(ctx context.Context, player players.Player) error {
 			slog.String("player_id", player.ID.String()),
 			slog.String("error", err.Error()))
 
-		return errPlayerCannotBeUpdated
+		return false, errPlayerCannotBeUpdated
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx,
-		player.FirstName, player.LastName,
-		player.Nickname, player.Email.Address, player.Country, player.Password,
-		player.DateUpdated, player.ID.String(),
-	)
+	result, err := stmt.ExecContext(ctx, updateValues(&player)...)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email or nickname already stored",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return false, alreadyExistsErr
+	}
+
 	if err != nil {
 		s.logger.Error("executing to update player",
 			slog.String("player_id", player.ID.String()),
 			slog.String("error", err.Error()))
 
-		return errPlayerCannotBeUpdated
+		return false, errPlayerCannotBeUpdated
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by player update",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPlayerCannotBeUpdated
+	}
+
+	return affected > 0, nil
+}
+
+// UpdateAll updates the players in a single transaction if they still have their versions. It returns
+// a BatchItemError for the first player that can't be updated, none of them is updated then.
+func (s *Storage) UpdateAll(ctx context.Context, batchPlayers []players.Player) error {
+	s.logger.Debug("updating players", slog.Int("players", len(batchPlayers)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to update players", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeUpdated
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, updatePlayerSQL)
+	if err != nil {
+		s.logger.Error("building query", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeUpdated
+	}
+
+	defer stmt.Close()
+
+	for index := range batchPlayers {
+		player := &batchPlayers[index]
+
+		result, err := stmt.ExecContext(ctx, updateValues(player)...)
+		if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+			s.logger.Debug("player with the same email or nickname already stored",
+				slog.String("player_id", player.ID.String()),
+				slog.String("error", err.Error()))
+
+			return &players.BatchItemError{Index: index, Err: alreadyExistsErr}
+		}
+
+		if err != nil {
+			s.logger.Error("executing to update player",
+				slog.String("player_id", player.ID.String()),
+				slog.String("error", err.Error()))
+
+			return errPlayersCannotBeUpdated
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			s.logger.Error("reading rows affected by player update",
+				slog.String("player_id", player.ID.String()),
+				slog.String("error", err.Error()))
+
+			return errPlayersCannotBeUpdated
+		}
+
+		if affected == 0 {
+			return &players.BatchItemError{Index: index, Err: players.ErrPlayerVersionConflict}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing players update", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeUpdated
 	}
 
 	return nil
 }
 
-// Delete player in the repository.
-func (s *Storage) Delete(ctx context.Context, playerID players.PlayerID) error {
+// Delete marks the player as deleted in the repository if it still has the given version,
+// it returns false if the player was changed or deleted in the meantime.
+func (s *Storage) Delete(ctx context.Context, playerID players.PlayerID, version int64, deletedAt time.Time) (bool, error) {
 	s.logger.Debug("deleting player", slog.String("player_id", playerID.String()))
 
 	stmt, err := s.db.Prepare(deletePlayerSQL)
@@ -142,40 +453,165 @@ func (s *Storage) Delete(ctx context.Context, playerID players.PlayerID) error {
 			slog.String("player_id", playerID.String()),
 			slog.String("error", err.Error()))
 
-		return errPlayerCannotBeDeleted
+		return false, errPlayerCannotBeDeleted
 	}
 
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx,
-		playerID.String(),
+	result, err := stmt.ExecContext(ctx,
+		deletedAt, playerID.String(), version,
 	)
 	if err != nil {
 		s.logger.Error("executing to delete player",
 			slog.String("player_id", playerID.String()),
 			slog.String("error", err.Error()))
 
-		return errPlayerCannotBeDeleted
+		return false, errPlayerCannotBeDeleted
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by player deletion",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPlayerCannotBeDeleted
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteAll marks the players as deleted in a single transaction if they still have their versions.
+// It returns a BatchItemError for the first player that can't be deleted, none of them is deleted then.
+func (s *Storage) DeleteAll(ctx context.Context, batchPlayers []players.Player, deletedAt time.Time) error {
+	s.logger.Debug("deleting players", slog.Int("players", len(batchPlayers)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to delete players", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeDeleted
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, deletePlayerSQL)
+	if err != nil {
+		s.logger.Error("building query", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeDeleted
+	}
+
+	defer stmt.Close()
+
+	for index, player := range batchPlayers {
+		result, err := stmt.ExecContext(ctx, deletedAt, player.ID.String(), player.Version)
+		if err != nil {
+			s.logger.Error("executing to delete player",
+				slog.String("player_id", player.ID.String()),
+				slog.String("error", err.Error()))
+
+			return errPlayersCannotBeDeleted
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			s.logger.Error("reading rows affected by player deletion",
+				slog.String("player_id", player.ID.String()),
+				slog.String("error", err.Error()))
+
+			return errPlayersCannotBeDeleted
+		}
+
+		if affected == 0 {
+			return &players.BatchItemError{Index: index, Err: players.ErrPlayerVersionConflict}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing players deletion", slog.String("error", err.Error()))
+
+		return errPlayersCannotBeDeleted
+	}
+
+	return nil
+}
+
+// Restore removes the deleted mark of the given player.
+func (s *Storage) Restore(ctx context.Context, playerID players.PlayerID) error {
+	s.logger.Debug("restoring player", slog.String("player_id", playerID.String()))
+
+	_, err := s.db.ExecContext(ctx, restorePlayerSQL, playerID.String())
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email or nickname already stored",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return alreadyExistsErr
+	}
+
+	if err != nil {
+		s.logger.Error("executing to restore player",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return errPlayerCannotBeRestored
 	}
 
 	return nil
 }
 
-// GetByID get a player with the given id.
+// PurgeDeletedBefore physically removes at most limit players deleted before the given date
+// and returns their ids.
+func (s *Storage) PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) ([]players.PlayerID, error) {
+	s.logger.Debug("purging deleted players", slog.Time("before", before), slog.Int("limit", limit))
+
+	rows, err := s.db.QueryContext(ctx, purgeDeletedPlayersSQL, before, limit)
+	if err != nil {
+		s.logger.Error("executing to purge deleted players", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBePurged
+	}
+
+	defer rows.Close()
+
+	purgedIDs := make([]players.PlayerID, 0)
+
+	for rows.Next() {
+		var playerID uuid.UUID
+
+		err := rows.Scan(&playerID)
+		if err != nil {
+			s.logger.Error("scanning purged player id", slog.String("error", err.Error()))
+
+			return nil, errPlayersCannotBePurged
+		}
+
+		purgedIDs = append(purgedIDs, players.PlayerID(playerID))
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if purged rows has an error", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBePurged
+	}
+
+	return purgedIDs, nil
+}
+
+// GetByID get a player with the given id. It returns nil if the player does not exist.
 func (s *Storage) GetByID(ctx context.Context, playerID players.PlayerID) (*players.Player, error) {
 	s.logger.Debug("get player by id", slog.String("player id", playerID.String()))
 
 	var player dbPlayer
-	// id,firstname,lastname,nickname,usrpwd,country,date_created,date_updated
 	err := s.db.QueryRowContext(ctx, selectByIDSQL, uuid.UUID(playerID)).
-		Scan(
-			&player.ID, &player.FirstName,
-			&player.LastName, &player.Nickname,
-			&player.Email, &player.Password,
-			&player.Country, &player.DateCreated,
-			&player.DateUpdated,
-		)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		Scan(player.columns()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
 		s.logger.Error("getting player by id",
 			slog.String("player_id", playerID.String()),
 			slog.String("error", err.Error()))
@@ -188,7 +624,100 @@ func (s *Storage) GetByID(ctx context.Context, playerID players.PlayerID) (*play
 	return &got, nil
 }
 
-// GetPlayersWithEmailOrNickName get players with given email or nickname.
+// GetByIDs get the players with the given ids, players that do not exist are left out.
+func (s *Storage) GetByIDs(ctx context.Context, playerIDs []players.PlayerID) ([]players.Player, error) {
+	s.logger.Debug("get players by ids", slog.Int("players", len(playerIDs)))
+
+	rows, err := s.db.QueryContext(ctx, selectByIDsSQL, pq.Array(toStringIDs(playerIDs)))
+	if err != nil {
+		s.logger.Error("getting players by ids", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	defer rows.Close()
+
+	storedPlayers := make([]players.Player, 0, len(playerIDs))
+
+	for rows.Next() {
+		var player dbPlayer
+
+		err := rows.Scan(player.columns()...)
+		if err != nil {
+			s.logger.Error("scanning player", slog.String("error", err.Error()))
+
+			return nil, errPlayersCannotBeRead
+		}
+
+		storedPlayers = append(storedPlayers, player.toPlayer())
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if player rows has an error", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	return storedPlayers, nil
+}
+
+// GetDeletedByID get a deleted player with the given id. It returns nil if there is no deleted player with the id.
+func (s *Storage) GetDeletedByID(ctx context.Context, playerID players.PlayerID) (*players.Player, error) {
+	s.logger.Debug("get deleted player by id", slog.String("player id", playerID.String()))
+
+	var player dbPlayer
+
+	err := s.db.QueryRowContext(ctx, selectDeletedByIDSQL, uuid.UUID(playerID)).
+		Scan(player.columns()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("getting deleted player by id",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return nil, errPlayerCannotBeRead
+	}
+
+	got := player.toPlayer()
+
+	return &got, nil
+}
+
+// GetByEmailOrNickname get the player whose email or nickname is the given login,
+// they are compared by their canonical forms. If the login is the email of a player and
+// the nickname of another one, the player with that email is returned. Players without
+// canonical forms are compared by the lower case form of their values, if several of them
+// match, the one whose email or nickname is exactly the login is returned.
+// It returns nil if the player does not exist.
+func (s *Storage) GetByEmailOrNickname(ctx context.Context, login string) (*players.Player, error) {
+	s.logger.Debug("get player by email or nickname", slog.String("login", login))
+
+	var player dbPlayer
+	err := s.db.QueryRowContext(ctx, selectByEmailOrNicknameSQL,
+		players.CanonicalEmail(login), players.CanonicalNickname(login), login).
+		Scan(player.columns()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("getting player by email or nickname",
+			slog.String("login", login),
+			slog.String("error", err.Error()))
+
+		return nil, errPlayerCannotBeRead
+	}
+
+	got := player.toPlayer()
+
+	return &got, nil
+}
+
+// GetPlayersWithEmailOrNickName get players with given email or nickname, they are
+// compared by their canonical forms.
 func (s *Storage) GetPlayersWithEmailOrNickName(ctx context.Context, filter players.PlayerFilter) (*players.PlayerExistResult, error) {
 	s.logger.Debug("get players by nickname or email", slog.Any("filter", filter))
 
@@ -198,13 +727,13 @@ func (s *Storage) GetPlayersWithEmailOrNickName(ctx context.Context, filter play
 
 	if filter.IgnoreID == nil {
 		queryRow = s.db.QueryRowContext(ctx, selectByNicknameAndEmail,
-			filter.Nickname,
-			filter.Email,
+			players.CanonicalNickname(filter.Nickname),
+			players.CanonicalEmail(filter.Email),
 		)
 	} else {
 		queryRow = s.db.QueryRowContext(ctx, selectByNicknameAndEmailAndID,
-			filter.Nickname,
-			filter.Email,
+			players.CanonicalNickname(filter.Nickname),
+			players.CanonicalEmail(filter.Email),
 			filter.IgnoreID.String(),
 		)
 	}
@@ -226,6 +755,49 @@ func (s *Storage) GetPlayersWithEmailOrNickName(ctx context.Context, filter play
 	return &result, nil
 }
 
+// GetPlayersWithEmailsOrNicknames get the keys of the players with any of the emails or nicknames
+// of the given filters in a single query, they are compared by their canonical forms.
+func (s *Storage) GetPlayersWithEmailsOrNicknames(ctx context.Context, filters []players.PlayerFilter) ([]players.PlayerKeys, error) {
+	s.logger.Debug("get players by nicknames or emails", slog.Int("filters", len(filters)))
+
+	nicknames, emails := toCanonicalKeys(filters)
+
+	rows, err := s.db.QueryContext(ctx, selectKeysByNicknamesOrEmailsSQL, pq.Array(nicknames), pq.Array(emails))
+	if err != nil {
+		s.logger.Error("getting players with given nicknames or emails", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	defer rows.Close()
+
+	storedKeys := make([]players.PlayerKeys, 0)
+
+	for rows.Next() {
+		var playerID uuid.UUID
+
+		var keys players.PlayerKeys
+
+		err := rows.Scan(&playerID, &keys.CanonicalNickname, &keys.CanonicalEmail)
+		if err != nil {
+			s.logger.Error("scanning player keys", slog.String("error", err.Error()))
+
+			return nil, errPlayersCannotBeRead
+		}
+
+		keys.ID = players.PlayerID(playerID)
+		storedKeys = append(storedKeys, keys)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if player keys rows has an error", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	return storedKeys, nil
+}
+
 // Search looks up players that match the given filter criteria.
 func (s *Storage) Search(ctx context.Context, searchCriteria players.SearchCriteria) (*players.SearchResult, error) {
 	s.logger.Debug("searching for players with search criteria", slog.Any("criteria", searchCriteria))
@@ -235,20 +807,22 @@ func (s *Storage) Search(ctx context.Context, searchCriteria players.SearchCrite
 		Offset: searchCriteria.Offset,
 	}
 
-	searchFilters := buildSQLFilters(searchCriteria)
+	searchFilters := buildSQLFilters(searchCriteria, s.hasTrigramSupport(ctx, searchCriteria))
 
-	count, err := s.queryCount(ctx, searchFilters)
-	if err != nil {
-		s.logger.Error("running count query of players that match given criteria",
-			slog.Any("filter", searchFilters),
-			slog.String("query", searchFilters.countStatement),
-			slog.String("error", err.Error()),
-		)
+	if !searchCriteria.SkipTotal {
+		count, err := s.queryCount(ctx, searchFilters)
+		if err != nil {
+			s.logger.Error("running count query of players that match given criteria",
+				slog.Any("filter", searchFilters),
+				slog.String("query", searchFilters.countStatement),
+				slog.String("error", err.Error()),
+			)
 
-		return nil, errUnableToSearchPlayers
-	}
+			return nil, errUnableToSearchPlayers
+		}
 
-	result.Total = count
+		result.Total = count
+	}
 
 	s.logger.Debug(
 		"search players with filters",
@@ -267,35 +841,975 @@ func (s *Storage) Search(ctx context.Context, searchCriteria players.SearchCrite
 		return nil, errUnableToSearchPlayers
 	}
 
+	// the query reads one player more than the limit to know if there is a next page.
+	if searchCriteria.Limit > 0 && len(playersFound) > int(searchCriteria.Limit) {
+		playersFound = playersFound[:searchCriteria.Limit]
+		result.Next = playersFound[len(playersFound)-1].toCursor(searchFilters.orderColumns)
+	}
+
 	result.Items = toPlayerItems(playersFound)
 
 	return &result, nil
 }
 
-func (s *Storage) queryCount(ctx context.Context, searchFilters *filterBuilder) (int, error) {
-	var count int
+// SaveLoginAttempt persists an authentication attempt.
+func (s *Storage) SaveLoginAttempt(ctx context.Context, attempt players.LoginAttempt) error {
+	s.logger.Debug("storing login attempt", slog.String("source", attempt.Source), slog.Bool("succeeded", attempt.Succeeded))
 
-	countStmt, err := s.db.Prepare(searchFilters.countStatement)
+	_, err := s.db.ExecContext(ctx, createLoginAttemptSQL,
+		toNullUUID(attempt.PlayerID), attempt.Source, attempt.Succeeded, attempt.DateCreated,
+	)
 	if err != nil {
-		s.logger.Error("building count players prepared statement",
-			slog.Any("filter", searchFilters),
-			slog.String("error", err.Error()),
-		)
+		s.logger.Error("executing insert to store login attempt",
+			slog.String("source", attempt.Source),
+			slog.String("error", err.Error()))
 
-		return -1, fmt.Errorf("unable to build query to count players: %w", err)
+		return errLoginAttemptCannotBeStored
 	}
 
-	defer countStmt.Close()
+	return nil
+}
 
-	row := countStmt.QueryRowContext(ctx, searchFilters.countArgs...)
+// CountFailedLoginAttemptsBySource counts failed authentication attempts from the given source since the given date.
+func (s *Storage) CountFailedLoginAttemptsBySource(ctx context.Context, source string, since time.Time) (int, error) {
+	s.logger.Debug("counting failed login attempts by source", slog.String("source", source), slog.Time("since", since))
 
-	err = row.Scan(&count)
-	if err != nil {
-		s.logger.Error("scanning count of players found",
-			slog.Any("filter", searchFilters),
-			slog.String("query", searchFilters.countStatement),
-			slog.String("error", err.Error()),
-		)
+	var count int
+
+	err := s.db.QueryRowContext(ctx, countFailedLoginAttemptsBySourceSQL, source, since).Scan(&count)
+	if err != nil {
+		s.logger.Error("counting failed login attempts by source",
+			slog.String("source", source),
+			slog.String("error", err.Error()))
+
+		return -1, errLoginAttemptsCannotBeRead
+	}
+
+	return count, nil
+}
+
+// IncrementFailedLoginAttempts increments the consecutive failed authentication attempts of the player
+// and returns the new count. The count starts again if the player lock already expired.
+func (s *Storage) IncrementFailedLoginAttempts(ctx context.Context, playerID players.PlayerID, now time.Time) (int, error) {
+	s.logger.Debug("incrementing failed login attempts", slog.String("player_id", playerID.String()))
+
+	var failedAttempts int
+
+	err := s.db.QueryRowContext(ctx, incrementFailedLoginAttemptsSQL, playerID.String(), now).Scan(&failedAttempts)
+	if err != nil {
+		s.logger.Error("executing update to increment failed login attempts",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return -1, errLockStateCannotBeUpdated
+	}
+
+	return failedAttempts, nil
+}
+
+// UpdateLockState updates the lock state of the player.
+func (s *Storage) UpdateLockState(ctx context.Context, playerID players.PlayerID, lockState players.LockState) error {
+	s.logger.Debug("updating player lock state", slog.String("player_id", playerID.String()))
+
+	_, err := s.db.ExecContext(ctx, updateLockStateSQL,
+		lockState.FailedAttempts, toNullTime(lockState.LockedUntil), playerID.String(),
+	)
+	if err != nil {
+		s.logger.Error("executing update to change player lock state",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return errLockStateCannotBeUpdated
+	}
+
+	return nil
+}
+
+// ChangeStatus moves the player from change.From to change.To and records the change.
+// It returns false if the player status is no longer change.From.
+func (s *Storage) ChangeStatus(ctx context.Context, change players.StatusChange) (bool, error) {
+	s.logger.Debug("changing player status", slog.String("player_id", change.PlayerID.String()), slog.String("status", string(change.To)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to change player status",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errStatusCannotBeChanged
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, changeStatusSQL,
+		string(change.To), toNullString(change.Reason), change.Actor,
+		toNullTime(change.SuspendedUntil), change.DateCreated,
+		change.PlayerID.String(), string(change.From),
+	)
+	if err != nil {
+		s.logger.Error("executing update to change player status",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errStatusCannotBeChanged
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by player status change",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errStatusCannotBeChanged
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, createStatusChangeSQL,
+		change.PlayerID.String(), string(change.From), string(change.To),
+		toNullString(change.Reason), change.Actor,
+		toNullTime(change.SuspendedUntil), change.DateCreated,
+	)
+	if err != nil {
+		s.logger.Error("executing insert to record player status change",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errStatusCannotBeChanged
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing player status change",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errStatusCannotBeChanged
+	}
+
+	return true, nil
+}
+
+// ExpireSuspensions activates the suspended players whose suspension expired at change.DateCreated
+// and returns their ids.
+func (s *Storage) ExpireSuspensions(ctx context.Context, change players.StatusChange) ([]players.PlayerID, error) {
+	s.logger.Debug("expiring player suspensions", slog.Time("now", change.DateCreated))
+
+	rows, err := s.db.QueryContext(ctx, expireSuspensionsSQL,
+		string(change.To), change.Reason, change.Actor,
+		change.DateCreated, string(change.From),
+	)
+	if err != nil {
+		s.logger.Error("executing to expire player suspensions", slog.String("error", err.Error()))
+
+		return nil, errSuspensionsCannotExpire
+	}
+
+	defer rows.Close()
+
+	playerIDs := make([]players.PlayerID, 0)
+
+	for rows.Next() {
+		var playerID uuid.UUID
+
+		err := rows.Scan(&playerID)
+		if err != nil {
+			s.logger.Error("scanning expired suspension player id", slog.String("error", err.Error()))
+
+			return nil, errSuspensionsCannotExpire
+		}
+
+		playerIDs = append(playerIDs, players.PlayerID(playerID))
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if expired suspension rows has an error", slog.String("error", err.Error()))
+
+		return nil, errSuspensionsCannotExpire
+	}
+
+	return playerIDs, nil
+}
+
+// UpdatePassword replaces the hashed password of the given player.
+func (s *Storage) UpdatePassword(ctx context.Context, playerID players.PlayerID, hashedPassword []byte) error {
+	s.logger.Debug("updating player password", slog.String("player_id", playerID.String()))
+
+	_, err := s.db.ExecContext(ctx, updatePasswordSQL, string(hashedPassword), playerID.String())
+	if err != nil {
+		s.logger.Error("executing update to change player password",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return errPasswordCannotBeUpdated
+	}
+
+	return nil
+}
+
+// ChangePassword stores the new hashed password of the player if its stored version is still
+// change.Version, adds the previous one to its password history, removes all its password
+// reset tokens and idempotency keys and clears its lock if change.Unlock is set. It returns
+// false if the player was changed or deleted in the meantime, or if change.ResetTokenHash
+// is given and the token was already used.
+func (s *Storage) ChangePassword(ctx context.Context, change players.PasswordChange) (bool, error) {
+	s.logger.Debug("changing player password", slog.String("player_id", change.PlayerID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to change player password",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	if change.ResetTokenHash != "" {
+		consumed, err := s.consumePasswordResetToken(ctx, tx, change)
+		if err != nil || !consumed {
+			return false, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, changePasswordSQL,
+		string(change.HashedPassword), change.DateChanged,
+		change.PlayerID.String(), change.Version, change.Unlock,
+	)
+	if err != nil {
+		s.logger.Error("executing update to change player password",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by player password change",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	if change.HistorySize > 0 {
+		_, err = tx.ExecContext(ctx, createPasswordHistorySQL,
+			change.PlayerID.String(), string(change.PreviousHashedPassword), change.DateChanged,
+		)
+		if err != nil {
+			s.logger.Error("executing insert to keep previous player password",
+				slog.String("player_id", change.PlayerID.String()),
+				slog.String("error", err.Error()))
+
+			return false, errPasswordCannotBeChanged
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, trimPasswordHistorySQL, change.PlayerID.String(), change.HistorySize)
+	if err != nil {
+		s.logger.Error("executing delete to trim player password history",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	_, err = tx.ExecContext(ctx, deletePasswordResetTokensSQL, change.PlayerID.String())
+	if err != nil {
+		s.logger.Error("executing delete to invalidate password reset tokens",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	// idempotency keys keep the hash of the password the player was created with.
+	_, err = tx.ExecContext(ctx, deletePlayerIdempotencyKeysSQL, change.PlayerID.String())
+	if err != nil {
+		s.logger.Error("executing delete of player idempotency keys",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing player password change",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	return true, nil
+}
+
+// consumePasswordResetToken removes the reset token used to change the password, it returns
+// false if the token was already used.
+func (s *Storage) consumePasswordResetToken(ctx context.Context, tx *sql.Tx, change players.PasswordChange) (bool, error) {
+	result, err := tx.ExecContext(ctx, consumePasswordResetTokenSQL, change.ResetTokenHash, change.PlayerID.String())
+	if err != nil {
+		s.logger.Error("executing delete to consume password reset token",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by password reset token consumption",
+			slog.String("player_id", change.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errPasswordCannotBeChanged
+	}
+
+	return affected > 0, nil
+}
+
+// SaveWithIdempotencyKey persists a new player and the idempotency key used to create it in the same
+// transaction. The key is stored first, so concurrent requests with the same key wait for each other
+// and only the first one creates the player. Expired keys are replaced.
+func (s *Storage) SaveWithIdempotencyKey(ctx context.Context, newPlayer players.Player, record players.IdempotencyRecord) error {
+	s.logger.Debug("storing player with idempotency key", slog.String("player_id", newPlayer.ID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to store player with idempotency key",
+			slog.String("player_id", newPlayer.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errPlayerCannotBeStored
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, deleteExpiredIdempotencyKeySQL, record.Source, record.Key, record.DateCreated)
+	if err != nil {
+		s.logger.Error("executing delete of expired idempotency key",
+			slog.String("player_id", newPlayer.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errIdempotencyKeyCannotBeSaved
+	}
+
+	reply, err := json.Marshal(toDBIdempotencyReply(&record.Reply))
+	if err != nil {
+		s.logger.Error("encoding idempotency key reply",
+			slog.String("player_id", newPlayer.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errIdempotencyKeyCannotBeSaved
+	}
+
+	result, err := tx.ExecContext(ctx, createIdempotencyKeySQL,
+		record.Source, record.Key, record.Fingerprint, string(record.HashedPassword),
+		record.Reply.ID.String(), reply, record.DateExpires, record.DateCreated,
+	)
+	if err != nil {
+		s.logger.Error("executing insert of idempotency key",
+			slog.String("player_id", newPlayer.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errIdempotencyKeyCannotBeSaved
+	}
+
+	stored, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by idempotency key insert",
+			slog.String("player_id", newPlayer.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errIdempotencyKeyCannotBeSaved
+	}
+
+	if stored == 0 {
+		return players.ErrIdempotencyKeyExists
+	}
+
+	player := toDBPlayer(&newPlayer)
+
+	_, err = tx.ExecContext(ctx, createPlayerSQL, player.insertValues()...)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email or nickname already stored",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return alreadyExistsErr
+	}
+
+	if err != nil {
+		s.logger.Error("executing insert to store player",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errPlayerCannotBeStored
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing player with idempotency key",
+			slog.String("player_id", player.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errPlayerCannotBeStored
+	}
+
+	return nil
+}
+
+// GetIdempotencyRecord get the record of the given idempotency key sent from the given source.
+// It returns nil if the key does not exist.
+func (s *Storage) GetIdempotencyRecord(ctx context.Context, source, key string) (*players.IdempotencyRecord, error) {
+	s.logger.Debug("get idempotency record")
+
+	var record players.IdempotencyRecord
+
+	var reply []byte
+
+	err := s.db.QueryRowContext(ctx, selectIdempotencyKeySQL, source, key).
+		Scan(&record.Source, &record.Key, &record.Fingerprint, &record.HashedPassword, &reply, &record.DateExpires, &record.DateCreated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("getting idempotency record", slog.String("error", err.Error()))
+
+		return nil, errIdempotencyKeyCannotBeRead
+	}
+
+	var storedReply dbIdempotencyReply
+
+	err = json.Unmarshal(reply, &storedReply)
+	if err != nil {
+		s.logger.Error("decoding idempotency record reply", slog.String("error", err.Error()))
+
+		return nil, errIdempotencyKeyCannotBeRead
+	}
+
+	record.Reply = storedReply.toPlayer()
+
+	record.DateExpires = record.DateExpires.UTC()
+	record.DateCreated = record.DateCreated.UTC()
+
+	return &record, nil
+}
+
+// DeleteExpiredIdempotencyKeys removes the idempotency keys expired at the given date and returns the number of removed keys.
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	s.logger.Debug("deleting expired idempotency keys", slog.Time("now", now))
+
+	result, err := s.db.ExecContext(ctx, deleteExpiredIdempotencyKeysSQL, now)
+	if err != nil {
+		s.logger.Error("executing delete of expired idempotency keys", slog.String("error", err.Error()))
+
+		return 0, errIdempotencyKeyCannotBePurged
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by expired idempotency keys delete", slog.String("error", err.Error()))
+
+		return 0, errIdempotencyKeyCannotBePurged
+	}
+
+	return int(deleted), nil
+}
+
+// SavePasswordResetToken persists a new password reset token, expired tokens of the same player are removed.
+func (s *Storage) SavePasswordResetToken(ctx context.Context, token players.PasswordResetToken) error {
+	s.logger.Debug("saving password reset token", slog.String("player_id", token.PlayerID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to save password reset token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errResetTokenCannotBeSaved
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, deleteExpiredPasswordResetTokensSQL, token.PlayerID.String(), token.DateCreated)
+	if err != nil {
+		s.logger.Error("executing delete of expired password reset tokens",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errResetTokenCannotBeSaved
+	}
+
+	_, err = tx.ExecContext(ctx, createPasswordResetTokenSQL,
+		token.TokenHash, token.PlayerID.String(), token.DateExpires, token.DateCreated,
+	)
+	if err != nil {
+		s.logger.Error("executing insert of password reset token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errResetTokenCannotBeSaved
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing password reset token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errResetTokenCannotBeSaved
+	}
+
+	return nil
+}
+
+// CountPasswordResetTokens counts the password reset tokens of the player that are not expired at the given date.
+func (s *Storage) CountPasswordResetTokens(ctx context.Context, playerID players.PlayerID, now time.Time) (int, error) {
+	s.logger.Debug("counting password reset tokens", slog.String("player_id", playerID.String()))
+
+	var count int
+
+	err := s.db.QueryRowContext(ctx, countPasswordResetTokensSQL, playerID.String(), now).Scan(&count)
+	if err != nil {
+		s.logger.Error("executing query to count password reset tokens",
+			slog.String("player_id", playerID.String()),
+			slog.String("error", err.Error()))
+
+		return 0, errResetTokenCannotBeRead
+	}
+
+	return count, nil
+}
+
+// GetPasswordResetToken get the password reset token with the given hash. It returns nil if the token does not exist.
+func (s *Storage) GetPasswordResetToken(ctx context.Context, tokenHash string) (*players.PasswordResetToken, error) {
+	s.logger.Debug("get password reset token")
+
+	var token players.PasswordResetToken
+
+	var playerID uuid.UUID
+
+	err := s.db.QueryRowContext(ctx, selectPasswordResetTokenSQL, tokenHash).
+		Scan(&token.TokenHash, &playerID, &token.DateExpires, &token.DateCreated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("getting password reset token", slog.String("error", err.Error()))
+
+		return nil, errResetTokenCannotBeRead
+	}
+
+	token.PlayerID = players.PlayerID(playerID)
+	token.DateExpires = token.DateExpires.UTC()
+	token.DateCreated = token.DateCreated.UTC()
+
+	return &token, nil
+}
+
+// SaveEmailVerificationToken persists a new email verification token, expired tokens of the same player are removed.
+func (s *Storage) SaveEmailVerificationToken(ctx context.Context, token players.EmailVerificationToken) error {
+	s.logger.Debug("saving email verification token", slog.String("player_id", token.PlayerID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to save email verification token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errVerificationTokenCannotBeSaved
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, deleteExpiredEmailVerificationTokensSQL, token.PlayerID.String(), token.DateCreated)
+	if err != nil {
+		s.logger.Error("executing delete of expired email verification tokens",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errVerificationTokenCannotBeSaved
+	}
+
+	_, err = tx.ExecContext(ctx, createEmailVerificationTokenSQL,
+		token.TokenHash, token.PlayerID.String(), token.Email, token.DateExpires, token.DateCreated,
+	)
+	if err != nil {
+		s.logger.Error("executing insert of email verification token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errVerificationTokenCannotBeSaved
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing email verification token",
+			slog.String("player_id", token.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return errVerificationTokenCannotBeSaved
+	}
+
+	return nil
+}
+
+// GetEmailVerificationToken get the email verification token with the given hash. It returns nil if the token does not exist.
+func (s *Storage) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*players.EmailVerificationToken, error) {
+	s.logger.Debug("get email verification token")
+
+	var token players.EmailVerificationToken
+
+	var playerID uuid.UUID
+
+	err := s.db.QueryRowContext(ctx, selectEmailVerificationTokenSQL, tokenHash).
+		Scan(&token.TokenHash, &playerID, &token.Email, &token.DateExpires, &token.DateCreated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		s.logger.Error("getting email verification token", slog.String("error", err.Error()))
+
+		return nil, errVerificationTokenCannotBeRead
+	}
+
+	token.PlayerID = players.PlayerID(playerID)
+	token.DateExpires = token.DateExpires.UTC()
+	token.DateCreated = token.DateCreated.UTC()
+
+	return &token, nil
+}
+
+// VerifyEmail consumes the token and stores the verified email of the player if its stored version
+// is still verification.Version. It returns false if the player was changed or deleted in the meantime
+// or the token was already used, and a PlayerAlreadyExistsError if another player has the email.
+func (s *Storage) VerifyEmail(ctx context.Context, verification players.EmailVerification) (bool, error) {
+	s.logger.Debug("verifying player email", slog.String("player_id", verification.PlayerID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("starting transaction to verify player email",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, consumeEmailVerificationTokenSQL, verification.TokenHash, verification.PlayerID.String())
+	if err != nil {
+		s.logger.Error("executing delete to consume email verification token",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	consumed, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by email verification token consumption",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	if consumed == 0 {
+		return false, nil
+	}
+
+	result, err = tx.ExecContext(ctx, verifyEmailSQL,
+		verification.Email, players.CanonicalEmail(verification.Email),
+		toNullEmail(verification.PendingEmail), verification.DateVerified,
+		verification.PlayerID.String(), verification.Version,
+	)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("player with the same email already stored",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, alreadyExistsErr
+	}
+
+	if err != nil {
+		s.logger.Error("executing update to verify player email",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("reading rows affected by player email verification",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		s.logger.Error("committing player email verification",
+			slog.String("player_id", verification.PlayerID.String()),
+			slog.String("error", err.Error()))
+
+		return false, errEmailCannotBeVerified
+	}
+
+	return true, nil
+}
+
+// GetPasswordHistory returns at most limit previous hashed passwords of the player, newest first.
+func (s *Storage) GetPasswordHistory(ctx context.Context, playerID players.PlayerID, limit int) ([][]byte, error) {
+	s.logger.Debug("getting player password history", slog.String("player_id", playerID.String()), slog.Int("limit", limit))
+
+	rows, err := s.db.QueryContext(ctx, selectPasswordHistorySQL, playerID.String(), limit)
+	if err != nil {
+		s.logger.Error("executing to get player password history", slog.String("error", err.Error()))
+
+		return nil, errPasswordHistoryCannotBeRead
+	}
+
+	defer rows.Close()
+
+	hashedPasswords := make([][]byte, 0, limit)
+
+	for rows.Next() {
+		var hashedPassword string
+
+		err := rows.Scan(&hashedPassword)
+		if err != nil {
+			s.logger.Error("scanning player password history", slog.String("error", err.Error()))
+
+			return nil, errPasswordHistoryCannotBeRead
+		}
+
+		hashedPasswords = append(hashedPasswords, []byte(hashedPassword))
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if player password history rows has an error", slog.String("error", err.Error()))
+
+		return nil, errPasswordHistoryCannotBeRead
+	}
+
+	return hashedPasswords, nil
+}
+
+// CountPlayersByCountry counts the players that are not deleted by their stored country.
+func (s *Storage) CountPlayersByCountry(ctx context.Context) (map[string]int, error) {
+	s.logger.Debug("counting players by country")
+
+	rows, err := s.db.QueryContext(ctx, countPlayersByCountrySQL)
+	if err != nil {
+		s.logger.Error("executing to count players by country", slog.String("error", err.Error()))
+
+		return nil, errCountriesCannotBeRead
+	}
+
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	for rows.Next() {
+		var country string
+
+		var count int
+
+		err := rows.Scan(&country, &count)
+		if err != nil {
+			s.logger.Error("scanning players by country", slog.String("error", err.Error()))
+
+			return nil, errCountriesCannotBeRead
+		}
+
+		counts[country] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if players by country rows has an error", slog.String("error", err.Error()))
+
+		return nil, errCountriesCannotBeRead
+	}
+
+	return counts, nil
+}
+
+// GetStoredCountries returns the distinct countries stored for all players, including deleted ones.
+func (s *Storage) GetStoredCountries(ctx context.Context) ([]string, error) {
+	s.logger.Debug("getting stored countries")
+
+	rows, err := s.db.QueryContext(ctx, selectStoredCountriesSQL)
+	if err != nil {
+		s.logger.Error("executing to get stored countries", slog.String("error", err.Error()))
+
+		return nil, errCountriesCannotBeRead
+	}
+
+	defer rows.Close()
+
+	countries := make([]string, 0)
+
+	for rows.Next() {
+		var country string
+
+		err := rows.Scan(&country)
+		if err != nil {
+			s.logger.Error("scanning stored country", slog.String("error", err.Error()))
+
+			return nil, errCountriesCannotBeRead
+		}
+
+		countries = append(countries, country)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if stored country rows has an error", slog.String("error", err.Error()))
+
+		return nil, errCountriesCannotBeRead
+	}
+
+	return countries, nil
+}
+
+// ReplaceCountry replaces the country of all the players whose country is from, including
+// deleted ones, and returns the number of changed players.
+func (s *Storage) ReplaceCountry(ctx context.Context, from, to string, updatedAt time.Time) (int, error) {
+	s.logger.Debug("replacing player country", slog.String("from", from), slog.String("to", to))
+
+	result, err := s.db.ExecContext(ctx, replaceCountrySQL, to, from, updatedAt)
+	if err != nil {
+		s.logger.Error("executing update to replace player country",
+			slog.String("from", from),
+			slog.String("error", err.Error()))
+
+		return 0, errCountryCannotBeReplaced
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("getting players with replaced country", slog.String("error", err.Error()))
+
+		return 0, errCountryCannotBeReplaced
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetPlayersWithoutCanonicalKeys returns the players without canonical nickname or email,
+// including deleted ones, the oldest first. Only the id, nickname and email are read.
+func (s *Storage) GetPlayersWithoutCanonicalKeys(ctx context.Context) ([]players.Player, error) {
+	s.logger.Debug("getting players without canonical keys")
+
+	rows, err := s.db.QueryContext(ctx, selectWithoutCanonicalKeysSQL)
+	if err != nil {
+		s.logger.Error("executing to get players without canonical keys", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	defer rows.Close()
+
+	result := make([]players.Player, 0)
+
+	for rows.Next() {
+		var playerID uuid.UUID
+
+		var nickname, email string
+
+		err := rows.Scan(&playerID, &nickname, &email)
+		if err != nil {
+			s.logger.Error("scanning player without canonical keys", slog.String("error", err.Error()))
+
+			return nil, errPlayersCannotBeRead
+		}
+
+		id := players.PlayerID(playerID)
+
+		result = append(result, players.Player{
+			ID:       &id,
+			Nickname: nickname,
+			Email:    mail.Address{Address: email},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("checking if players without canonical keys rows has an error", slog.String("error", err.Error()))
+
+		return nil, errPlayersCannotBeRead
+	}
+
+	return result, nil
+}
+
+// SaveCanonicalKeys stores the canonical nickname and email of the player, it returns a
+// PlayerAlreadyExistsError if they are taken by another active player.
+func (s *Storage) SaveCanonicalKeys(ctx context.Context, keys players.PlayerKeys) error {
+	s.logger.Debug("saving player canonical keys", slog.String("player_id", keys.ID.String()))
+
+	_, err := s.db.ExecContext(ctx, updateCanonicalKeysSQL, keys.ID.String(), keys.CanonicalNickname, keys.CanonicalEmail)
+	if alreadyExistsErr := toPlayerAlreadyExistsError(err); alreadyExistsErr != nil {
+		s.logger.Debug("canonical keys of the player are taken by another player",
+			slog.String("player_id", keys.ID.String()),
+			slog.String("error", err.Error()))
+
+		return alreadyExistsErr
+	}
+
+	if err != nil {
+		s.logger.Error("executing update to save player canonical keys",
+			slog.String("player_id", keys.ID.String()),
+			slog.String("error", err.Error()))
+
+		return errCanonicalKeysCannotBeSaved
+	}
+
+	return nil
+}
+
+func (s *Storage) queryCount(ctx context.Context, searchFilters *filterBuilder) (int, error) {
+	var count int
+
+	countStmt, err := s.db.Prepare(searchFilters.countStatement)
+	if err != nil {
+		s.logger.Error("building count players prepared statement",
+			slog.Any("filter", searchFilters),
+			slog.String("error", err.Error()),
+		)
+
+		return -1, fmt.Errorf("unable to build query to count players: %w", err)
+	}
+
+	defer countStmt.Close()
+
+	row := countStmt.QueryRowContext(ctx, searchFilters.countArgs...)
+
+	err = row.Scan(&count)
+	if err != nil {
+		s.logger.Error("scanning count of players found",
+			slog.Any("filter", searchFilters),
+			slog.String("query", searchFilters.countStatement),
+			slog.String("error", err.Error()),
+		)
 
 		return -1, fmt.Errorf("unable to scanning count of players found: %w", err)
 	}
@@ -321,8 +1835,7 @@ func (s *Storage) queryPlayers(ctx context.Context, searchFilters *filterBuilder
 
 	for rows.Next() {
 		player := new(dbPlayerItem)
-		// id, firstname, lastname, nickname, country
-		rowErr := rows.Scan(&player.ID, &player.FirstName, &player.LastName, &player.Nickname, &player.Country)
+		rowErr := rows.Scan(player.columns()...)
 		if rowErr != nil {
 			s.logger.Error("scanning rows for searching players with search criteria",
 				slog.Any("filter", searchFilters),
@@ -360,17 +1873,99 @@ func (s *Storage) Health() (string, error) {
 	return "storage", nil
 }
 
-func buildSQLFilters(filters players.SearchCriteria) *filterBuilder {
+// hasTrigramSupport indicates if fuzzy searches can use pg_trgm, searches that are not fuzzy
+// don't check it. Only a found extension is kept, if it is not installed it is looked up again
+// after trigramCheckInterval, and right away if it can't be read.
+func (s *Storage) hasTrigramSupport(ctx context.Context, searchCriteria players.SearchCriteria) bool {
+	if searchCriteria.Fuzzy == nil || *searchCriteria.Fuzzy == "" {
+		return false
+	}
+
+	s.trigramMutex.Lock()
+	defer s.trigramMutex.Unlock()
+
+	if s.trigramSupport {
+		return true
+	}
+
+	now := time.Now().UTC()
+
+	if !s.trigramCheckedAt.IsZero() && now.Sub(s.trigramCheckedAt) < trigramCheckInterval {
+		return false
+	}
+
+	var installed bool
+
+	err := s.db.QueryRowContext(ctx, trigramInstalledSQL).Scan(&installed)
+	if err != nil {
+		s.logger.Error("checking if pg_trgm is installed", slog.String("error", err.Error()))
+
+		return false
+	}
+
+	if !installed {
+		s.trigramCheckedAt = now
+
+		if !s.trigramFallbackLogged {
+			s.logger.Warn("pg_trgm is not installed, fuzzy searches fall back to ILIKE until it is")
+
+			s.trigramFallbackLogged = true
+		}
+
+		return false
+	}
+
+	if s.trigramFallbackLogged {
+		s.logger.Info("pg_trgm was installed, fuzzy searches use it from now on")
+	}
+
+	s.trigramSupport = true
+
+	return true
+}
+
+func buildSQLFilters(filters players.SearchCriteria, trigramSupport bool) *filterBuilder {
 	newFilterBuilder := &filterBuilder{
 		filters:   make([]string, 0),
 		countArgs: make([]interface{}, 0),
 		queryArgs: make([]interface{}, 0),
 	}
 
+	newFilterBuilder.addStaticCondition(notDeletedCondition)
+
 	if filters.Country != nil && *filters.Country != "" {
 		newFilterBuilder.addCondition(countryColumn, equalsOperator, filters.Country)
 	}
 
+	if filters.Status != nil {
+		newFilterBuilder.addCondition(statusColumn, equalsOperator, string(*filters.Status))
+	}
+
+	if filters.NicknamePrefix != nil && *filters.NicknamePrefix != "" {
+		newFilterBuilder.addCondition(
+			lookupNicknameExpression, likeOperator, prefixPattern(players.CanonicalNickname(*filters.NicknamePrefix)),
+		)
+	}
+
+	if filters.FirstName != nil && *filters.FirstName != "" {
+		newFilterBuilder.addCondition(firstNameColumn, ilikeOperator, containsPattern(*filters.FirstName))
+	}
+
+	if filters.LastName != nil && *filters.LastName != "" {
+		newFilterBuilder.addCondition(lastNameColumn, ilikeOperator, containsPattern(*filters.LastName))
+	}
+
+	if filters.Email != nil && *filters.Email != "" {
+		newFilterBuilder.addCondition(lookupEmailExpression, equalsOperator, players.CanonicalEmail(*filters.Email))
+	}
+
+	newFilterBuilder.addDateRange(dateCreatedColumn, filters.DateCreated)
+	newFilterBuilder.addDateRange(dateUpdatedColumn, filters.DateUpdated)
+
+	if filters.Fuzzy != nil && *filters.Fuzzy != "" {
+		newFilterBuilder.addFuzzyCondition(*filters.Fuzzy, trigramSupport)
+	}
+
 	var countWhereClause string
 	for _, v := range newFilterBuilder.filters {
 		countWhereClause += v
@@ -379,7 +1974,14 @@ func buildSQLFilters(filters players.SearchCriteria) *filterBuilder {
 	countStatement := fmt.Sprintf(countByFilterSQL, countWhereClause)
 	newFilterBuilder.countStatement = countStatement
 
-	newFilterBuilder.addFilter(" LIMIT", filters.Limit, true)
+	newFilterBuilder.setOrderColumns(filters.Sort)
+
+	if filters.After != nil {
+		newFilterBuilder.addKeysetCondition(filters.After)
+	}
+
+	newFilterBuilder.addOrderBy()
+	newFilterBuilder.addFilter(" LIMIT", limitWithNextPage(filters.Limit), true)
 	newFilterBuilder.addFilter(" OFFSET", filters.Offset, true)
 
 	var whereClause string
@@ -387,7 +1989,7 @@ func buildSQLFilters(filters players.SearchCriteria) *filterBuilder {
 		whereClause += v
 	}
 
-	queryStatement := fmt.Sprintf(selectByFilterSQL, whereClause)
+	queryStatement := fmt.Sprintf(selectByFilterSQL, newFilterBuilder.scoreColumn(), whereClause)
 	newFilterBuilder.query = queryStatement
 
 	return newFilterBuilder
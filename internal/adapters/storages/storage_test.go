@@ -3,6 +3,9 @@ package storages_test
 import (
 	"context"
 	"database/sql"
+	"net/mail"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/fernandoocampo/players/internal/appkit/e2etests"
 	"github.com/fernandoocampo/players/internal/appkit/unittests"
 	"github.com/fernandoocampo/players/internal/players"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -57,6 +61,165 @@ func TestSavePlayer(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSavePlayerButNicknameOrEmailAlreadyStored(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	existingPlayer := savePlayer(ctx, t, storage)
+
+	sameNickname := e2etests.RandomPlayerFixture()
+	sameNickname.Nickname = existingPlayer.Nickname
+
+	sameEmail := e2etests.RandomPlayerFixture()
+	sameEmail.Email = existingPlayer.Email
+
+	// When
+	errNickname := storage.Save(ctx, sameNickname)
+	errEmail := storage.Save(ctx, sameEmail)
+
+	// Then
+	var nicknameErr, emailErr *players.PlayerAlreadyExistsError
+
+	assert.ErrorAs(t, errNickname, &nicknameErr)
+	assert.True(t, nicknameErr.WasNickname)
+	assert.False(t, nicknameErr.WasEmail)
+	assert.ErrorAs(t, errEmail, &emailErr)
+	assert.True(t, emailErr.WasEmail)
+	assert.False(t, emailErr.WasNickname)
+}
+
+func TestPlayersAreComparedByCanonicalEmailAndNickname(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	existingPlayer := savePlayer(ctx, t, storage)
+
+	sameNickname := e2etests.RandomPlayerFixture()
+	sameNickname.Nickname = strings.ToUpper(existingPlayer.Nickname)
+
+	sameEmail := e2etests.RandomPlayerFixture()
+	sameEmail.Email = mail.Address{Address: strings.ToUpper(existingPlayer.Email.Address)}
+
+	// When
+	errNickname := storage.Save(ctx, sameNickname)
+	errEmail := storage.Save(ctx, sameEmail)
+	gotByEmail, errByEmail := storage.GetByEmailOrNickname(ctx, strings.ToUpper(existingPlayer.Email.Address))
+
+	// Then
+	assert.ErrorIs(t, errNickname, players.ErrPlayerAlreadyExists)
+	assert.ErrorIs(t, errEmail, players.ErrPlayerAlreadyExists)
+	assert.NoError(t, errByEmail)
+	assert.Equal(t, existingPlayer.ID, gotByEmail.ID)
+	// the display form is kept as it was given.
+	assert.Equal(t, existingPlayer.Email, gotByEmail.Email)
+}
+
+func TestSaveCanonicalKeysOfPlayersCreatedWithoutThem(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	existingPlayer := savePlayer(ctx, t, storage)
+
+	withoutKeys := e2etests.RandomPlayerFixture()
+	savePlayerWithoutCanonicalKeys(ctx, t, client, withoutKeys)
+
+	collision := players.PlayerKeys{
+		ID:                *withoutKeys.ID,
+		CanonicalNickname: players.CanonicalNickname(existingPlayer.Nickname),
+		CanonicalEmail:    players.CanonicalEmail(withoutKeys.Email.Address),
+	}
+	keys := players.PlayerKeys{
+		ID:                *withoutKeys.ID,
+		CanonicalNickname: players.CanonicalNickname(withoutKeys.Nickname),
+		CanonicalEmail:    players.CanonicalEmail(withoutKeys.Email.Address),
+	}
+
+	// When
+	gotBefore, errBefore := storage.GetPlayersWithoutCanonicalKeys(ctx)
+	errCollision := storage.SaveCanonicalKeys(ctx, collision)
+	errSave := storage.SaveCanonicalKeys(ctx, keys)
+	gotAfter, errAfter := storage.GetPlayersWithoutCanonicalKeys(ctx)
+
+	// Then
+	require.NoError(t, errBefore)
+	require.NoError(t, errAfter)
+	assert.Contains(t, gotBefore, players.Player{ID: withoutKeys.ID, Nickname: withoutKeys.Nickname, Email: withoutKeys.Email})
+	assert.ErrorIs(t, errCollision, players.ErrPlayerAlreadyExists)
+	assert.NoError(t, errSave)
+	assert.NotContains(t, gotAfter, players.Player{ID: withoutKeys.ID, Nickname: withoutKeys.Nickname, Email: withoutKeys.Email})
+}
+
+func TestPlayersWithoutCanonicalKeysAreComparedByLowerCase(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	// two players created before the canonical forms whose emails only differ in case.
+	upperPlayer := e2etests.RandomPlayerFixture()
+	upperPlayer.Email.Address = strings.ToUpper(upperPlayer.Email.Address)
+	lowerPlayer := e2etests.RandomPlayerFixture()
+	lowerPlayer.Email.Address = strings.ToLower(upperPlayer.Email.Address)
+	savePlayerWithoutCanonicalKeys(ctx, t, client, upperPlayer)
+	savePlayerWithoutCanonicalKeys(ctx, t, client, lowerPlayer)
+
+	updatedPlayer := upperPlayer
+	updatedPlayer.LastName = "updated"
+	updatedPlayer.DateUpdated = time.Now().UTC()
+
+	// When
+	exist, errExist := storage.GetPlayersWithEmailOrNickName(ctx, players.PlayerFilter{
+		Email:    strings.ToLower(upperPlayer.Email.Address),
+		Nickname: strings.ToUpper(upperPlayer.Nickname),
+	})
+	gotUpper, errUpper := storage.GetByEmailOrNickname(ctx, upperPlayer.Email.Address)
+	gotLower, errLower := storage.GetByEmailOrNickname(ctx, lowerPlayer.Email.Address)
+	gotByNickname, errByNickname := storage.GetByEmailOrNickname(ctx, strings.ToUpper(lowerPlayer.Nickname))
+	updated, errUpdate := storage.Update(ctx, updatedPlayer)
+
+	// Then
+	require.NoError(t, errExist)
+	assert.True(t, exist.EmailExist)
+	assert.True(t, exist.NicknameExist)
+	require.NoError(t, errUpper)
+	require.NotNil(t, gotUpper)
+	assert.Equal(t, upperPlayer.ID, gotUpper.ID)
+	require.NoError(t, errLower)
+	require.NotNil(t, gotLower)
+	assert.Equal(t, lowerPlayer.ID, gotLower.ID)
+	require.NoError(t, errByNickname)
+	require.NotNil(t, gotByNickname)
+	assert.Equal(t, lowerPlayer.ID, gotByNickname.ID)
+	assert.NoError(t, errUpdate)
+	assert.True(t, updated)
+}
+
 func TestUpdatePlayer(t *testing.T) {
 	if !*e2etests.E2ETest {
 		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
@@ -74,10 +237,18 @@ func TestUpdatePlayer(t *testing.T) {
 	newPlayer.DateUpdated = time.Now().UTC()
 
 	// When
-	err := storage.Update(ctx, *newPlayer)
+	updated, err := storage.Update(ctx, *newPlayer)
+	// the stored version was incremented, so the same version cannot be written twice.
+	updatedAgain, errAgain := storage.Update(ctx, *newPlayer)
+	got, errGet := storage.GetByID(ctx, *newPlayer.ID)
 
 	// Then
 	assert.NoError(t, err)
+	assert.NoError(t, errAgain)
+	assert.NoError(t, errGet)
+	assert.True(t, updated)
+	assert.False(t, updatedAgain)
+	assert.Equal(t, newPlayer.Version+1, got.Version)
 }
 
 func TestDeletePlayer(t *testing.T) {
@@ -94,10 +265,51 @@ func TestDeletePlayer(t *testing.T) {
 	newPlayer := savePlayer(ctx, t, storage)
 
 	// When
-	err := storage.Delete(ctx, *newPlayer.ID)
+	deleted, err := storage.Delete(ctx, *newPlayer.ID, newPlayer.Version, time.Now().UTC())
 
 	// Then
 	assert.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestDeletedPlayerIsRestoredAndPurged(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	deletedAt := time.Now().UTC().Add(-time.Hour)
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := savePlayer(ctx, t, storage)
+
+	// When
+	_, errDelete := storage.Delete(ctx, *newPlayer.ID, newPlayer.Version, deletedAt)
+	gotAfterDelete, errGet := storage.GetByID(ctx, *newPlayer.ID)
+	gotDeleted, errGetDeleted := storage.GetDeletedByID(ctx, *newPlayer.ID)
+	errRestore := storage.Restore(ctx, *newPlayer.ID)
+	gotAfterRestore, errGetRestored := storage.GetByID(ctx, *newPlayer.ID)
+	_, errDeleteAgain := storage.Delete(ctx, *newPlayer.ID, gotAfterRestore.Version, deletedAt)
+	purgedIDs, errPurge := storage.PurgeDeletedBefore(ctx, deletedAt.Add(time.Minute), 1000)
+	gotAfterPurge, errGetPurged := storage.GetDeletedByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errDelete)
+	assert.NoError(t, errGet)
+	assert.Nil(t, gotAfterDelete)
+	assert.NoError(t, errGetDeleted)
+	assert.NotNil(t, gotDeleted.DateDeleted)
+	assert.NoError(t, errRestore)
+	assert.NoError(t, errGetRestored)
+	assert.Equal(t, newPlayer.ID, gotAfterRestore.ID)
+	assert.NoError(t, errDeleteAgain)
+	assert.NoError(t, errPurge)
+	assert.Contains(t, purgedIDs, *newPlayer.ID)
+	assert.NoError(t, errGetPurged)
+	assert.Nil(t, gotAfterPurge)
 }
 
 func TestGetPlayerByID(t *testing.T) {
@@ -123,6 +335,488 @@ func TestGetPlayerByID(t *testing.T) {
 	assert.Equal(t, newPlayer, got)
 }
 
+func TestGetPlayerByEmailOrNickname(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := savePlayer(ctx, t, storage)
+
+	// an old player whose nickname is the email of the new player.
+	otherPlayer := e2etests.RandomPlayerFixture()
+	otherPlayer.Nickname = newPlayer.Email.Address
+	require.NoError(t, storage.Save(ctx, otherPlayer))
+
+	// When
+	gotByEmail, errByEmail := storage.GetByEmailOrNickname(ctx, newPlayer.Email.Address)
+	gotByNickname, errByNickname := storage.GetByEmailOrNickname(ctx, newPlayer.Nickname)
+	gotUnknown, errUnknown := storage.GetByEmailOrNickname(ctx, "unknownplayer")
+
+	// Then
+	assert.NoError(t, errByEmail)
+	assert.NoError(t, errByNickname)
+	assert.NoError(t, errUnknown)
+	assert.Equal(t, newPlayer.ID, gotByEmail.ID, "the email match is preferred")
+	assert.Equal(t, newPlayer.ID, gotByNickname.ID)
+	assert.Nil(t, gotUnknown)
+}
+
+func TestLockStateIsPersisted(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+	source := "e2e-" + uuid.NewString()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := savePlayer(ctx, t, storage)
+
+	// When
+	errAttempt := storage.SaveLoginAttempt(ctx, players.LoginAttempt{
+		PlayerID:    newPlayer.ID,
+		Source:      source,
+		Succeeded:   false,
+		DateCreated: now,
+	})
+	failedBySource, errCount := storage.CountFailedLoginAttemptsBySource(ctx, source, now.Add(-time.Minute))
+	failedAttempts, errIncrement := storage.IncrementFailedLoginAttempts(ctx, *newPlayer.ID, now)
+	lockedUntil := now.Add(time.Hour)
+	errLock := storage.UpdateLockState(ctx, *newPlayer.ID, players.LockState{
+		FailedAttempts: failedAttempts,
+		LockedUntil:    &lockedUntil,
+	})
+	got, errGet := storage.GetByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errAttempt)
+	assert.NoError(t, errCount)
+	assert.NoError(t, errIncrement)
+	assert.NoError(t, errLock)
+	assert.NoError(t, errGet)
+	assert.Equal(t, 1, failedBySource)
+	assert.Equal(t, 1, failedAttempts)
+	assert.True(t, got.LockState.IsLocked(now))
+}
+
+func TestStatusChangesArePersisted(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+	suspendedUntil := now.Add(-time.Minute)
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := savePlayer(ctx, t, storage)
+
+	suspension := players.StatusChange{
+		PlayerID:       newPlayer.ID,
+		From:           players.StatusActive,
+		To:             players.StatusSuspended,
+		Reason:         "e2e suspension",
+		Actor:          "e2e",
+		SuspendedUntil: &suspendedUntil,
+		DateCreated:    now,
+	}
+
+	// When
+	suspended, errSuspend := storage.ChangeStatus(ctx, suspension)
+	// the player is not active anymore, so the same change must not be applied twice.
+	suspendedAgain, errSuspendAgain := storage.ChangeStatus(ctx, suspension)
+	gotSuspended, errGetSuspended := storage.GetByID(ctx, *newPlayer.ID)
+	expiredIDs, errExpire := storage.ExpireSuspensions(ctx, players.StatusChange{
+		From:        players.StatusSuspended,
+		To:          players.StatusActive,
+		Reason:      "suspension expired",
+		Actor:       "system",
+		DateCreated: now,
+	})
+	gotActive, errGetActive := storage.GetByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errSuspend)
+	assert.NoError(t, errSuspendAgain)
+	assert.NoError(t, errGetSuspended)
+	assert.NoError(t, errExpire)
+	assert.NoError(t, errGetActive)
+	assert.True(t, suspended)
+	assert.False(t, suspendedAgain)
+	assert.Equal(t, players.StatusSuspended, gotSuspended.Status.Status)
+	assert.Equal(t, "e2e suspension", gotSuspended.Status.Reason)
+	assert.Contains(t, expiredIDs, *newPlayer.ID)
+	assert.Equal(t, players.StatusActive, gotActive.Status.Status)
+	assert.Nil(t, gotActive.Status.SuspendedUntil)
+}
+
+func TestStoredCountriesAreCountedAndReplaced(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	legacyCountry := "e2e-" + uuid.NewString()[:8]
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := e2etests.RandomPlayerFixture()
+	newPlayer.Country = legacyCountry
+	err := storage.Save(ctx, newPlayer)
+	require.NoError(t, err)
+
+	countsBefore, err := storage.CountPlayersByCountry(ctx)
+	require.NoError(t, err)
+
+	// When
+	storedCountries, errStored := storage.GetStoredCountries(ctx)
+	replaced, errReplace := storage.ReplaceCountry(ctx, legacyCountry, "CO", time.Now().UTC())
+	countsAfter, errCount := storage.CountPlayersByCountry(ctx)
+	got, errGet := storage.GetByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errStored)
+	assert.NoError(t, errReplace)
+	assert.NoError(t, errCount)
+	assert.NoError(t, errGet)
+	assert.Equal(t, 1, countsBefore[legacyCountry])
+	assert.Contains(t, storedCountries, legacyCountry)
+	assert.Equal(t, 1, replaced)
+	assert.NotContains(t, countsAfter, legacyCountry)
+	assert.Equal(t, countsBefore["CO"]+1, countsAfter["CO"])
+	assert.Equal(t, "CO", got.Country)
+	assert.Equal(t, newPlayer.Version+1, got.Version)
+}
+
+func TestPasswordChangesKeepHistory(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	historySize := 2
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := e2etests.RandomPlayerFixture()
+	err := storage.Save(ctx, newPlayer)
+	require.NoError(t, err)
+
+	hashedPasswords := [][]byte{
+		newPlayer.Password,
+		[]byte("$2a$04$e2e.first.password"),
+		[]byte("$2a$04$e2e.second.password"),
+		[]byte("$2a$04$e2e.third.password"),
+	}
+
+	// When
+	for i := 1; i < len(hashedPasswords); i++ {
+		changed, err := storage.ChangePassword(ctx, players.PasswordChange{
+			PlayerID:               *newPlayer.ID,
+			Version:                newPlayer.Version + int64(i-1),
+			HashedPassword:         hashedPasswords[i],
+			PreviousHashedPassword: hashedPasswords[i-1],
+			HistorySize:            historySize,
+			DateChanged:            time.Now().UTC(),
+		})
+		require.NoError(t, err)
+		require.True(t, changed)
+	}
+
+	staleChanged, errStale := storage.ChangePassword(ctx, players.PasswordChange{
+		PlayerID:       *newPlayer.ID,
+		Version:        newPlayer.Version,
+		HashedPassword: []byte("$2a$04$e2e.stale.password"),
+		HistorySize:    historySize,
+		DateChanged:    time.Now().UTC(),
+	})
+	history, errHistory := storage.GetPasswordHistory(ctx, *newPlayer.ID, 10)
+	got, errGet := storage.GetByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errStale)
+	assert.False(t, staleChanged)
+	assert.NoError(t, errHistory)
+	assert.Equal(t, [][]byte{hashedPasswords[2], hashedPasswords[1]}, history)
+	assert.NoError(t, errGet)
+	assert.Equal(t, hashedPasswords[3], got.Password)
+	assert.Equal(t, newPlayer.Version+3, got.Version)
+}
+
+func TestPasswordResetTokensAreConsumed(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := e2etests.RandomPlayerFixture()
+	err := storage.Save(ctx, newPlayer)
+	require.NoError(t, err)
+
+	usedToken := players.PasswordResetToken{
+		TokenHash:   "e2e-used-" + uuid.NewString(),
+		PlayerID:    *newPlayer.ID,
+		DateExpires: now.Add(time.Hour),
+		DateCreated: now,
+	}
+	outstandingToken := players.PasswordResetToken{
+		TokenHash:   "e2e-outstanding-" + uuid.NewString(),
+		PlayerID:    *newPlayer.ID,
+		DateExpires: now.Add(time.Hour),
+		DateCreated: now,
+	}
+
+	require.NoError(t, storage.SavePasswordResetToken(ctx, usedToken))
+	require.NoError(t, storage.SavePasswordResetToken(ctx, outstandingToken))
+
+	passwordChange := players.PasswordChange{
+		PlayerID:       *newPlayer.ID,
+		Version:        newPlayer.Version,
+		HashedPassword: []byte("$2a$04$e2e.reset.password"),
+		ResetTokenHash: usedToken.TokenHash,
+		DateChanged:    now,
+	}
+
+	// When
+	storedToken, errGet := storage.GetPasswordResetToken(ctx, usedToken.TokenHash)
+	changed, errChange := storage.ChangePassword(ctx, passwordChange)
+	passwordChange.Version++
+	changedAgain, errChangeAgain := storage.ChangePassword(ctx, passwordChange)
+	usedAfter, errUsed := storage.GetPasswordResetToken(ctx, usedToken.TokenHash)
+	outstandingAfter, errOutstanding := storage.GetPasswordResetToken(ctx, outstandingToken.TokenHash)
+
+	// Then
+	assert.NoError(t, errGet)
+	require.NotNil(t, storedToken)
+	assert.Equal(t, usedToken.PlayerID, storedToken.PlayerID)
+	assert.WithinDuration(t, usedToken.DateExpires, storedToken.DateExpires, time.Millisecond)
+	assert.NoError(t, errChange)
+	assert.True(t, changed)
+	assert.NoError(t, errChangeAgain)
+	assert.False(t, changedAgain)
+	assert.NoError(t, errUsed)
+	assert.Nil(t, usedAfter)
+	assert.NoError(t, errOutstanding)
+	assert.Nil(t, outstandingAfter)
+}
+
+func TestPendingEmailIsVerified(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	newPlayer := e2etests.RandomPlayerFixture()
+	err := storage.Save(ctx, newPlayer)
+	require.NoError(t, err)
+
+	pendingEmail := mail.Address{Address: "pending." + newPlayer.Email.Address}
+	newPlayer.PendingEmail = &pendingEmail
+	updated, err := storage.Update(ctx, newPlayer)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	verificationToken := players.EmailVerificationToken{
+		TokenHash:   "e2e-verification-" + uuid.NewString(),
+		PlayerID:    *newPlayer.ID,
+		Email:       pendingEmail.Address,
+		DateExpires: now.Add(time.Hour),
+		DateCreated: now,
+	}
+	require.NoError(t, storage.SaveEmailVerificationToken(ctx, verificationToken))
+
+	verification := players.EmailVerification{
+		PlayerID:     *newPlayer.ID,
+		Version:      newPlayer.Version + 1,
+		Email:        pendingEmail.Address,
+		TokenHash:    verificationToken.TokenHash,
+		DateVerified: now,
+	}
+
+	// When
+	pending, errPending := storage.GetByID(ctx, *newPlayer.ID)
+	storedToken, errToken := storage.GetEmailVerificationToken(ctx, verificationToken.TokenHash)
+	verified, errVerify := storage.VerifyEmail(ctx, verification)
+	verification.Version++
+	verifiedAgain, errVerifyAgain := storage.VerifyEmail(ctx, verification)
+	got, errGet := storage.GetByID(ctx, *newPlayer.ID)
+
+	// Then
+	assert.NoError(t, errPending)
+	assert.Equal(t, newPlayer.Email.Address, pending.Email.Address)
+	assert.Equal(t, &pendingEmail, pending.PendingEmail)
+	assert.False(t, pending.EmailVerified)
+	assert.NoError(t, errToken)
+	require.NotNil(t, storedToken)
+	assert.Equal(t, pendingEmail.Address, storedToken.Email)
+	assert.NoError(t, errVerify)
+	assert.True(t, verified)
+	assert.NoError(t, errVerifyAgain)
+	assert.False(t, verifiedAgain)
+	assert.NoError(t, errGet)
+	assert.Equal(t, pendingEmail.Address, got.Email.Address)
+	assert.Nil(t, got.PendingEmail)
+	assert.True(t, got.EmailVerified)
+	assert.Equal(t, newPlayer.Version+2, got.Version)
+}
+
+func TestIdempotencyKeyIsStoredOnce(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	firstPlayer := e2etests.RandomPlayerFixture()
+	secondPlayer := e2etests.RandomPlayerFixture()
+	reply := firstPlayer
+	reply.Password = nil
+	record := players.IdempotencyRecord{
+		Source:         "10.0.0.7",
+		Key:            "e2e-idempotency-" + uuid.NewString(),
+		Fingerprint:    "e2e-fingerprint",
+		HashedPassword: firstPlayer.Password,
+		Reply:          reply,
+		DateExpires:    now.Add(time.Hour),
+		DateCreated:    now,
+	}
+	secondRecord := record
+	secondRecord.Reply = secondPlayer
+
+	// When
+	errFirst := storage.SaveWithIdempotencyKey(ctx, firstPlayer, record)
+	errSecond := storage.SaveWithIdempotencyKey(ctx, secondPlayer, secondRecord)
+	storedRecord, errRecord := storage.GetIdempotencyRecord(ctx, record.Source, record.Key)
+	otherSourceRecord, errOtherSource := storage.GetIdempotencyRecord(ctx, "10.0.0.8", record.Key)
+	secondStored, errGetSecond := storage.GetByID(ctx, *secondPlayer.ID)
+	purged, errPurge := storage.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Hour))
+	purgedRecord, errPurgedRecord := storage.GetIdempotencyRecord(ctx, record.Source, record.Key)
+
+	// Then
+	assert.NoError(t, errFirst)
+	assert.ErrorIs(t, errSecond, players.ErrIdempotencyKeyExists)
+	assert.NoError(t, errRecord)
+	require.NotNil(t, storedRecord)
+	assert.Equal(t, record.Reply.ID, storedRecord.Reply.ID)
+	assert.Equal(t, record.Reply.Nickname, storedRecord.Reply.Nickname)
+	assert.Equal(t, record.Reply.Email, storedRecord.Reply.Email)
+	assert.Equal(t, record.Reply.Version, storedRecord.Reply.Version)
+	assert.Nil(t, storedRecord.Reply.Password)
+	assert.Equal(t, record.Fingerprint, storedRecord.Fingerprint)
+	assert.Equal(t, record.HashedPassword, storedRecord.HashedPassword)
+	assert.WithinDuration(t, record.DateExpires, storedRecord.DateExpires, time.Millisecond)
+	assert.NoError(t, errOtherSource)
+	assert.Nil(t, otherSourceRecord)
+	assert.NoError(t, errGetSecond)
+	assert.Nil(t, secondStored)
+	assert.NoError(t, errPurge)
+	assert.GreaterOrEqual(t, purged, 1)
+	assert.NoError(t, errPurgedRecord)
+	assert.Nil(t, purgedRecord)
+}
+
+func TestPlayersAreStoredInBatch(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+	now := time.Now().UTC()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	firstPlayer := e2etests.RandomPlayerFixture()
+	secondPlayer := e2etests.RandomPlayerFixture()
+	duplicatedPlayer := e2etests.RandomPlayerFixture()
+	duplicatedPlayer.Nickname = strings.ToUpper(firstPlayer.Nickname)
+
+	updatedFirstPlayer := firstPlayer
+	updatedFirstPlayer.LastName = "batch"
+	staleSecondPlayer := secondPlayer
+	staleSecondPlayer.Version = 7
+	staleSecondPlayer.LastName = "stale"
+
+	// When
+	errSave := storage.SaveAll(ctx, []players.Player{firstPlayer, secondPlayer})
+	errDuplicated := storage.SaveAll(ctx, []players.Player{duplicatedPlayer})
+	keys, errKeys := storage.GetPlayersWithEmailsOrNicknames(ctx, []players.PlayerFilter{
+		{Nickname: firstPlayer.Nickname},
+		{Email: secondPlayer.Email.Address},
+	})
+	errStale := storage.UpdateAll(ctx, []players.Player{updatedFirstPlayer, staleSecondPlayer})
+	afterStale, errAfterStale := storage.GetByID(ctx, *firstPlayer.ID)
+	errUpdate := storage.UpdateAll(ctx, []players.Player{updatedFirstPlayer})
+	storedPlayers, errGet := storage.GetByIDs(ctx, []players.PlayerID{*firstPlayer.ID, *secondPlayer.ID, *duplicatedPlayer.ID})
+	updatedFirstPlayer.Version++
+	errDelete := storage.DeleteAll(ctx, []players.Player{updatedFirstPlayer, secondPlayer}, now)
+	deletedPlayers, errGetDeleted := storage.GetByIDs(ctx, []players.PlayerID{*firstPlayer.ID, *secondPlayer.ID})
+
+	// Then
+	assert.NoError(t, errSave)
+	assert.ErrorIs(t, errDuplicated, players.ErrPlayerAlreadyExists)
+	assert.NoError(t, errKeys)
+	assert.Len(t, keys, 2)
+
+	var itemErr *players.BatchItemError
+	require.ErrorAs(t, errStale, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, errStale, players.ErrPlayerVersionConflict)
+	assert.NoError(t, errAfterStale)
+	require.NotNil(t, afterStale)
+	assert.Equal(t, firstPlayer.LastName, afterStale.LastName, "the stale batch was rolled back")
+
+	assert.NoError(t, errUpdate)
+	assert.NoError(t, errGet)
+	require.Len(t, storedPlayers, 2)
+
+	for _, storedPlayer := range storedPlayers {
+		if *storedPlayer.ID == *firstPlayer.ID {
+			assert.Equal(t, "batch", storedPlayer.LastName)
+			assert.Equal(t, int64(2), storedPlayer.Version)
+		}
+	}
+
+	assert.NoError(t, errDelete)
+	assert.NoError(t, errGetDeleted)
+	assert.Empty(t, deletedPlayers)
+}
+
 func TestGetPlayersByNicknameOrEmail(t *testing.T) {
 	if !*e2etests.E2ETest {
 		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
@@ -226,7 +920,7 @@ func TestSearchPlayers(t *testing.T) {
 	storage, client := newStorage(t)
 	defer closeConnection(t, client)
 
-	countryFilter := "Colombia"
+	countryFilter := "CO"
 	searchCriteria := players.SearchCriteria{
 		Country: &countryFilter,
 		Limit:   5,
@@ -235,7 +929,7 @@ func TestSearchPlayers(t *testing.T) {
 
 	for range 5 { // Save 5 players with the same country
 		newPlayer := e2etests.RandomPlayerFixture()
-		newPlayer.Country = "Colombia"
+		newPlayer.Country = "CO"
 		err := storage.Save(ctx, newPlayer)
 		require.NoError(t, err)
 	}
@@ -249,6 +943,310 @@ func TestSearchPlayers(t *testing.T) {
 	assert.Equal(t, 5, len(got.Items))
 }
 
+func TestSearchPlayersWithMultipleFilters(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	prefix := "e2e_" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	matchingPlayer := e2etests.RandomPlayerFixture()
+	matchingPlayer.Nickname = prefix + "match"
+	matchingPlayer.LastName = "Searchable"
+	otherPlayer := e2etests.RandomPlayerFixture()
+	otherPlayer.Nickname = prefix + "other"
+	otherPlayer.LastName = "Other"
+	wildcardPlayer := e2etests.RandomPlayerFixture()
+
+	for _, player := range []players.Player{matchingPlayer, otherPlayer, wildcardPlayer} {
+		require.NoError(t, storage.Save(ctx, player))
+	}
+
+	searchCriteria := players.SearchCriteria{
+		NicknamePrefix: players.NewString(strings.ToUpper(prefix)),
+		LastName:       players.NewString("archab"),
+		Email:          players.NewString(matchingPlayer.Email.Address),
+		DateCreated:    players.DateRange{From: matchingPlayer.DateCreated.Add(-time.Minute)},
+		DateUpdated:    players.DateRange{To: matchingPlayer.DateUpdated.Add(time.Minute)},
+		Limit:          5,
+	}
+	wildcardCriteria := players.SearchCriteria{
+		NicknamePrefix: players.NewString("%"),
+		FirstName:      players.NewString("_"),
+		Limit:          5,
+	}
+
+	// When
+	got, err := storage.Search(ctx, searchCriteria)
+	gotWildcard, errWildcard := storage.Search(ctx, wildcardCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.Total)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, *matchingPlayer.ID, got.Items[0].ID)
+	assert.NoError(t, errWildcard)
+	assert.Equal(t, 0, gotWildcard.Total, "wildcards are searched as plain characters")
+}
+
+func TestSearchPlayersSorted(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	lastName := "sorted" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	dateCreated := time.Now().UTC().Truncate(time.Microsecond)
+
+	for index := range 6 {
+		newPlayer := e2etests.RandomPlayerFixture()
+		newPlayer.LastName = lastName
+		// players are created in pairs with the same date to check the tie-breaker.
+		newPlayer.DateCreated = dateCreated.Add(time.Duration(index/2) * time.Second)
+		require.NoError(t, storage.Save(ctx, newPlayer))
+	}
+
+	searchCriteria := players.SearchCriteria{
+		LastName: players.NewString(lastName),
+		Sort: []players.SortKey{
+			{Field: players.SortByDateCreated, Descending: true},
+		},
+		Limit: 2,
+	}
+
+	// When
+	var pages []players.PlayerItem
+
+	for offset := 0; offset < 6; offset += 2 {
+		searchCriteria.Offset = uint16(offset)
+
+		got, err := storage.Search(ctx, searchCriteria)
+		require.NoError(t, err)
+
+		pages = append(pages, got.Items...)
+	}
+
+	// Then
+	require.Len(t, pages, 6)
+
+	seen := make(map[players.PlayerID]struct{}, len(pages))
+
+	for index, item := range pages {
+		_, repeated := seen[item.ID]
+		assert.False(t, repeated, "player %s is in more than one page", item.ID)
+
+		seen[item.ID] = struct{}{}
+
+		if index%2 == 1 {
+			assert.Less(t, pages[index-1].ID.String(), item.ID.String(), "players with the same date are sorted by id")
+		}
+	}
+}
+
+func TestSearchPlayersWithCursor(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	lastName := "cursor" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	dateCreated := time.Now().UTC().Truncate(time.Microsecond)
+
+	for index := range 5 {
+		newPlayer := e2etests.RandomPlayerFixture()
+		newPlayer.LastName = lastName
+		// players are created in pairs with the same date to check the tie-breaker.
+		newPlayer.DateCreated = dateCreated.Add(time.Duration(index/2) * time.Second)
+		require.NoError(t, storage.Save(ctx, newPlayer))
+	}
+
+	searchCriteria := players.SearchCriteria{
+		LastName: players.NewString(lastName),
+		Sort: []players.SortKey{
+			{Field: players.SortByDateCreated, Descending: true},
+		},
+		Limit: 2,
+	}
+
+	// When
+	var pages []players.PlayerItem
+
+	for range 3 {
+		got, err := storage.Search(ctx, searchCriteria)
+		require.NoError(t, err)
+		assert.Equal(t, 5, got.Total, "the count includes the players of previous pages")
+
+		pages = append(pages, got.Items...)
+		searchCriteria.After = got.Next
+	}
+
+	// Then
+	require.Len(t, pages, 5)
+	assert.Nil(t, searchCriteria.After, "the last page has no next page")
+
+	seen := make(map[players.PlayerID]struct{}, len(pages))
+
+	for _, item := range pages {
+		_, repeated := seen[item.ID]
+		assert.False(t, repeated, "player %s is in more than one page", item.ID)
+
+		seen[item.ID] = struct{}{}
+	}
+}
+
+func TestSearchPlayersWithCursorByNicknameWithoutCanonicalKeys(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	lastName := "nocanonical" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	wantNicknames := make([]string, 0, 5)
+
+	for index := range 5 {
+		newPlayer := e2etests.RandomPlayerFixture()
+		newPlayer.LastName = lastName
+		wantNicknames = append(wantNicknames, players.CanonicalNickname(newPlayer.Nickname))
+
+		// players created before the canonical forms are mixed with the new ones.
+		if index%2 == 0 {
+			newPlayer.Nickname = strings.ToUpper(newPlayer.Nickname)
+			savePlayerWithoutCanonicalKeys(ctx, t, client, newPlayer)
+
+			continue
+		}
+
+		require.NoError(t, storage.Save(ctx, newPlayer))
+	}
+
+	slices.Sort(wantNicknames)
+
+	searchCriteria := players.SearchCriteria{
+		LastName: players.NewString(lastName),
+		Sort: []players.SortKey{
+			{Field: players.SortByNickname},
+		},
+		Limit: 2,
+	}
+
+	// When
+	var pages []players.PlayerItem
+
+	for range 3 {
+		got, err := storage.Search(ctx, searchCriteria)
+		require.NoError(t, err)
+
+		pages = append(pages, got.Items...)
+		searchCriteria.After = got.Next
+	}
+
+	// Then
+	assert.Nil(t, searchCriteria.After, "the last page has no next page")
+
+	gotNicknames := make([]string, 0, len(pages))
+
+	for _, item := range pages {
+		gotNicknames = append(gotNicknames, players.CanonicalNickname(item.Nickname))
+	}
+
+	assert.Equal(t, wantNicknames, gotNicknames)
+}
+
+func TestSearchAllPlayersWithoutTotal(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	savePlayer(ctx, t, storage)
+	savePlayer(ctx, t, storage)
+
+	searchCriteria := players.SearchCriteria{
+		ListAll:   true,
+		SkipTotal: true,
+		Limit:     1,
+	}
+
+	// When
+	got, err := storage.Search(ctx, searchCriteria)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, got.Items, 1)
+	assert.Zero(t, got.Total, "players are not counted")
+	assert.NotNil(t, got.Next, "there are more players than the limit")
+}
+
+func TestSearchPlayersFuzzy(t *testing.T) {
+	if !*e2etests.E2ETest {
+		t.Skip("this is an e2e test to verify database calls, to execute this test send e2e-test flag to true")
+	}
+
+	// Given
+	ctx := context.TODO()
+
+	storage, client := newStorage(t)
+	defer closeConnection(t, client)
+
+	suffix := strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+
+	exactPlayer := e2etests.RandomPlayerFixture()
+	exactPlayer.Nickname = "zeratul" + suffix
+	require.NoError(t, storage.Save(ctx, exactPlayer))
+
+	similarPlayer := e2etests.RandomPlayerFixture()
+	similarPlayer.Nickname = "zeratulx" + suffix
+	require.NoError(t, storage.Save(ctx, similarPlayer))
+
+	searchCriteria := players.SearchCriteria{
+		// a half-remembered nickname with a typo, it is only found with pg_trgm.
+		Fuzzy: players.NewString("zeratl" + suffix),
+		Limit: 1,
+	}
+
+	// When
+	firstPage, err := storage.Search(ctx, searchCriteria)
+	require.NoError(t, err)
+
+	searchCriteria.After = firstPage.Next
+
+	secondPage, err := storage.Search(ctx, searchCriteria)
+	require.NoError(t, err)
+
+	// Then
+	require.Len(t, firstPage.Items, 1)
+	require.Len(t, secondPage.Items, 1)
+	assert.Equal(t, exactPlayer.ID, &firstPage.Items[0].ID, "the most similar player is ranked first")
+	assert.Equal(t, similarPlayer.ID, &secondPage.Items[0].ID)
+	assert.Greater(t, firstPage.Items[0].Score, secondPage.Items[0].Score)
+	assert.Positive(t, secondPage.Items[0].Score)
+}
+
 func newStorage(t *testing.T) (*storages.Storage, *sql.DB) {
 	t.Helper()
 
@@ -280,6 +1278,18 @@ func savePlayer(ctx context.Context, t *testing.T, storage *storages.Storage) *p
 	return &newPlayer
 }
 
+// savePlayerWithoutCanonicalKeys stores the player like the players created before the
+// canonical forms were added, which don't have them.
+func savePlayerWithoutCanonicalKeys(ctx context.Context, t *testing.T, client *sql.DB, player players.Player) {
+	t.Helper()
+
+	_, err := client.ExecContext(ctx, `INSERT INTO players(id,firstname,lastname,nickname,email,usrpwd,country,date_created,date_updated) 
+	VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)`,
+		player.ID.String(), player.FirstName, player.LastName, player.Nickname,
+		player.Email.Address, player.Password, player.Country, player.DateCreated, player.DateUpdated)
+	require.NoError(t, err)
+}
+
 func closeConnection(t *testing.T, conn *sql.DB) {
 	t.Helper()
 
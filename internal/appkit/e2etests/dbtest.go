@@ -83,6 +83,10 @@ func RandomPlayerFixture() players.Player {
 		Country:     randomCountry(),
 		DateCreated: anyUTCDate,
 		DateUpdated: anyUTCDate,
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+		Version: 1,
 	}
 }
 
@@ -98,7 +102,7 @@ func randomString(length int) string {
 }
 
 func randomCountry() string {
-	countries := []string{"UK", "Spain", "Colombia", "France", "Germany"}
+	countries := []string{"GB", "ES", "CO", "FR", "DE"}
 
 	return countries[rand.Intn(4)]
 }
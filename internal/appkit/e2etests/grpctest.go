@@ -9,13 +9,16 @@ import (
 
 type GRPCServerParameters struct {
 	Address string
+	// AdminKey is sent by the tests of the requests only admins can make.
+	AdminKey string
 }
 
 func GRPCServerParametersFixture(t *testing.T) GRPCServerParameters {
 	t.Helper()
 
 	parameters := GRPCServerParameters{
-		Address: getStringEnvVar(t, "PLAYERS_E2E_GRPC_SERVER_ADDRESS", "localhost:50051"),
+		Address:  getStringEnvVar(t, "PLAYERS_E2E_GRPC_SERVER_ADDRESS", "localhost:50051"),
+		AdminKey: getStringEnvVar(t, "PLAYERS_ADMIN_KEY", ""),
 	}
 
 	return parameters
@@ -15,3 +15,15 @@ func (m *MockHasher) Hash(password string) ([]byte, error) {
 
 	return args.Get(0).([]byte), args.Error(1)
 }
+
+func (m *MockHasher) Verify(hashedPassword []byte, password string) bool {
+	args := m.Called(hashedPassword, password)
+
+	return args.Bool(0)
+}
+
+func (m *MockHasher) NeedsRehash(hashedPassword []byte) bool {
+	args := m.Called(hashedPassword)
+
+	return args.Bool(0)
+}
@@ -0,0 +1,22 @@
+package unittests
+
+import (
+	"context"
+
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func NewMailerMock() *MockMailer {
+	return &MockMailer{}
+}
+
+func (m *MockMailer) Send(ctx context.Context, mail players.Mail) error {
+	args := m.Called(ctx, mail)
+
+	return args.Error(0)
+}
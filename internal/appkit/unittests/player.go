@@ -4,9 +4,11 @@ import (
 	"log/slog"
 	"net/mail"
 	"testing"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/players"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,15 +41,172 @@ func NewEmailAddress(t *testing.T, address string) *mail.Address {
 	return emailAddress
 }
 
+// DummyHashFixture is returned by the hasher mocks of the services created here
+// when the service hashes its dummy password.
+var DummyHashFixture = []byte("$2a$04$Ht0b6zNi2sEKvW5KZbC7OO6hyVjKXhpo0O0kk6vZOWyvr4BkUi0cS")
+
+// newPlayerService creates a service, hasher mocks hash its dummy password
+// unless the test already expects the hash of any password.
+func newPlayerService(serviceSetup *players.ServiceSetup) *players.Service {
+	if hasherMock, ok := serviceSetup.Hasher.(*MockHasher); ok {
+		hasherMock.On("Hash", mock.AnythingOfType("string")).Return(DummyHashFixture, nil).Once().Maybe()
+	}
+
+	service, err := players.NewService(serviceSetup)
+	if err != nil {
+		panic(err)
+	}
+
+	return service
+}
+
 func NewPlayerService(storageMock players.Storage, hasherMock players.Hasher, notifierMock players.Notifier) (*players.Service, *slog.Logger) {
 	serviceSetup := players.ServiceSetup{
-		Storage:  storageMock,
-		Hasher:   hasherMock,
-		Notifier: notifierMock,
-		Logger:   NewLogger(),
+		Storage:      storageMock,
+		Hasher:       hasherMock,
+		Notifier:     notifierMock,
+		PageTokenKey: []byte("page-token-key"),
+		Logger:       NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithLockoutPolicy(
+	storageMock players.Storage,
+	hasherMock players.Hasher,
+	notifierMock players.Notifier,
+	lockoutPolicy players.LockoutPolicy,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:       storageMock,
+		Hasher:        hasherMock,
+		Notifier:      notifierMock,
+		LockoutPolicy: lockoutPolicy,
+		Logger:        NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithDeletionRetention(
+	storageMock players.Storage,
+	notifierMock players.Notifier,
+	deletionRetention time.Duration,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:           storageMock,
+		Notifier:          notifierMock,
+		DeletionRetention: deletionRetention,
+		Logger:            NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithPasswordHistory(
+	storageMock players.Storage,
+	hasherMock players.Hasher,
+	notifierMock players.Notifier,
+	passwordHistorySize int,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:             storageMock,
+		Hasher:              hasherMock,
+		Notifier:            notifierMock,
+		PasswordHistorySize: passwordHistorySize,
+		Logger:              NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithMailer(
+	storageMock players.Storage,
+	hasherMock players.Hasher,
+	notifierMock players.Notifier,
+	mailerMock players.Mailer,
+	passwordResetTokenTTL time.Duration,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:               storageMock,
+		Hasher:                hasherMock,
+		Notifier:              notifierMock,
+		Mailer:                mailerMock,
+		PasswordResetTokenTTL: passwordResetTokenTTL,
+		Logger:                NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithPasswordResetLimit(
+	storageMock players.Storage,
+	mailerMock players.Mailer,
+	maxPendingPasswordResets int,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:                  storageMock,
+		Notifier:                 NewNotifierMock(),
+		Mailer:                   mailerMock,
+		PasswordResetTokenTTL:    time.Hour,
+		MaxPendingPasswordResets: maxPendingPasswordResets,
+		Logger:                   NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithEmailVerification(
+	storageMock players.Storage,
+	hasherMock players.Hasher,
+	notifierMock players.Notifier,
+	mailerMock players.Mailer,
+	emailVerificationTokenTTL time.Duration,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:                   storageMock,
+		Hasher:                    hasherMock,
+		Notifier:                  notifierMock,
+		Mailer:                    mailerMock,
+		EmailVerificationTokenTTL: emailVerificationTokenTTL,
+		Logger:                    NewLogger(),
+	}
+
+	service := newPlayerService(&serviceSetup)
+
+	return service, serviceSetup.Logger
+}
+
+func NewPlayerServiceWithIdempotencyKeys(
+	storageMock players.Storage,
+	hasherMock players.Hasher,
+	notifierMock players.Notifier,
+	mailerMock players.Mailer,
+	idempotencyKeyTTL time.Duration,
+) (*players.Service, *slog.Logger) {
+	serviceSetup := players.ServiceSetup{
+		Storage:           storageMock,
+		Hasher:            hasherMock,
+		Notifier:          notifierMock,
+		Mailer:            mailerMock,
+		IdempotencyKeyTTL: idempotencyKeyTTL,
+		Logger:            NewLogger(),
 	}
 
-	service := players.NewService(&serviceSetup)
+	service := newPlayerService(&serviceSetup)
 
 	return service, serviceSetup.Logger
 }
@@ -2,6 +2,7 @@ package unittests
 
 import (
 	"context"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/players"
 	"github.com/stretchr/testify/mock"
@@ -27,12 +28,36 @@ func (m *MockStorage) GetPlayersWithEmailOrNickName(ctx context.Context, filter
 	return args.Get(0).(*players.PlayerExistResult), args.Error(1)
 }
 
-func (m *MockStorage) Delete(ctx context.Context, playerID players.PlayerID) error {
+func (m *MockStorage) Delete(ctx context.Context, playerID players.PlayerID, version int64, deletedAt time.Time) (bool, error) {
+	args := m.Called(ctx, playerID, version, deletedAt)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStorage) Restore(ctx context.Context, playerID players.PlayerID) error {
 	args := m.Called(ctx, playerID)
 
 	return args.Error(0)
 }
 
+func (m *MockStorage) PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) ([]players.PlayerID, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.PlayerID), args.Error(1)
+}
+
+func (m *MockStorage) GetDeletedByID(ctx context.Context, playerID players.PlayerID) (*players.Player, error) {
+	args := m.Called(ctx, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.Player), args.Error(1)
+}
+
 func (m *MockStorage) Search(ctx context.Context, searchCriteria players.SearchCriteria) (*players.SearchResult, error) {
 	args := m.Called(ctx, searchCriteria)
 	if args.Get(0) == nil {
@@ -42,14 +67,224 @@ func (m *MockStorage) Search(ctx context.Context, searchCriteria players.SearchC
 	return args.Get(0).(*players.SearchResult), args.Error(1)
 }
 
-func (m *MockStorage) Update(ctx context.Context, player players.Player) error {
+func (m *MockStorage) Update(ctx context.Context, player players.Player) (bool, error) {
 	args := m.Called(ctx, player)
 
-	return args.Error(0)
+	return args.Bool(0), args.Error(1)
 }
 
 func (m *MockStorage) GetByID(ctx context.Context, id players.PlayerID) (*players.Player, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.Player), args.Error(1)
+}
+
+func (m *MockStorage) GetByEmailOrNickname(ctx context.Context, login string) (*players.Player, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 
 	return args.Get(0).(*players.Player), args.Error(1)
 }
+
+func (m *MockStorage) UpdatePassword(ctx context.Context, playerID players.PlayerID, hashedPassword []byte) error {
+	args := m.Called(ctx, playerID, hashedPassword)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) SaveLoginAttempt(ctx context.Context, attempt players.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) CountFailedLoginAttemptsBySource(ctx context.Context, source string, since time.Time) (int, error) {
+	args := m.Called(ctx, source, since)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) IncrementFailedLoginAttempts(ctx context.Context, playerID players.PlayerID, now time.Time) (int, error) {
+	args := m.Called(ctx, playerID, now)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) UpdateLockState(ctx context.Context, playerID players.PlayerID, lockState players.LockState) error {
+	args := m.Called(ctx, playerID, lockState)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) ChangeStatus(ctx context.Context, change players.StatusChange) (bool, error) {
+	args := m.Called(ctx, change)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStorage) ExpireSuspensions(ctx context.Context, change players.StatusChange) ([]players.PlayerID, error) {
+	args := m.Called(ctx, change)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.PlayerID), args.Error(1)
+}
+
+func (m *MockStorage) CountPlayersByCountry(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockStorage) GetStoredCountries(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStorage) ReplaceCountry(ctx context.Context, from, to string, updatedAt time.Time) (int, error) {
+	args := m.Called(ctx, from, to, updatedAt)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) GetPlayersWithoutCanonicalKeys(ctx context.Context) ([]players.Player, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.Player), args.Error(1)
+}
+
+func (m *MockStorage) SaveCanonicalKeys(ctx context.Context, keys players.PlayerKeys) error {
+	args := m.Called(ctx, keys)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) CountPasswordResetTokens(ctx context.Context, playerID players.PlayerID, now time.Time) (int, error) {
+	args := m.Called(ctx, playerID, now)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) ChangePassword(ctx context.Context, change players.PasswordChange) (bool, error) {
+	args := m.Called(ctx, change)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStorage) GetPasswordHistory(ctx context.Context, playerID players.PlayerID, limit int) ([][]byte, error) {
+	args := m.Called(ctx, playerID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([][]byte), args.Error(1)
+}
+
+func (m *MockStorage) SavePasswordResetToken(ctx context.Context, token players.PasswordResetToken) error {
+	args := m.Called(ctx, token)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPasswordResetToken(ctx context.Context, tokenHash string) (*players.PasswordResetToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockStorage) SaveEmailVerificationToken(ctx context.Context, token players.EmailVerificationToken) error {
+	args := m.Called(ctx, token)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*players.EmailVerificationToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockStorage) VerifyEmail(ctx context.Context, verification players.EmailVerification) (bool, error) {
+	args := m.Called(ctx, verification)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStorage) SaveWithIdempotencyKey(ctx context.Context, player players.Player, record players.IdempotencyRecord) error {
+	args := m.Called(ctx, player, record)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetIdempotencyRecord(ctx context.Context, source, key string) (*players.IdempotencyRecord, error) {
+	args := m.Called(ctx, source, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*players.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) SaveAll(ctx context.Context, newPlayers []players.Player) error {
+	args := m.Called(ctx, newPlayers)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) UpdateAll(ctx context.Context, batchPlayers []players.Player) error {
+	args := m.Called(ctx, batchPlayers)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) DeleteAll(ctx context.Context, batchPlayers []players.Player, deletedAt time.Time) error {
+	args := m.Called(ctx, batchPlayers, deletedAt)
+
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetByIDs(ctx context.Context, ids []players.PlayerID) ([]players.Player, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.Player), args.Error(1)
+}
+
+func (m *MockStorage) GetPlayersWithEmailsOrNicknames(ctx context.Context, filters []players.PlayerFilter) ([]players.PlayerKeys, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]players.PlayerKeys), args.Error(1)
+}
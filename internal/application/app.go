@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/fernandoocampo/players/internal/adapters/cryptos"
 	"github.com/fernandoocampo/players/internal/adapters/grpc"
+	"github.com/fernandoocampo/players/internal/adapters/mailers"
 	"github.com/fernandoocampo/players/internal/adapters/notifiers"
 	"github.com/fernandoocampo/players/internal/adapters/storages"
 	"github.com/fernandoocampo/players/internal/adapters/tracers"
@@ -38,21 +40,25 @@ type Event struct {
 }
 
 type Application struct {
-	settings          *settings
-	dbClient          *sql.DB
-	playerRepository  *storages.Storage
-	playerService     *players.Service
-	playerGRPCServer  *grpc.Server
-	playerGRPCHandler *grpc.Handler
-	passwordHasher    *cryptos.Bcrypt
-	eventNotifier     *notifiers.Notifier
-	tracerService     *tracers.TracerService
-	logger            *slog.Logger
-	resourcesToClose  []Closer
-	resourcesHealth   []HealthChecker
-	version           string
-	buildDate         string
-	commitHash        string
+	settings             *settings
+	dbClient             *sql.DB
+	playerRepository     *storages.Storage
+	playerService        *players.Service
+	playerPurger         *players.Worker
+	suspensionExpirer    *players.Worker
+	idempotencyKeyPurger *players.Worker
+	playerGRPCServer     *grpc.Server
+	playerGRPCHandler    *grpc.Handler
+	passwordHasher       *cryptos.Composite
+	eventNotifier        *notifiers.Notifier
+	mailer               *mailers.Mailer
+	tracerService        *tracers.TracerService
+	logger               *slog.Logger
+	resourcesToClose     []Closer
+	resourcesHealth      []HealthChecker
+	version              string
+	buildDate            string
+	commitHash           string
 }
 
 var (
@@ -78,14 +84,18 @@ func (a *Application) Run() error {
 	a.printInfo()
 
 	// load configuration
-	a.loadConfiguration()
+	err := a.loadConfiguration()
+	if err != nil {
+		return fmt.Errorf("unable to start application: %w", err)
+	}
+
 	// initialize logger
 	a.initializeLogger()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err := a.initializeStorage()
+	err = a.initializeStorage()
 	if err != nil {
 		a.logger.Error("initializing player storage", slog.String("error", err.Error()))
 
@@ -97,15 +107,30 @@ func (a *Application) Run() error {
 		return fmt.Errorf("unable to start application: %w", err)
 	}
 
-	a.initializePasswordHasher()
+	err = a.initializePasswordHasher()
+	if err != nil {
+		a.logger.Error("initializing password hasher", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to start application: %w", err)
+	}
+
 	a.initializeNotifier()
-	a.initializeService()
+	a.initializeMailer()
+	err = a.initializeService()
+	if err != nil {
+		a.logger.Error("initializing player service", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to start application: %w", err)
+	}
+
+	a.initializeWorkers()
 	a.initializeGRPCTransport()
 
 	eventStream := make(chan Event)
 	a.listenToOSSignal(ctx, eventStream)
 
 	a.startEventNotifier(ctx)
+	a.startWorkers(ctx)
 	a.startGRPCServer(ctx, eventStream)
 
 	a.addResourceToHealthChecks(a.eventNotifier)
@@ -137,10 +162,19 @@ func (a *Application) Run() error {
 	return nil
 }
 
-func (a *Application) loadConfiguration() {
+func (a *Application) loadConfiguration() error {
 	slog.Info("loading configuration")
 
-	a.settings = loadSettings()
+	settings, err := loadSettings()
+	if err != nil {
+		slog.Error("loading configuration", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to load configuration: %w", err)
+	}
+
+	a.settings = settings
+
+	return nil
 }
 
 func (a *Application) initializeLogger() {
@@ -194,36 +228,109 @@ func (a *Application) initializeStorage() error {
 	return nil
 }
 
-func (a *Application) initializePasswordHasher() {
-	a.logger.Info("initializing password hasher")
+func (a *Application) initializePasswordHasher() error {
+	a.logger.Info("initializing password hasher", slog.String("algorithm", a.settings.passwordAlgorithm))
 
-	setup := cryptos.BcryptSetup{
+	bcryptSetup := cryptos.BcryptSetup{
 		Logger: a.logger,
 		Cost:   a.settings.passwordGenerationCost,
 	}
 
-	a.passwordHasher = cryptos.NewBcrypt(setup)
+	argon2Setup := cryptos.Argon2Setup{
+		Memory:      uint32(a.settings.argon2.memoryKiB),
+		Iterations:  uint32(a.settings.argon2.iterations),
+		Parallelism: uint8(a.settings.argon2.parallelism),
+		Logger:      a.logger,
+	}
+
+	setup := cryptos.CompositeSetup{
+		DefaultAlgorithm: a.settings.passwordAlgorithm,
+		Hashers: map[string]cryptos.AlgorithmHasher{
+			cryptos.BcryptAlgorithm:   cryptos.NewBcrypt(bcryptSetup),
+			cryptos.Argon2idAlgorithm: cryptos.NewArgon2(argon2Setup),
+		},
+	}
+
+	passwordHasher, err := cryptos.NewComposite(setup)
+	if err != nil {
+		return fmt.Errorf("unable to initialize password hasher: %w", err)
+	}
+
+	a.passwordHasher = passwordHasher
+
+	return nil
 }
 
-func (a *Application) initializeService() {
+func (a *Application) initializeService() error {
 	a.logger.Info("initializing player service")
 
+	pageTokenKey, err := a.loadPageTokenKey()
+	if err != nil {
+		return fmt.Errorf("unable to initialize player service: %w", err)
+	}
+
 	setup := players.ServiceSetup{
-		Storage:  a.playerRepository,
-		Hasher:   a.passwordHasher,
-		Notifier: a.eventNotifier,
-		Logger:   a.logger,
+		Storage:                   a.playerRepository,
+		Hasher:                    a.passwordHasher,
+		Notifier:                  a.eventNotifier,
+		LockoutPolicy:             a.settings.lockout.toLockoutPolicy(),
+		DeletionRetention:         a.settings.deletionRetention(),
+		PasswordHistorySize:       a.settings.passwordHistorySize,
+		Mailer:                    a.mailer,
+		PasswordResetTokenTTL:     a.settings.passwordResetTokenTTL(),
+		MaxPendingPasswordResets:  a.settings.maxPendingPasswordResets,
+		EmailVerificationTokenTTL: a.settings.emailVerificationTokenTTL(),
+		IdempotencyKeyTTL:         a.settings.idempotencyKeyTTL(),
+		PageTokenKey:              pageTokenKey,
+		Logger:                    a.logger,
+	}
+
+	playerService, err := players.NewService(&setup)
+	if err != nil {
+		return fmt.Errorf("unable to initialize player service: %w", err)
 	}
 
-	a.playerService = players.NewService(&setup)
+	a.playerService = playerService
+
+	return nil
+}
+
+// loadPageTokenKey returns the configured key to sign page tokens. Without it a random key
+// is generated, so page tokens stop working when the service restarts and can't be shared
+// between instances.
+func (a *Application) loadPageTokenKey() ([]byte, error) {
+	if a.settings.pageTokenKey != "" {
+		return []byte(a.settings.pageTokenKey), nil
+	}
+
+	a.logger.Warn("page token key is not configured, using a random key", slog.String("env_var", pageTokenKeyEnvVar))
+
+	key := make([]byte, pageTokenKeySize)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate page token key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (a *Application) initializeWorkers() {
+	a.logger.Info("initializing background workers")
+
+	a.playerPurger = players.NewPurger(a.playerService, a.settings.purgeInterval(), a.logger)
+	a.suspensionExpirer = players.NewSuspensionExpirer(a.playerService, a.settings.suspensionExpiryInterval(), a.logger)
+	a.idempotencyKeyPurger = players.NewIdempotencyKeyPurger(a.playerService, a.settings.purgeInterval(), a.logger)
 }
 
 func (a *Application) initializeGRPCTransport() {
 	a.logger.Info("initializing grpc transport")
 
 	handlerSetup := grpc.HandlerSetup{
-		Service: a.playerService,
-		Logger:  a.logger,
+		Service:           a.playerService,
+		AdminKey:          a.settings.adminKey,
+		TrustForwardedFor: a.settings.trustForwardedFor,
+		Logger:            a.logger,
 	}
 
 	a.playerGRPCHandler = grpc.NewHandler(handlerSetup)
@@ -250,6 +357,17 @@ func (a *Application) initializeNotifier() {
 	a.eventNotifier = notifiers.NewNotifier(setup)
 }
 
+func (a *Application) initializeMailer() {
+	a.logger.Info("initializing player mailer")
+
+	setup := mailers.MailerSetup{
+		Logger:   a.logger,
+		FilePath: a.settings.mailerFilePath,
+	}
+
+	a.mailer = mailers.NewMailer(setup)
+}
+
 func (a *Application) initializeTracerService(ctx context.Context) error {
 	a.logger.Info("starting tracer service")
 
@@ -348,6 +466,14 @@ func (a *Application) startEventNotifier(ctx context.Context) {
 	a.eventNotifier.Start(ctx)
 }
 
+func (a *Application) startWorkers(ctx context.Context) {
+	a.logger.Info("starting background workers")
+
+	a.playerPurger.Start(ctx)
+	a.suspensionExpirer.Start(ctx)
+	a.idempotencyKeyPurger.Start(ctx)
+}
+
 func (a *Application) listenToOSSignal(ctx context.Context, eventStream chan<- Event) {
 	signalStream := make(chan os.Signal, 1)
 	signal.Notify(signalStream, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/fernandoocampo/players/internal/players"
+)
+
+var errCanonicalCollisions = errors.New("some players collide with other players, rename or delete them and run it again")
+
+// CanonicalizePlayers stores the canonical nickname and email of the players created before
+// they were compared by them. It must run after the migrations that add the canonical forms,
+// it can be run again safely because only players without them are changed.
+func (a *Application) CanonicalizePlayers() error {
+	err := a.loadConfiguration()
+	if err != nil {
+		return fmt.Errorf("unable to canonicalize players: %w", err)
+	}
+
+	a.initializeLogger()
+
+	err = a.initializeStorage()
+	if err != nil {
+		a.logger.Error("initializing player storage", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to canonicalize players: %w", err)
+	}
+
+	a.addResourceToClose(a.dbClient)
+
+	defer a.closeResources()
+
+	setup := players.ServiceSetup{
+		Storage: a.playerRepository,
+		Logger:  a.logger,
+	}
+
+	playerService, err := players.NewService(&setup)
+	if err != nil {
+		return fmt.Errorf("unable to canonicalize players: %w", err)
+	}
+
+	result, err := playerService.CanonicalizePlayers(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to canonicalize players: %w", err)
+	}
+
+	a.logger.Info("players were canonicalized",
+		slog.Int("players", result.Players),
+		slog.Int("collisions", len(result.Collisions)))
+
+	if len(result.Collisions) > 0 {
+		return fmt.Errorf("unable to canonicalize %d players: %w", len(result.Collisions), errCanonicalCollisions)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/fernandoocampo/players/internal/players"
+)
+
+// NormalizeCountries replaces the countries stored for the players with their ISO 3166-1
+// alpha-2 codes. It is a one-time migration for players created before countries were
+// validated, it can be run again safely because normalized countries are not changed.
+func (a *Application) NormalizeCountries() error {
+	err := a.loadConfiguration()
+	if err != nil {
+		return fmt.Errorf("unable to normalize countries: %w", err)
+	}
+
+	a.initializeLogger()
+
+	err = a.initializeStorage()
+	if err != nil {
+		a.logger.Error("initializing player storage", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to normalize countries: %w", err)
+	}
+
+	a.addResourceToClose(a.dbClient)
+
+	defer a.closeResources()
+
+	setup := players.ServiceSetup{
+		Storage: a.playerRepository,
+		Logger:  a.logger,
+	}
+
+	playerService, err := players.NewService(&setup)
+	if err != nil {
+		return fmt.Errorf("unable to normalize countries: %w", err)
+	}
+
+	result, err := playerService.NormalizeCountries(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to normalize countries: %w", err)
+	}
+
+	a.logger.Info("countries were normalized",
+		slog.Int("players", result.Players),
+		slog.Any("unknown_countries", result.Unknown))
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package application
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/players"
 )
 
 // Settings contains the parameters required for this service to work.
@@ -16,12 +22,63 @@ type settings struct {
 	tracerServiceURL string
 	// password generation cost
 	passwordGenerationCost int
+	// algorithm used to hash new passwords, it could be 'argon2id' or 'bcrypt'.
+	passwordAlgorithm string
+	// argon2id parameters.
+	argon2 argon2Setup
 	// port for web server
 	webServerPort int
 	// port for grpc server
 	grpcServerPort int
 	// timeout value used to wait for notifier to push events in event bus.
 	timeoutToPublishSec int
+	// rules to lock players after failed authentications.
+	lockout lockoutSetup
+	// time in seconds a deleted player can be restored before being purged.
+	deletionRetentionSec int
+	// time in seconds between purges of deleted players.
+	purgeIntervalSec int
+	// time in seconds between checks for expired suspensions.
+	suspensionExpiryIntervalSec int
+	// number of last passwords a player cannot reuse, zero disables the check.
+	passwordHistorySize int
+	// time in seconds a password reset token can be used.
+	passwordResetTokenTTLSec int
+	// number of unexpired password reset tokens a player can have, zero or negative values disable the limit.
+	maxPendingPasswordResets int
+	// time in seconds an email verification token can be used.
+	emailVerificationTokenTTLSec int
+	// time in seconds a create request can be retried with the same idempotency key.
+	idempotencyKeyTTLSec int
+	// file where mails are written, mails are only logged if it is empty.
+	mailerFilePath string
+	// key admins send to use admin only features, nobody is an admin if it is empty.
+	adminKey string
+	// takes the source of the requests from the x-forwarded-for metadata set by a gateway.
+	trustForwardedFor bool
+	// key to sign search page tokens, a random key is used if it is empty.
+	pageTokenKey string
+}
+
+// lockoutSetup contains parameters to protect players against credential stuffing.
+// Zero or negative values disable the respective protection.
+type lockoutSetup struct {
+	// consecutive failed attempts allowed before locking the player.
+	maxFailedAttempts int
+	// time in seconds the player stays locked.
+	lockDurationSec int
+	// failed attempts allowed from the same source within sourceWindowSec.
+	maxFailedAttemptsPerSource int
+	// time window in seconds used to count failed attempts per source.
+	sourceWindowSec int
+}
+
+// argon2Setup contains argon2id parameters, zero values use the hasher defaults.
+type argon2Setup struct {
+	// memory in KiB.
+	memoryKiB   int
+	iterations  int
+	parallelism int
 }
 
 type databaseSetup struct {
@@ -33,19 +90,84 @@ type databaseSetup struct {
 }
 
 const (
-	logLevelEnvVar               = "PLAYERS_LOG_LEVEL"
-	webServerPortEnvVar          = "PLAYERS_WEB_SERVER_PORT"
-	grpcServerPortEnvVar         = "PLAYERS_GRPC_SERVER_PORT"
-	passwordGenerationCostEnvVar = "PLAYERS_PASSWORD_GENERATION_COST"
-	postgresDBNameEnvVar         = "PLAYERS_POSTGRES_DB"
-	postgresHostEnvVar           = "PLAYERS_POSTGRES_HOST"
-	postgresPlayerEnvVar         = "PLAYERS_POSTGRES_PLAYER"
-	postgresPasswordEnvVar       = "PLAYERS_POSTGRES_PASSWORD"
-	postgresPortEnvVar           = "PLAYERS_POSTGRES_PORT"
-	timeoutToPublishSecEnvVar    = "PLAYERS_TIMEOUT_TO_PUBLISH_SEC"
-	tracerServiceURL             = "PLAYERS_TRACER_SERVICE_URL"
+	logLevelEnvVar                     = "PLAYERS_LOG_LEVEL"
+	webServerPortEnvVar                = "PLAYERS_WEB_SERVER_PORT"
+	grpcServerPortEnvVar               = "PLAYERS_GRPC_SERVER_PORT"
+	passwordGenerationCostEnvVar       = "PLAYERS_PASSWORD_GENERATION_COST"
+	passwordAlgorithmEnvVar            = "PLAYERS_PASSWORD_ALGORITHM"
+	argon2MemoryKiBEnvVar              = "PLAYERS_ARGON2_MEMORY_KIB"
+	argon2IterationsEnvVar             = "PLAYERS_ARGON2_ITERATIONS"
+	argon2ParallelismEnvVar            = "PLAYERS_ARGON2_PARALLELISM"
+	postgresDBNameEnvVar               = "PLAYERS_POSTGRES_DB"
+	postgresHostEnvVar                 = "PLAYERS_POSTGRES_HOST"
+	postgresPlayerEnvVar               = "PLAYERS_POSTGRES_PLAYER"
+	postgresPasswordEnvVar             = "PLAYERS_POSTGRES_PASSWORD"
+	postgresPortEnvVar                 = "PLAYERS_POSTGRES_PORT"
+	timeoutToPublishSecEnvVar          = "PLAYERS_TIMEOUT_TO_PUBLISH_SEC"
+	tracerServiceURL                   = "PLAYERS_TRACER_SERVICE_URL"
+	loginMaxFailedAttemptsEnvVar       = "PLAYERS_LOGIN_MAX_FAILED_ATTEMPTS"
+	loginLockDurationSecEnvVar         = "PLAYERS_LOGIN_LOCK_DURATION_SEC"
+	loginMaxFailedPerSourceEnvVar      = "PLAYERS_LOGIN_MAX_FAILED_ATTEMPTS_PER_SOURCE"
+	loginSourceWindowSecEnvVar         = "PLAYERS_LOGIN_SOURCE_WINDOW_SEC"
+	deletionRetentionSecEnvVar         = "PLAYERS_DELETION_RETENTION_SEC"
+	purgeIntervalSecEnvVar             = "PLAYERS_PURGE_INTERVAL_SEC"
+	suspensionExpiryIntervalSecEnvVar  = "PLAYERS_SUSPENSION_EXPIRY_INTERVAL_SEC"
+	passwordHistorySizeEnvVar          = "PLAYERS_PASSWORD_HISTORY_SIZE"
+	passwordResetTokenTTLSecEnvVar     = "PLAYERS_PASSWORD_RESET_TOKEN_TTL_SEC"
+	maxPendingPasswordResetsEnvVar     = "PLAYERS_PASSWORD_RESET_MAX_PENDING"
+	emailVerificationTokenTTLSecEnvVar = "PLAYERS_EMAIL_VERIFICATION_TOKEN_TTL_SEC"
+	idempotencyKeyTTLSecEnvVar         = "PLAYERS_IDEMPOTENCY_KEY_TTL_SEC"
+	mailerFilePathEnvVar               = "PLAYERS_MAILER_FILE_PATH"
+	adminKeyEnvVar                     = "PLAYERS_ADMIN_KEY"
+	trustForwardedForEnvVar            = "PLAYERS_TRUST_FORWARDED_FOR"
+	pageTokenKeyEnvVar                 = "PLAYERS_PAGE_TOKEN_KEY"
+)
+
+// default lockout values.
+const (
+	defaultLoginMaxFailedAttempts  = 5
+	defaultLoginLockDurationSec    = 900
+	defaultLoginMaxFailedPerSource = 20
+	defaultLoginSourceWindowSec    = 900
+)
+
+// default soft delete values.
+const (
+	defaultDeletionRetentionSec = 30 * 24 * 60 * 60
+	defaultPurgeIntervalSec     = 60 * 60
+)
+
+// defaultSuspensionExpiryIntervalSec time between checks for expired suspensions.
+const defaultSuspensionExpiryIntervalSec = 60
+
+// defaultPasswordHistorySize number of last passwords a player cannot reuse.
+const defaultPasswordHistorySize = 5
+
+// defaultPasswordResetTokenTTLSec time a password reset token can be used.
+const defaultPasswordResetTokenTTLSec = 60 * 60
+
+// defaultMaxPendingPasswordResets number of unexpired password reset tokens a player can have.
+const defaultMaxPendingPasswordResets = 3
+
+// defaultEmailVerificationTokenTTLSec time an email verification token can be used.
+const defaultEmailVerificationTokenTTLSec = 24 * 60 * 60
+
+// defaultIdempotencyKeyTTLSec time a create request can be retried with the same idempotency key.
+const defaultIdempotencyKeyTTLSec = 24 * 60 * 60
+
+// argon2id limits, greater costs would make authentications take too long.
+const (
+	// maxArgon2MemoryKiB 4 GiB.
+	maxArgon2MemoryKiB  = 4 * 1024 * 1024
+	maxArgon2Iterations = 100
 )
 
+// pageTokenKeySize size in bytes of the random key that signs page tokens if none is configured.
+const pageTokenKeySize = 32
+
+// defaultPasswordAlgorithm is used to hash new passwords if no algorithm is given.
+const defaultPasswordAlgorithm = "argon2id"
+
 // log levels.
 const (
 	productionLog  = "production"
@@ -57,8 +179,8 @@ const (
 	serviceName = "players-api"
 )
 
-// loadSettings load all application parameters.
-func loadSettings() *settings {
+// loadSettings load all application parameters, it fails if some of them are out of range.
+func loadSettings() (*settings, error) {
 	webServerPort := loadIntEnvVar(webServerPortEnvVar)
 	if webServerPort == 0 {
 		webServerPort = 8080
@@ -69,12 +191,79 @@ func loadSettings() *settings {
 		tracerServiceURL:       loadStringEnvVar(tracerServiceURL),
 		logLevel:               loadStringEnvVar(logLevelEnvVar),
 		passwordGenerationCost: loadIntEnvVar(passwordGenerationCostEnvVar),
+		passwordAlgorithm:      loadStringEnvVarWithDefault(passwordAlgorithmEnvVar, defaultPasswordAlgorithm),
+		argon2:                 loadArgon2Settings(),
 		webServerPort:          webServerPort,
 		grpcServerPort:         loadIntEnvVar(grpcServerPortEnvVar),
 		timeoutToPublishSec:    loadIntEnvVar(timeoutToPublishSecEnvVar),
+		lockout:                loadLockoutSettings(),
+		deletionRetentionSec:   loadIntEnvVarWithDefault(deletionRetentionSecEnvVar, defaultDeletionRetentionSec),
+		purgeIntervalSec:       loadIntEnvVarWithDefault(purgeIntervalSecEnvVar, defaultPurgeIntervalSec),
+		suspensionExpiryIntervalSec: loadIntEnvVarWithDefault(
+			suspensionExpiryIntervalSecEnvVar, defaultSuspensionExpiryIntervalSec,
+		),
+		passwordHistorySize: loadIntEnvVarWithDefault(passwordHistorySizeEnvVar, defaultPasswordHistorySize),
+		passwordResetTokenTTLSec: loadIntEnvVarWithDefault(
+			passwordResetTokenTTLSecEnvVar, defaultPasswordResetTokenTTLSec,
+		),
+		maxPendingPasswordResets: loadIntEnvVarWithDefault(
+			maxPendingPasswordResetsEnvVar, defaultMaxPendingPasswordResets,
+		),
+		emailVerificationTokenTTLSec: loadIntEnvVarWithDefault(
+			emailVerificationTokenTTLSecEnvVar, defaultEmailVerificationTokenTTLSec,
+		),
+		idempotencyKeyTTLSec: loadIntEnvVarWithDefault(idempotencyKeyTTLSecEnvVar, defaultIdempotencyKeyTTLSec),
+		mailerFilePath:       loadStringEnvVar(mailerFilePathEnvVar),
+		adminKey:             loadStringEnvVar(adminKeyEnvVar),
+		trustForwardedFor:    loadBoolEnvVar(trustForwardedForEnvVar),
+		pageTokenKey:         loadStringEnvVar(pageTokenKeyEnvVar),
+	}
+
+	err := newSettings.argon2.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid argon2id settings: %w", err)
 	}
 
-	return &newSettings
+	return &newSettings, nil
+}
+
+// loadLockoutSettings load settings to lock players after failed authentications.
+func loadLockoutSettings() lockoutSetup {
+	return lockoutSetup{
+		maxFailedAttempts:          loadIntEnvVarWithDefault(loginMaxFailedAttemptsEnvVar, defaultLoginMaxFailedAttempts),
+		lockDurationSec:            loadIntEnvVarWithDefault(loginLockDurationSecEnvVar, defaultLoginLockDurationSec),
+		maxFailedAttemptsPerSource: loadIntEnvVarWithDefault(loginMaxFailedPerSourceEnvVar, defaultLoginMaxFailedPerSource),
+		sourceWindowSec:            loadIntEnvVarWithDefault(loginSourceWindowSecEnvVar, defaultLoginSourceWindowSec),
+	}
+}
+
+// validate checks the argon2id parameters fit the types the hasher uses, so they don't wrap
+// into a huge memory cost or a parallelism of zero.
+func (a argon2Setup) validate() error {
+	var err error
+
+	if a.memoryKiB < 0 || a.memoryKiB > maxArgon2MemoryKiB {
+		err = errors.Join(err, fmt.Errorf("%s must be between 0 and %d", argon2MemoryKiBEnvVar, maxArgon2MemoryKiB))
+	}
+
+	if a.iterations < 0 || a.iterations > maxArgon2Iterations {
+		err = errors.Join(err, fmt.Errorf("%s must be between 0 and %d", argon2IterationsEnvVar, maxArgon2Iterations))
+	}
+
+	if a.parallelism < 0 || a.parallelism > math.MaxUint8 {
+		err = errors.Join(err, fmt.Errorf("%s must be between 0 and %d", argon2ParallelismEnvVar, math.MaxUint8))
+	}
+
+	return err
+}
+
+// loadArgon2Settings load argon2id parameters.
+func loadArgon2Settings() argon2Setup {
+	return argon2Setup{
+		memoryKiB:   loadIntEnvVarWithDefault(argon2MemoryKiBEnvVar, 0),
+		iterations:  loadIntEnvVarWithDefault(argon2IterationsEnvVar, 0),
+		parallelism: loadIntEnvVarWithDefault(argon2ParallelismEnvVar, 0),
+	}
 }
 
 // loadRepositorySettings load settings for player database.
@@ -88,6 +277,39 @@ func loadRepositorySettings() databaseSetup {
 	}
 }
 
+func (s *settings) deletionRetention() time.Duration {
+	return time.Duration(s.deletionRetentionSec) * time.Second
+}
+
+func (s *settings) purgeInterval() time.Duration {
+	return time.Duration(s.purgeIntervalSec) * time.Second
+}
+
+func (s *settings) suspensionExpiryInterval() time.Duration {
+	return time.Duration(s.suspensionExpiryIntervalSec) * time.Second
+}
+
+func (s *settings) passwordResetTokenTTL() time.Duration {
+	return time.Duration(s.passwordResetTokenTTLSec) * time.Second
+}
+
+func (s *settings) emailVerificationTokenTTL() time.Duration {
+	return time.Duration(s.emailVerificationTokenTTLSec) * time.Second
+}
+
+func (s *settings) idempotencyKeyTTL() time.Duration {
+	return time.Duration(s.idempotencyKeyTTLSec) * time.Second
+}
+
+func (l lockoutSetup) toLockoutPolicy() players.LockoutPolicy {
+	return players.LockoutPolicy{
+		MaxFailedAttempts:          l.maxFailedAttempts,
+		LockDuration:               time.Duration(l.lockDurationSec) * time.Second,
+		MaxFailedAttemptsPerSource: l.maxFailedAttemptsPerSource,
+		SourceWindow:               time.Duration(l.sourceWindowSec) * time.Second,
+	}
+}
+
 func loadIntEnvVar(key string) int {
 	strValue := loadStringEnvVar(key)
 
@@ -104,6 +326,45 @@ func loadIntEnvVar(key string) int {
 	return intValue
 }
 
+// loadIntEnvVarWithDefault load an int env var, if it is not set the default value is returned.
+func loadIntEnvVarWithDefault(key string, defaultValue int) int {
+	if loadStringEnvVar(key) == "" {
+		return defaultValue
+	}
+
+	return loadIntEnvVar(key)
+}
+
+// loadBoolEnvVar load a bool env var, it is false if it is not set or is not a bool.
+func loadBoolEnvVar(key string) bool {
+	strValue := loadStringEnvVar(key)
+	if strValue == "" {
+		return false
+	}
+
+	boolValue, err := strconv.ParseBool(strValue)
+	if err != nil {
+		slog.Error(
+			"env var is not a bool",
+			slog.String("key", key),
+			slog.String("value", strValue))
+
+		return false
+	}
+
+	return boolValue
+}
+
 func loadStringEnvVar(key string) string {
 	return os.Getenv(key)
 }
+
+// loadStringEnvVarWithDefault load a string env var, if it is not set the default value is returned.
+func loadStringEnvVarWithDefault(key, defaultValue string) string {
+	value := loadStringEnvVar(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,284 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticatePlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(false)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, givenPlayerID, got)
+}
+
+func TestAuthenticatePlayerButWrongPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	credentials := players.Credentials{
+		Login:    "focampo@anyemail.com",
+		Password: "wrongpwd",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo@anyemail.com").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(false)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCredentials)
+	assert.Nil(t, got)
+}
+
+func TestAuthenticatePlayerButPlayerDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	credentials := players.Credentials{
+		Login:    "unknown",
+		Password: "th1s1s@dummypwd",
+	}
+
+	dummyHash := []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "unknown").Return(nil, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", mock.AnythingOfType("string")).Return(dummyHash, nil)
+	hasherMock.On("Verify", dummyHash, credentials.Password).Return(false)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCredentials)
+	assert.Nil(t, got)
+	// the password is verified even if the player does not exist.
+	hasherMock.AssertCalled(t, "Verify", dummyHash, credentials.Password)
+}
+
+func TestAuthenticatePlayerButEmptyCredentials(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	credentials := players.Credentials{}
+
+	want := `unable to authenticate player: login is empty
+password is empty`
+
+	service, _ := unittests.NewPlayerServiceWithStorage(unittests.NewStorageMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidPlayerData)
+	assert.Nil(t, got)
+	assert.Equal(t, want, err.Error())
+}
+
+func TestAuthenticatePlayerButError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	want := "unable to authenticate player: unexpected get error"
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(nil, errors.New("unexpected get error"))
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, got)
+	assert.Equal(t, want, err.Error())
+}
+
+func TestAuthenticatePlayerWithEndpointSuccessfully(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	want := players.AuthenticatePlayerResult{
+		ID: givenPlayerID,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(false)
+
+	service, logger := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+	authenticatePlayerEndpoint := players.MakeAuthenticatePlayerEndpoint(service, logger)
+
+	// When
+	got, err := authenticatePlayerEndpoint.Do(ctx, &credentials)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAuthenticatePlayerRehashesOutdatedPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	rehashedPassword := []byte("$2a$12$Ht0b6zNi2sEKvW5KZbC7OO6hyVjKXhpo0O0kk6vZOWyvr4BkUi0cS")
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+	storageMock.On("UpdatePassword", ctx, *givenPlayerID, rehashedPassword).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(true)
+	hasherMock.On("Hash", credentials.Password).Return(rehashedPassword, nil)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, givenPlayerID, got)
+	storageMock.AssertExpectations(t)
+}
+
+func TestAuthenticatePlayerButRehashFails(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	rehashedPassword := []byte("$2a$12$Ht0b6zNi2sEKvW5KZbC7OO6hyVjKXhpo0O0kk6vZOWyvr4BkUi0cS")
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+	storageMock.On("UpdatePassword", ctx, *givenPlayerID, rehashedPassword).Return(errors.New("unexpected update error"))
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(true)
+	hasherMock.On("Hash", credentials.Password).Return(rehashedPassword, nil)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	// the player is authenticated even if the new hash cannot be stored.
+	assert.NoError(t, err)
+	assert.Equal(t, givenPlayerID, got)
+}
+
+func existingPlayerToAuthenticateFixture(t *testing.T, playerID *players.PlayerID) players.Player {
+	t.Helper()
+
+	return players.Player{
+		ID:          playerID,
+		FirstName:   "Fernando",
+		LastName:    "Ocampo",
+		Nickname:    "focampo",
+		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
+		Country:     "Spain",
+		DateCreated: time.Now().UTC(),
+		DateUpdated: time.Now().UTC(),
+	}
+}
+
+func TestNewServiceButDummyPasswordCannotBeHashed(t *testing.T) {
+	t.Parallel()
+	// Given
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", mock.AnythingOfType("string")).Return([]byte(nil), errors.New("unexpected hasher error"))
+
+	setup := players.ServiceSetup{
+		Storage: unittests.NewStorageMock(),
+		Hasher:  hasherMock,
+		Logger:  unittests.NewLogger(),
+	}
+
+	// When
+	got, err := players.NewService(&setup)
+
+	// Then
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "unable to hash dummy password: unexpected hasher error")
+}
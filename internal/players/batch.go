@@ -0,0 +1,318 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BatchMode defines how the players of a batch are stored.
+type BatchMode string
+
+// batch modes.
+const (
+	// BatchTransactional stores every player of the batch or none of them.
+	BatchTransactional BatchMode = "transactional"
+	// BatchBestEffort stores the valid players of the batch even if other players fail.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// maxBatchSize max number of players of a batch, it keeps multi-row inserts
+// under the limit of parameters of a statement.
+const maxBatchSize = 500
+
+// batch field names used in validation errors.
+const (
+	PlayersField  = "players"
+	PlayerIDField = "player_id"
+	ModeField     = "mode"
+)
+
+// BatchCreatePlayers contains the players to create in a batch.
+type BatchCreatePlayers struct {
+	Players []NewPlayer
+	Mode    BatchMode
+}
+
+// BatchUpdatePlayers contains the players to update in a batch.
+type BatchUpdatePlayers struct {
+	Players []UpdatePlayer
+	Mode    BatchMode
+}
+
+// BatchDeletePlayers contains the players to delete in a batch.
+type BatchDeletePlayers struct {
+	Players []DeletePlayer
+	Mode    BatchMode
+}
+
+// BatchResult contains the result of every player of a batch, in the order of the batch.
+type BatchResult struct {
+	Items []BatchItemResult
+}
+
+// BatchItemResult result of a player of a batch. Player is the created, updated or
+// deleted player, it is nil if Err is not nil.
+type BatchItemResult struct {
+	Player *Player
+	Err    error
+}
+
+// BatchPlayersResult standard response for creating, updating or deleting players in batch.
+type BatchPlayersResult struct {
+	Result *BatchResult
+	Err    string
+}
+
+// PlayerKeys contains the canonical nickname and email of a stored player.
+type PlayerKeys struct {
+	ID                PlayerID
+	CanonicalNickname string
+	CanonicalEmail    string
+}
+
+// BatchItemError is returned by the storage when a player of a batch stored in a single
+// transaction fails, Index is the position of the player in the given players.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+// batchPlayer player of a batch that is ready to be stored.
+type batchPlayer struct {
+	// index position of the player in the batch.
+	index  int
+	player *Player
+	// emailToVerify new email of an updated player.
+	emailToVerify string
+}
+
+// takenKeys canonical nicknames and emails taken by stored players or by previous players
+// of a batch, with the id of the player that has them.
+type takenKeys struct {
+	nicknames map[string]PlayerID
+	emails    map[string]PlayerID
+}
+
+var (
+	ErrEmptyBatch       = newFieldError(PlayersField, "batch is empty")
+	ErrBatchTooLarge    = newFieldError(PlayersField, fmt.Sprintf("batch has more than %d players", maxBatchSize))
+	ErrUnknownBatchMode = newFieldError(ModeField, "mode is unknown")
+	// ErrIdempotencyKeyInBatch is returned for new players of a batch with an idempotency key.
+	ErrIdempotencyKeyInBatch = newFieldError(IdempotencyKeyField, "idempotency keys are not supported in batches")
+	// ErrPlayerRepeatedInBatch is returned for a player that is already changed by a previous item of the batch.
+	ErrPlayerRepeatedInBatch = newFieldError(PlayerIDField, "player is repeated in the batch")
+	// ErrBatchAborted is returned for the players of a transactional batch that were not
+	// stored because other players of the batch failed.
+	ErrBatchAborted = errors.New("player was not stored because other players of the batch failed")
+)
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("player %d of the batch: %s", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the size and mode of the batch.
+func (b BatchCreatePlayers) Validate() error {
+	return validateBatch(len(b.Players), b.Mode)
+}
+
+// Validate checks the size and mode of the batch.
+func (b BatchUpdatePlayers) Validate() error {
+	return validateBatch(len(b.Players), b.Mode)
+}
+
+// Validate checks the size and mode of the batch.
+func (b BatchDeletePlayers) Validate() error {
+	return validateBatch(len(b.Players), b.Mode)
+}
+
+func validateBatch(size int, mode BatchMode) error {
+	var err error
+
+	switch {
+	case size == 0:
+		err = ErrEmptyBatch
+	case size > maxBatchSize:
+		err = ErrBatchTooLarge
+	}
+
+	if mode != BatchTransactional && mode != BatchBestEffort {
+		err = errors.Join(err, ErrUnknownBatchMode)
+	}
+
+	return err
+}
+
+// validateInBatch validates the new player, retries of a batch can't be identified
+// by the idempotency keys of its players.
+func (n NewPlayer) validateInBatch() error {
+	err := n.Validate()
+	if n.IdempotencyKey != "" {
+		err = errors.Join(err, ErrIdempotencyKeyInBatch)
+	}
+
+	return err
+}
+
+// Failed indicates if any player of the batch was not stored.
+func (b *BatchResult) Failed() bool {
+	for _, item := range b.Items {
+		if item.Err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newBatchPlayersResult create a new BatchPlayersResult.
+func newBatchPlayersResult(result *BatchResult, err error) BatchPlayersResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return BatchPlayersResult{
+		Result: result,
+		Err:    errmessage,
+	}
+}
+
+func newBatchResult(size int) *BatchResult {
+	return &BatchResult{
+		Items: make([]BatchItemResult, size),
+	}
+}
+
+// isPending indicates if the player at the given index did not fail yet.
+func (b *BatchResult) isPending(index int) bool {
+	return b.Items[index].Err == nil
+}
+
+func (b *BatchResult) fail(index int, err error) {
+	if err != nil {
+		b.Items[index] = BatchItemResult{Err: err}
+	}
+}
+
+func (b *BatchResult) succeed(index int, player *Player) {
+	b.Items[index] = BatchItemResult{Player: player}
+}
+
+// mustAbort indicates if nothing can be stored because the batch is transactional and a player failed.
+func (b *BatchResult) mustAbort(mode BatchMode) bool {
+	return mode == BatchTransactional && b.Failed()
+}
+
+// abort fails the players that did not fail with ErrBatchAborted.
+func (b *BatchResult) abort() {
+	for index := range b.Items {
+		if b.isPending(index) {
+			b.fail(index, ErrBatchAborted)
+		}
+	}
+}
+
+// failRepeated fails the players that are already in a previous item of the batch.
+func (b *BatchResult) failRepeated(playerIDs []PlayerID) {
+	seen := make(map[PlayerID]struct{}, len(playerIDs))
+
+	for index, playerID := range playerIDs {
+		if _, ok := seen[playerID]; ok && b.isPending(index) {
+			b.fail(index, ErrPlayerRepeatedInBatch)
+
+			continue
+		}
+
+		seen[playerID] = struct{}{}
+	}
+}
+
+// pendingIDs returns the ids of the players that did not fail.
+func (b *BatchResult) pendingIDs(playerIDs []PlayerID) []PlayerID {
+	pendingIDs := make([]PlayerID, 0, len(playerIDs))
+
+	for index, playerID := range playerIDs {
+		if b.isPending(index) {
+			pendingIDs = append(pendingIDs, playerID)
+		}
+	}
+
+	return pendingIDs
+}
+
+// pendingFilters returns the non empty filters of the players that did not fail.
+func (b *BatchResult) pendingFilters(filters []PlayerFilter) []PlayerFilter {
+	pendingFilters := make([]PlayerFilter, 0, len(filters))
+
+	for index, filter := range filters {
+		if b.isPending(index) && !filter.isEmpty() {
+			pendingFilters = append(pendingFilters, filter)
+		}
+	}
+
+	return pendingFilters
+}
+
+func (p PlayerFilter) isEmpty() bool {
+	return p.Email == "" && p.Nickname == ""
+}
+
+func newTakenKeys(storedKeys []PlayerKeys) takenKeys {
+	newTakenKeys := takenKeys{
+		nicknames: make(map[string]PlayerID, len(storedKeys)),
+		emails:    make(map[string]PlayerID, len(storedKeys)),
+	}
+
+	for _, keys := range storedKeys {
+		newTakenKeys.nicknames[keys.CanonicalNickname] = keys.ID
+		newTakenKeys.emails[keys.CanonicalEmail] = keys.ID
+	}
+
+	return newTakenKeys
+}
+
+// check indicates if the email or nickname of the filter is taken by a player other than filter.IgnoreID.
+func (t takenKeys) check(filter PlayerFilter) PlayerExistResult {
+	return PlayerExistResult{
+		EmailExist:    filter.Email != "" && isTakenByOther(t.emails, CanonicalEmail(filter.Email), filter.IgnoreID),
+		NicknameExist: filter.Nickname != "" && isTakenByOther(t.nicknames, CanonicalNickname(filter.Nickname), filter.IgnoreID),
+	}
+}
+
+// take marks the email and nickname of the filter as taken by filter.IgnoreID, which is
+// empty for new players.
+func (t takenKeys) take(filter PlayerFilter) {
+	var owner PlayerID
+	if filter.IgnoreID != nil {
+		owner = *filter.IgnoreID
+	}
+
+	if filter.Email != "" {
+		t.emails[CanonicalEmail(filter.Email)] = owner
+	}
+
+	if filter.Nickname != "" {
+		t.nicknames[CanonicalNickname(filter.Nickname)] = owner
+	}
+}
+
+func isTakenByOther(owners map[string]PlayerID, key string, ignoreID *PlayerID) bool {
+	owner, ok := owners[key]
+
+	return ok && (ignoreID == nil || owner != *ignoreID)
+}
+
+// toPlayers returns the players of the batch to store them.
+func toPlayers(batchPlayers []batchPlayer) []Player {
+	newPlayers := make([]Player, 0, len(batchPlayers))
+
+	for _, item := range batchPlayers {
+		newPlayers = append(newPlayers, *item.player)
+	}
+
+	return newPlayers
+}
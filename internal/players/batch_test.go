@@ -0,0 +1,568 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCreatePlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchCreatePlayers{
+		Players: []players.NewPlayer{
+			newBatchPlayerFixture(t, "focampo"),
+			newBatchPlayerFixture(t, "mrestrepo"),
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	var savedPlayers []players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).
+		Return([]players.PlayerKeys{}, nil).Once()
+	storageMock.On("SaveAll", ctx, mock.AnythingOfType("[]players.Player")).
+		Run(func(args mock.Arguments) { savedPlayers = args.Get(1).([]players.Player) }).
+		Return(nil).Once()
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchCreate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, got.Failed())
+	require.Len(t, got.Items, 2)
+	require.Len(t, savedPlayers, 2)
+	assert.Equal(t, "focampo", got.Items[0].Player.Nickname)
+	assert.Equal(t, "mrestrepo", got.Items[1].Player.Nickname)
+	assert.Equal(t, savedPlayers[0].ID, got.Items[0].Player.ID)
+	assert.Equal(t, "ES", got.Items[0].Player.Country)
+	storageMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	storageMock.AssertExpectations(t)
+}
+
+func TestBatchCreatePlayersTransactionalButPlayersFailed(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	invalidPlayer := newBatchPlayerFixture(t, "fo")
+	batch := players.BatchCreatePlayers{
+		Players: []players.NewPlayer{
+			newBatchPlayerFixture(t, "focampo"),
+			invalidPlayer,
+			newBatchPlayerFixture(t, "FOCAMPO"),
+			newBatchPlayerFixture(t, "taken"),
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	storedKeys := []players.PlayerKeys{
+		{ID: unittests.NewPlayerID(), CanonicalNickname: "taken", CanonicalEmail: "someone@anyemail.com"},
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).Return(storedKeys, nil)
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchCreate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, got.Failed())
+	require.Len(t, got.Items, 4)
+	assert.ErrorIs(t, got.Items[0].Err, players.ErrBatchAborted)
+	assert.Equal(t, []*players.FieldError{
+		{Field: "nickname", Description: "nickname is shorter than 3 characters"},
+	}, players.FieldErrors(got.Items[1].Err))
+	assert.ErrorIs(t, got.Items[2].Err, players.ErrPlayerAlreadyExists)
+	assert.ErrorIs(t, got.Items[3].Err, players.ErrPlayerAlreadyExists)
+
+	for _, item := range got.Items {
+		assert.Nil(t, item.Player)
+	}
+
+	storageMock.AssertNotCalled(t, "SaveAll", mock.Anything, mock.Anything)
+}
+
+func TestBatchCreatePlayersBestEffort(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchCreatePlayers{
+		Players: []players.NewPlayer{
+			newBatchPlayerFixture(t, "focampo"),
+			newBatchPlayerFixture(t, "taken"),
+			newBatchPlayerFixture(t, "mrestrepo"),
+		},
+		Mode: players.BatchBestEffort,
+	}
+	batch.Players[2].IdempotencyKey = "any-key"
+
+	storedKeys := []players.PlayerKeys{
+		{ID: unittests.NewPlayerID(), CanonicalNickname: "taken", CanonicalEmail: "someone@anyemail.com"},
+	}
+
+	var savedPlayers []players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).Return(storedKeys, nil)
+	storageMock.On("SaveAll", ctx, mock.AnythingOfType("[]players.Player")).
+		Run(func(args mock.Arguments) { savedPlayers = args.Get(1).([]players.Player) }).
+		Return(nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service := newBatchServiceWithNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.BatchCreate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, got.Failed())
+	require.Len(t, got.Items, 3)
+	assert.NoError(t, got.Items[0].Err)
+	assert.NotNil(t, got.Items[0].Player)
+	assert.ErrorIs(t, got.Items[1].Err, players.ErrPlayerAlreadyExists)
+	assert.ErrorIs(t, got.Items[2].Err, players.ErrIdempotencyKeyInBatch)
+	require.Len(t, savedPlayers, 1)
+	assert.Equal(t, "focampo", savedPlayers[0].Nickname)
+	notifierMock.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func TestBatchCreatePlayersBestEffortButPlayersCreatedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchCreatePlayers{
+		Players: []players.NewPlayer{
+			newBatchPlayerFixture(t, "focampo"),
+			newBatchPlayerFixture(t, "mrestrepo"),
+		},
+		Mode: players.BatchBestEffort,
+	}
+
+	alreadyExistsErr := &players.PlayerAlreadyExistsError{WasNickname: true}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).Return([]players.PlayerKeys{}, nil)
+	storageMock.On("SaveAll", ctx, mock.AnythingOfType("[]players.Player")).Return(alreadyExistsErr)
+	storageMock.On("Save", ctx, mock.MatchedBy(func(player players.Player) bool { return player.Nickname == "focampo" })).Return(alreadyExistsErr)
+	storageMock.On("Save", ctx, mock.MatchedBy(func(player players.Player) bool { return player.Nickname == "mrestrepo" })).Return(nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchCreate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.ErrorIs(t, got.Items[0].Err, players.ErrPlayerAlreadyExists)
+	assert.NoError(t, got.Items[1].Err)
+	assert.Equal(t, "mrestrepo", got.Items[1].Player.Nickname)
+}
+
+func TestBatchCreatePlayersTransactionalButPlayersCreatedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchCreatePlayers{
+		Players: []players.NewPlayer{
+			newBatchPlayerFixture(t, "focampo"),
+			newBatchPlayerFixture(t, "mrestrepo"),
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	storedKeys := []players.PlayerKeys{
+		{ID: unittests.NewPlayerID(), CanonicalNickname: "mrestrepo", CanonicalEmail: "someone@anyemail.com"},
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).
+		Return([]players.PlayerKeys{}, nil).Once()
+	storageMock.On("SaveAll", ctx, mock.AnythingOfType("[]players.Player")).
+		Return(&players.PlayerAlreadyExistsError{WasNickname: true})
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, mock.AnythingOfType("[]players.PlayerFilter")).
+		Return(storedKeys, nil).Once()
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchCreate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.ErrorIs(t, got.Items[0].Err, players.ErrBatchAborted)
+	assert.ErrorIs(t, got.Items[1].Err, players.ErrPlayerAlreadyExists)
+	storageMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBatchCreatePlayersButInvalidBatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		batch func(t *testing.T) players.BatchCreatePlayers
+		want  []*players.FieldError
+	}{
+		"empty_batch": {
+			batch: func(_ *testing.T) players.BatchCreatePlayers {
+				return players.BatchCreatePlayers{Mode: players.BatchTransactional}
+			},
+			want: []*players.FieldError{
+				{Field: "players", Description: "batch is empty"},
+			},
+		},
+		"too_many_players": {
+			batch: func(t *testing.T) players.BatchCreatePlayers {
+				newPlayers := make([]players.NewPlayer, 501)
+				for index := range newPlayers {
+					newPlayers[index] = newBatchPlayerFixture(t, "focampo")
+				}
+
+				return players.BatchCreatePlayers{Players: newPlayers, Mode: players.BatchBestEffort}
+			},
+			want: []*players.FieldError{
+				{Field: "players", Description: "batch has more than 500 players"},
+			},
+		},
+		"unknown_mode": {
+			batch: func(t *testing.T) players.BatchCreatePlayers {
+				return players.BatchCreatePlayers{
+					Players: []players.NewPlayer{newBatchPlayerFixture(t, "focampo")},
+					Mode:    "eventually",
+				}
+			},
+			want: []*players.FieldError{
+				{Field: "mode", Description: "mode is unknown"},
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			storageMock := unittests.NewStorageMock()
+			service := newBatchService(storageMock)
+
+			// When
+			got, err := service.BatchCreate(ctx, data.batch(st))
+
+			// Then
+			assert.Nil(st, got)
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			assert.Equal(st, data.want, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "GetPlayersWithEmailsOrNicknames", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBatchUpdatePlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayer := storedBatchPlayerFixture(t, "focampo")
+	secondPlayer := storedBatchPlayerFixture(t, "mrestrepo")
+	batch := players.BatchUpdatePlayers{
+		Players: []players.UpdatePlayer{
+			{ID: *firstPlayer.ID, Nickname: players.NewString("fernando")},
+			{ID: *secondPlayer.ID, LastName: players.NewString("Restrepo")},
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	wantFilters := []players.PlayerFilter{
+		{Nickname: "fernando", IgnoreID: firstPlayer.ID},
+	}
+
+	var updatedPlayers []players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailsOrNicknames", ctx, wantFilters).Return([]players.PlayerKeys{}, nil)
+	storageMock.On("GetByIDs", ctx, []players.PlayerID{*firstPlayer.ID, *secondPlayer.ID}).
+		Return([]players.Player{secondPlayer, firstPlayer}, nil)
+	storageMock.On("UpdateAll", ctx, mock.AnythingOfType("[]players.Player")).
+		Run(func(args mock.Arguments) { updatedPlayers = args.Get(1).([]players.Player) }).
+		Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service := newBatchServiceWithNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.BatchUpdate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, got.Failed())
+	require.Len(t, updatedPlayers, 2)
+	assert.Equal(t, int64(3), updatedPlayers[0].Version, "the storage gets the version read")
+	assert.Equal(t, "fernando", got.Items[0].Player.Nickname)
+	assert.Equal(t, int64(4), got.Items[0].Player.Version)
+	assert.Equal(t, "Restrepo", got.Items[1].Player.LastName)
+	notifierMock.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func TestBatchUpdatePlayersTransactionalButPlayerChangedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayer := storedBatchPlayerFixture(t, "focampo")
+	secondPlayer := storedBatchPlayerFixture(t, "mrestrepo")
+	batch := players.BatchUpdatePlayers{
+		Players: []players.UpdatePlayer{
+			{ID: *firstPlayer.ID, LastName: players.NewString("Restrepo")},
+			{ID: *secondPlayer.ID, FirstName: players.NewString("Mario")},
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByIDs", ctx, mock.AnythingOfType("[]players.PlayerID")).
+		Return([]players.Player{firstPlayer, secondPlayer}, nil)
+	storageMock.On("UpdateAll", ctx, mock.AnythingOfType("[]players.Player")).
+		Return(&players.BatchItemError{Index: 1, Err: players.ErrPlayerVersionConflict})
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchUpdate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.ErrorIs(t, got.Items[0].Err, players.ErrBatchAborted)
+	assert.ErrorIs(t, got.Items[1].Err, players.ErrPlayerVersionConflict)
+	storageMock.AssertNotCalled(t, "GetPlayersWithEmailsOrNicknames", mock.Anything, mock.Anything)
+}
+
+func TestBatchUpdatePlayersBestEffort(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayer := storedBatchPlayerFixture(t, "focampo")
+	secondPlayer := storedBatchPlayerFixture(t, "mrestrepo")
+	missingPlayerID := unittests.NewPlayerID()
+	batch := players.BatchUpdatePlayers{
+		Players: []players.UpdatePlayer{
+			{ID: *firstPlayer.ID, LastName: players.NewString("Restrepo")},
+			{ID: missingPlayerID, LastName: players.NewString("Restrepo")},
+			{ID: *firstPlayer.ID, LastName: players.NewString("Ocampo")},
+			{ID: *secondPlayer.ID, LastName: players.NewString("Ocampo"), ExpectedVersion: 2},
+			{ID: unittests.NewPlayerID(), Email: &mail.Address{Address: "mrestrepo"}},
+		},
+		Mode: players.BatchBestEffort,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByIDs", ctx, []players.PlayerID{*firstPlayer.ID, missingPlayerID, *secondPlayer.ID}).
+		Return([]players.Player{firstPlayer, secondPlayer}, nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service := newBatchServiceWithNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.BatchUpdate(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, got.Items, 5)
+	assert.NoError(t, got.Items[0].Err)
+	assert.Equal(t, "Restrepo", got.Items[0].Player.LastName)
+	assert.ErrorIs(t, got.Items[1].Err, players.ErrPlayerDoesNotExist)
+	assert.ErrorIs(t, got.Items[2].Err, players.ErrPlayerRepeatedInBatch)
+	assert.ErrorIs(t, got.Items[3].Err, players.ErrPlayerVersionConflict)
+	assert.Equal(t, []*players.FieldError{
+		{Field: "email", Description: "email is not a valid address"},
+	}, players.FieldErrors(got.Items[4].Err))
+	storageMock.AssertNumberOfCalls(t, "Update", 1)
+	notifierMock.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func TestBatchDeletePlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayer := storedBatchPlayerFixture(t, "focampo")
+	secondPlayer := storedBatchPlayerFixture(t, "mrestrepo")
+	batch := players.BatchDeletePlayers{
+		Players: []players.DeletePlayer{
+			{ID: *firstPlayer.ID},
+			{ID: *secondPlayer.ID, ExpectedVersion: 3},
+		},
+		Mode: players.BatchTransactional,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByIDs", ctx, mock.AnythingOfType("[]players.PlayerID")).
+		Return([]players.Player{firstPlayer, secondPlayer}, nil)
+	storageMock.On("DeleteAll", ctx, []players.Player{firstPlayer, secondPlayer}, mock.AnythingOfType("time.Time")).Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service := newBatchServiceWithNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.BatchDelete(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, got.Failed())
+	assert.Equal(t, firstPlayer.ID, got.Items[0].Player.ID)
+	assert.Equal(t, secondPlayer.ID, got.Items[1].Player.ID)
+	notifierMock.AssertNumberOfCalls(t, "Notify", 2)
+}
+
+func TestBatchDeletePlayersBestEffort(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayer := storedBatchPlayerFixture(t, "focampo")
+	secondPlayer := storedBatchPlayerFixture(t, "mrestrepo")
+	batch := players.BatchDeletePlayers{
+		Players: []players.DeletePlayer{
+			{ID: *firstPlayer.ID},
+			{ID: *secondPlayer.ID},
+		},
+		Mode: players.BatchBestEffort,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByIDs", ctx, mock.AnythingOfType("[]players.PlayerID")).
+		Return([]players.Player{firstPlayer, secondPlayer}, nil)
+	storageMock.On("Delete", ctx, *firstPlayer.ID, firstPlayer.Version, mock.AnythingOfType("time.Time")).Return(true, nil)
+	storageMock.On("Delete", ctx, *secondPlayer.ID, secondPlayer.Version, mock.AnythingOfType("time.Time")).
+		Return(false, errors.New("unexpected delete error"))
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service := newBatchServiceWithNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.BatchDelete(ctx, batch)
+
+	// Then
+	require.NoError(t, err)
+	assert.NoError(t, got.Items[0].Err)
+	assert.EqualError(t, got.Items[1].Err, "unable to delete player: unexpected delete error")
+	storageMock.AssertNotCalled(t, "DeleteAll", mock.Anything, mock.Anything, mock.Anything)
+	notifierMock.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func TestBatchDeletePlayersButStorageError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchDeletePlayers{
+		Players: []players.DeletePlayer{{ID: unittests.NewPlayerID()}},
+		Mode:    players.BatchTransactional,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByIDs", ctx, mock.AnythingOfType("[]players.PlayerID")).Return(nil, errors.New("unexpected read error"))
+
+	service := newBatchService(storageMock)
+
+	// When
+	got, err := service.BatchDelete(ctx, batch)
+
+	// Then
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "unable to delete players: unexpected read error")
+}
+
+func TestBatchDeletePlayersWithEndpointButInvalidBatch(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	batch := players.BatchDeletePlayers{Mode: players.BatchTransactional}
+	wantedResult := players.BatchPlayersResult{
+		Err: "unable to delete players: batch is empty",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	service, logger := unittests.NewPlayerServiceWithStorage(storageMock)
+	batchDeletePlayersEndpoint := players.MakeBatchDeletePlayersEndpoint(service, logger)
+
+	// When
+	got, err := batchDeletePlayersEndpoint.Do(ctx, &batch)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, wantedResult, got)
+}
+
+func newBatchService(storageMock *unittests.MockStorage) *players.Service {
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	return newBatchServiceWithNotifier(storageMock, notifierMock)
+}
+
+func newBatchServiceWithNotifier(storageMock *unittests.MockStorage, notifierMock *unittests.MockNotifier) *players.Service {
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", mock.AnythingOfType("string")).Return([]byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"), nil)
+
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", mock.Anything, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, hasherMock, notifierMock, mailerMock, time.Hour)
+
+	return service
+}
+
+func newBatchPlayerFixture(t *testing.T, nickname string) players.NewPlayer {
+	t.Helper()
+
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.Nickname = nickname
+	newPlayer.Email = *unittests.NewEmailAddress(t, nickname+"@anyemail.com")
+
+	return newPlayer
+}
+
+func storedBatchPlayerFixture(t *testing.T, nickname string) players.Player {
+	t.Helper()
+
+	playerID := unittests.NewPlayerID()
+
+	return players.Player{
+		ID:          &playerID,
+		FirstName:   "Fernando",
+		LastName:    "Ocampo",
+		Nickname:    nickname,
+		Email:       *unittests.NewEmailAddress(t, nickname+"@anyemail.com"),
+		Country:     "ES",
+		DateCreated: time.Now().UTC(),
+		DateUpdated: time.Now().UTC(),
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+		Version: 3,
+	}
+}
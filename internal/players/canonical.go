@@ -0,0 +1,57 @@
+package players
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps characters of other scripts that look like latin letters to
+// those letters, so nicknames like "аlice" with a cyrillic "а" collide with "alice".
+// Keys are lower case because they are applied after case folding.
+var confusables = map[rune]rune{
+	// cyrillic.
+	'а': 'a', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'ӏ': 'l', 'о': 'o',
+	'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'ԝ': 'w', 'х': 'x', 'у': 'y', 'с': 'c',
+	// greek.
+	'α': 'a', 'ι': 'i', 'ϳ': 'j', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'ϲ': 'c',
+	'υ': 'u', 'χ': 'x',
+	// latin.
+	'ı': 'i', 'ɑ': 'a', 'ɡ': 'g',
+}
+
+// Canonicalization contains the result of storing the canonical forms of the players
+// created before they were compared by them.
+type Canonicalization struct {
+	// Players number of players whose canonical forms were stored.
+	Players int
+	// Collisions players whose canonical nickname or email is taken by another active
+	// player, their canonical forms were not stored.
+	Collisions []PlayerID
+}
+
+// CanonicalNickname returns the form used to compare nicknames, two nicknames are the same
+// if their canonical forms are equal. It applies NFKC normalization, case folding and
+// replaces confusable characters with the latin letters they look like.
+func CanonicalNickname(nickname string) string {
+	folded := cases.Fold().String(norm.NFKC.String(nickname))
+
+	return strings.Map(toLatinLookalike, folded)
+}
+
+// CanonicalEmail returns the form used to compare email addresses. Both the local part and
+// the domain are compared case-insensitively after NFKC normalization, even if the local
+// part is case sensitive for some mail servers, because no provider relies on it.
+func CanonicalEmail(address string) string {
+	return strings.ToLower(norm.NFKC.String(address))
+}
+
+func toLatinLookalike(r rune) rune {
+	latin, ok := confusables[r]
+	if !ok {
+		return r
+	}
+
+	return latin
+}
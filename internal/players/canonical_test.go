@@ -0,0 +1,156 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalNickname(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		nickname string
+		want     string
+	}{
+		"lower_case": {
+			nickname: "focampo",
+			want:     "focampo",
+		},
+		"upper_case": {
+			nickname: "FOcampo",
+			want:     "focampo",
+		},
+		"full_width_characters": {
+			nickname: "ｆｏｃａｍｐｏ",
+			want:     "focampo",
+		},
+		"cyrillic_lookalikes": {
+			nickname: "fосаmро",
+			want:     "focampo",
+		},
+		"greek_lookalikes": {
+			nickname: "fοcampο",
+			want:     "focampo",
+		},
+		"sharp_s_is_folded": {
+			nickname: "Straße",
+			want:     "strasse",
+		},
+		"other_scripts_are_kept": {
+			nickname: "Jugadorñ",
+			want:     "jugadorñ",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+
+			// When
+			got := players.CanonicalNickname(data.nickname)
+
+			// Then
+			assert.Equal(st, data.want, got)
+		})
+	}
+}
+
+func TestCanonicalEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		email string
+		want  string
+	}{
+		"lower_case": {
+			email: "focampo@anyemail.com",
+			want:  "focampo@anyemail.com",
+		},
+		"upper_case_local_part_and_domain": {
+			email: "FOcampo@AnyEmail.COM",
+			want:  "focampo@anyemail.com",
+		},
+		"full_width_characters": {
+			email: "ｆｏｃａｍｐｏ@anyemail.com",
+			want:  "focampo@anyemail.com",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+
+			// When
+			got := players.CanonicalEmail(data.email)
+
+			// Then
+			assert.Equal(st, data.want, got)
+		})
+	}
+}
+
+func TestCanonicalizePlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	strasse := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	alice := unittests.PlayerIDFixture(t, "8b1cb38a-d21c-4e36-b7f0-814f96180b5e")
+	cyrillicAlice := unittests.PlayerIDFixture(t, "f7ee12ee-191d-4f7e-819e-dbb7b115bed2")
+
+	storedPlayers := []players.Player{
+		{ID: strasse, Nickname: "Straße", Email: mail.Address{Address: "Strasse@Example.com"}},
+		{ID: alice, Nickname: "alice", Email: mail.Address{Address: "alice@example.com"}},
+		{ID: cyrillicAlice, Nickname: "аlice", Email: mail.Address{Address: "alice2@example.com"}},
+	}
+
+	want := players.Canonicalization{
+		Players:    2,
+		Collisions: []players.PlayerID{*cyrillicAlice},
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithoutCanonicalKeys", ctx).Return(storedPlayers, nil)
+	storageMock.On("SaveCanonicalKeys", ctx, players.PlayerKeys{
+		ID: *strasse, CanonicalNickname: "strasse", CanonicalEmail: "strasse@example.com",
+	}).Return(nil)
+	storageMock.On("SaveCanonicalKeys", ctx, players.PlayerKeys{
+		ID: *alice, CanonicalNickname: "alice", CanonicalEmail: "alice@example.com",
+	}).Return(nil)
+	storageMock.On("SaveCanonicalKeys", ctx, players.PlayerKeys{
+		ID: *cyrillicAlice, CanonicalNickname: "alice", CanonicalEmail: "alice2@example.com",
+	}).Return(&players.PlayerAlreadyExistsError{WasNickname: true})
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.CanonicalizePlayers(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &want, got)
+	storageMock.AssertExpectations(t)
+}
+
+func TestCanonicalizePlayersButStorageError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithoutCanonicalKeys", ctx).Return(nil, errors.New("database is down"))
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.CanonicalizePlayers(ctx)
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
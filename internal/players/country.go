@@ -0,0 +1,431 @@
+package players
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Country is a country defined by ISO 3166-1.
+type Country struct {
+	// Code ISO 3166-1 alpha-2 code, players store it as their country.
+	Code string
+	// Alpha3 ISO 3166-1 alpha-3 code.
+	Alpha3 string
+	// Name english short name.
+	Name string
+}
+
+// CountryPlayers contains the number of players of a country.
+type CountryPlayers struct {
+	Country Country
+	// Players number of players that are not deleted.
+	Players int
+}
+
+// CountryNormalization contains the result of normalizing the stored countries.
+type CountryNormalization struct {
+	// Players number of players whose country was normalized.
+	Players int
+	// Unknown stored countries that are not ISO 3166-1 countries, they were not changed.
+	Unknown []string
+}
+
+// ListCountriesResult standard response for listing the countries.
+type ListCountriesResult struct {
+	Countries []CountryPlayers
+	Err       string
+}
+
+// ErrInvalidCountry is returned for countries that are not ISO 3166-1 countries.
+var ErrInvalidCountry = newFieldError(CountryField, "country is not an ISO 3166-1 country")
+
+// countries ISO 3166-1 countries sorted by code.
+var countries = []Country{
+	{Code: "AD", Alpha3: "AND", Name: "Andorra"},
+	{Code: "AE", Alpha3: "ARE", Name: "United Arab Emirates"},
+	{Code: "AF", Alpha3: "AFG", Name: "Afghanistan"},
+	{Code: "AG", Alpha3: "ATG", Name: "Antigua and Barbuda"},
+	{Code: "AI", Alpha3: "AIA", Name: "Anguilla"},
+	{Code: "AL", Alpha3: "ALB", Name: "Albania"},
+	{Code: "AM", Alpha3: "ARM", Name: "Armenia"},
+	{Code: "AO", Alpha3: "AGO", Name: "Angola"},
+	{Code: "AQ", Alpha3: "ATA", Name: "Antarctica"},
+	{Code: "AR", Alpha3: "ARG", Name: "Argentina"},
+	{Code: "AS", Alpha3: "ASM", Name: "American Samoa"},
+	{Code: "AT", Alpha3: "AUT", Name: "Austria"},
+	{Code: "AU", Alpha3: "AUS", Name: "Australia"},
+	{Code: "AW", Alpha3: "ABW", Name: "Aruba"},
+	{Code: "AX", Alpha3: "ALA", Name: "Åland Islands"},
+	{Code: "AZ", Alpha3: "AZE", Name: "Azerbaijan"},
+	{Code: "BA", Alpha3: "BIH", Name: "Bosnia and Herzegovina"},
+	{Code: "BB", Alpha3: "BRB", Name: "Barbados"},
+	{Code: "BD", Alpha3: "BGD", Name: "Bangladesh"},
+	{Code: "BE", Alpha3: "BEL", Name: "Belgium"},
+	{Code: "BF", Alpha3: "BFA", Name: "Burkina Faso"},
+	{Code: "BG", Alpha3: "BGR", Name: "Bulgaria"},
+	{Code: "BH", Alpha3: "BHR", Name: "Bahrain"},
+	{Code: "BI", Alpha3: "BDI", Name: "Burundi"},
+	{Code: "BJ", Alpha3: "BEN", Name: "Benin"},
+	{Code: "BL", Alpha3: "BLM", Name: "Saint Barthélemy"},
+	{Code: "BM", Alpha3: "BMU", Name: "Bermuda"},
+	{Code: "BN", Alpha3: "BRN", Name: "Brunei Darussalam"},
+	{Code: "BO", Alpha3: "BOL", Name: "Bolivia"},
+	{Code: "BQ", Alpha3: "BES", Name: "Bonaire, Sint Eustatius and Saba"},
+	{Code: "BR", Alpha3: "BRA", Name: "Brazil"},
+	{Code: "BS", Alpha3: "BHS", Name: "Bahamas"},
+	{Code: "BT", Alpha3: "BTN", Name: "Bhutan"},
+	{Code: "BV", Alpha3: "BVT", Name: "Bouvet Island"},
+	{Code: "BW", Alpha3: "BWA", Name: "Botswana"},
+	{Code: "BY", Alpha3: "BLR", Name: "Belarus"},
+	{Code: "BZ", Alpha3: "BLZ", Name: "Belize"},
+	{Code: "CA", Alpha3: "CAN", Name: "Canada"},
+	{Code: "CC", Alpha3: "CCK", Name: "Cocos (Keeling) Islands"},
+	{Code: "CD", Alpha3: "COD", Name: "Democratic Republic of the Congo"},
+	{Code: "CF", Alpha3: "CAF", Name: "Central African Republic"},
+	{Code: "CG", Alpha3: "COG", Name: "Congo"},
+	{Code: "CH", Alpha3: "CHE", Name: "Switzerland"},
+	{Code: "CI", Alpha3: "CIV", Name: "Côte d'Ivoire"},
+	{Code: "CK", Alpha3: "COK", Name: "Cook Islands"},
+	{Code: "CL", Alpha3: "CHL", Name: "Chile"},
+	{Code: "CM", Alpha3: "CMR", Name: "Cameroon"},
+	{Code: "CN", Alpha3: "CHN", Name: "China"},
+	{Code: "CO", Alpha3: "COL", Name: "Colombia"},
+	{Code: "CR", Alpha3: "CRI", Name: "Costa Rica"},
+	{Code: "CU", Alpha3: "CUB", Name: "Cuba"},
+	{Code: "CV", Alpha3: "CPV", Name: "Cabo Verde"},
+	{Code: "CW", Alpha3: "CUW", Name: "Curaçao"},
+	{Code: "CX", Alpha3: "CXR", Name: "Christmas Island"},
+	{Code: "CY", Alpha3: "CYP", Name: "Cyprus"},
+	{Code: "CZ", Alpha3: "CZE", Name: "Czechia"},
+	{Code: "DE", Alpha3: "DEU", Name: "Germany"},
+	{Code: "DJ", Alpha3: "DJI", Name: "Djibouti"},
+	{Code: "DK", Alpha3: "DNK", Name: "Denmark"},
+	{Code: "DM", Alpha3: "DMA", Name: "Dominica"},
+	{Code: "DO", Alpha3: "DOM", Name: "Dominican Republic"},
+	{Code: "DZ", Alpha3: "DZA", Name: "Algeria"},
+	{Code: "EC", Alpha3: "ECU", Name: "Ecuador"},
+	{Code: "EE", Alpha3: "EST", Name: "Estonia"},
+	{Code: "EG", Alpha3: "EGY", Name: "Egypt"},
+	{Code: "EH", Alpha3: "ESH", Name: "Western Sahara"},
+	{Code: "ER", Alpha3: "ERI", Name: "Eritrea"},
+	{Code: "ES", Alpha3: "ESP", Name: "Spain"},
+	{Code: "ET", Alpha3: "ETH", Name: "Ethiopia"},
+	{Code: "FI", Alpha3: "FIN", Name: "Finland"},
+	{Code: "FJ", Alpha3: "FJI", Name: "Fiji"},
+	{Code: "FK", Alpha3: "FLK", Name: "Falkland Islands"},
+	{Code: "FM", Alpha3: "FSM", Name: "Micronesia"},
+	{Code: "FO", Alpha3: "FRO", Name: "Faroe Islands"},
+	{Code: "FR", Alpha3: "FRA", Name: "France"},
+	{Code: "GA", Alpha3: "GAB", Name: "Gabon"},
+	{Code: "GB", Alpha3: "GBR", Name: "United Kingdom"},
+	{Code: "GD", Alpha3: "GRD", Name: "Grenada"},
+	{Code: "GE", Alpha3: "GEO", Name: "Georgia"},
+	{Code: "GF", Alpha3: "GUF", Name: "French Guiana"},
+	{Code: "GG", Alpha3: "GGY", Name: "Guernsey"},
+	{Code: "GH", Alpha3: "GHA", Name: "Ghana"},
+	{Code: "GI", Alpha3: "GIB", Name: "Gibraltar"},
+	{Code: "GL", Alpha3: "GRL", Name: "Greenland"},
+	{Code: "GM", Alpha3: "GMB", Name: "Gambia"},
+	{Code: "GN", Alpha3: "GIN", Name: "Guinea"},
+	{Code: "GP", Alpha3: "GLP", Name: "Guadeloupe"},
+	{Code: "GQ", Alpha3: "GNQ", Name: "Equatorial Guinea"},
+	{Code: "GR", Alpha3: "GRC", Name: "Greece"},
+	{Code: "GS", Alpha3: "SGS", Name: "South Georgia and the South Sandwich Islands"},
+	{Code: "GT", Alpha3: "GTM", Name: "Guatemala"},
+	{Code: "GU", Alpha3: "GUM", Name: "Guam"},
+	{Code: "GW", Alpha3: "GNB", Name: "Guinea-Bissau"},
+	{Code: "GY", Alpha3: "GUY", Name: "Guyana"},
+	{Code: "HK", Alpha3: "HKG", Name: "Hong Kong"},
+	{Code: "HM", Alpha3: "HMD", Name: "Heard Island and McDonald Islands"},
+	{Code: "HN", Alpha3: "HND", Name: "Honduras"},
+	{Code: "HR", Alpha3: "HRV", Name: "Croatia"},
+	{Code: "HT", Alpha3: "HTI", Name: "Haiti"},
+	{Code: "HU", Alpha3: "HUN", Name: "Hungary"},
+	{Code: "ID", Alpha3: "IDN", Name: "Indonesia"},
+	{Code: "IE", Alpha3: "IRL", Name: "Ireland"},
+	{Code: "IL", Alpha3: "ISR", Name: "Israel"},
+	{Code: "IM", Alpha3: "IMN", Name: "Isle of Man"},
+	{Code: "IN", Alpha3: "IND", Name: "India"},
+	{Code: "IO", Alpha3: "IOT", Name: "British Indian Ocean Territory"},
+	{Code: "IQ", Alpha3: "IRQ", Name: "Iraq"},
+	{Code: "IR", Alpha3: "IRN", Name: "Iran"},
+	{Code: "IS", Alpha3: "ISL", Name: "Iceland"},
+	{Code: "IT", Alpha3: "ITA", Name: "Italy"},
+	{Code: "JE", Alpha3: "JEY", Name: "Jersey"},
+	{Code: "JM", Alpha3: "JAM", Name: "Jamaica"},
+	{Code: "JO", Alpha3: "JOR", Name: "Jordan"},
+	{Code: "JP", Alpha3: "JPN", Name: "Japan"},
+	{Code: "KE", Alpha3: "KEN", Name: "Kenya"},
+	{Code: "KG", Alpha3: "KGZ", Name: "Kyrgyzstan"},
+	{Code: "KH", Alpha3: "KHM", Name: "Cambodia"},
+	{Code: "KI", Alpha3: "KIR", Name: "Kiribati"},
+	{Code: "KM", Alpha3: "COM", Name: "Comoros"},
+	{Code: "KN", Alpha3: "KNA", Name: "Saint Kitts and Nevis"},
+	{Code: "KP", Alpha3: "PRK", Name: "North Korea"},
+	{Code: "KR", Alpha3: "KOR", Name: "South Korea"},
+	{Code: "KW", Alpha3: "KWT", Name: "Kuwait"},
+	{Code: "KY", Alpha3: "CYM", Name: "Cayman Islands"},
+	{Code: "KZ", Alpha3: "KAZ", Name: "Kazakhstan"},
+	{Code: "LA", Alpha3: "LAO", Name: "Laos"},
+	{Code: "LB", Alpha3: "LBN", Name: "Lebanon"},
+	{Code: "LC", Alpha3: "LCA", Name: "Saint Lucia"},
+	{Code: "LI", Alpha3: "LIE", Name: "Liechtenstein"},
+	{Code: "LK", Alpha3: "LKA", Name: "Sri Lanka"},
+	{Code: "LR", Alpha3: "LBR", Name: "Liberia"},
+	{Code: "LS", Alpha3: "LSO", Name: "Lesotho"},
+	{Code: "LT", Alpha3: "LTU", Name: "Lithuania"},
+	{Code: "LU", Alpha3: "LUX", Name: "Luxembourg"},
+	{Code: "LV", Alpha3: "LVA", Name: "Latvia"},
+	{Code: "LY", Alpha3: "LBY", Name: "Libya"},
+	{Code: "MA", Alpha3: "MAR", Name: "Morocco"},
+	{Code: "MC", Alpha3: "MCO", Name: "Monaco"},
+	{Code: "MD", Alpha3: "MDA", Name: "Moldova"},
+	{Code: "ME", Alpha3: "MNE", Name: "Montenegro"},
+	{Code: "MF", Alpha3: "MAF", Name: "Saint Martin (French part)"},
+	{Code: "MG", Alpha3: "MDG", Name: "Madagascar"},
+	{Code: "MH", Alpha3: "MHL", Name: "Marshall Islands"},
+	{Code: "MK", Alpha3: "MKD", Name: "North Macedonia"},
+	{Code: "ML", Alpha3: "MLI", Name: "Mali"},
+	{Code: "MM", Alpha3: "MMR", Name: "Myanmar"},
+	{Code: "MN", Alpha3: "MNG", Name: "Mongolia"},
+	{Code: "MO", Alpha3: "MAC", Name: "Macao"},
+	{Code: "MP", Alpha3: "MNP", Name: "Northern Mariana Islands"},
+	{Code: "MQ", Alpha3: "MTQ", Name: "Martinique"},
+	{Code: "MR", Alpha3: "MRT", Name: "Mauritania"},
+	{Code: "MS", Alpha3: "MSR", Name: "Montserrat"},
+	{Code: "MT", Alpha3: "MLT", Name: "Malta"},
+	{Code: "MU", Alpha3: "MUS", Name: "Mauritius"},
+	{Code: "MV", Alpha3: "MDV", Name: "Maldives"},
+	{Code: "MW", Alpha3: "MWI", Name: "Malawi"},
+	{Code: "MX", Alpha3: "MEX", Name: "Mexico"},
+	{Code: "MY", Alpha3: "MYS", Name: "Malaysia"},
+	{Code: "MZ", Alpha3: "MOZ", Name: "Mozambique"},
+	{Code: "NA", Alpha3: "NAM", Name: "Namibia"},
+	{Code: "NC", Alpha3: "NCL", Name: "New Caledonia"},
+	{Code: "NE", Alpha3: "NER", Name: "Niger"},
+	{Code: "NF", Alpha3: "NFK", Name: "Norfolk Island"},
+	{Code: "NG", Alpha3: "NGA", Name: "Nigeria"},
+	{Code: "NI", Alpha3: "NIC", Name: "Nicaragua"},
+	{Code: "NL", Alpha3: "NLD", Name: "Netherlands"},
+	{Code: "NO", Alpha3: "NOR", Name: "Norway"},
+	{Code: "NP", Alpha3: "NPL", Name: "Nepal"},
+	{Code: "NR", Alpha3: "NRU", Name: "Nauru"},
+	{Code: "NU", Alpha3: "NIU", Name: "Niue"},
+	{Code: "NZ", Alpha3: "NZL", Name: "New Zealand"},
+	{Code: "OM", Alpha3: "OMN", Name: "Oman"},
+	{Code: "PA", Alpha3: "PAN", Name: "Panama"},
+	{Code: "PE", Alpha3: "PER", Name: "Peru"},
+	{Code: "PF", Alpha3: "PYF", Name: "French Polynesia"},
+	{Code: "PG", Alpha3: "PNG", Name: "Papua New Guinea"},
+	{Code: "PH", Alpha3: "PHL", Name: "Philippines"},
+	{Code: "PK", Alpha3: "PAK", Name: "Pakistan"},
+	{Code: "PL", Alpha3: "POL", Name: "Poland"},
+	{Code: "PM", Alpha3: "SPM", Name: "Saint Pierre and Miquelon"},
+	{Code: "PN", Alpha3: "PCN", Name: "Pitcairn"},
+	{Code: "PR", Alpha3: "PRI", Name: "Puerto Rico"},
+	{Code: "PS", Alpha3: "PSE", Name: "Palestine"},
+	{Code: "PT", Alpha3: "PRT", Name: "Portugal"},
+	{Code: "PW", Alpha3: "PLW", Name: "Palau"},
+	{Code: "PY", Alpha3: "PRY", Name: "Paraguay"},
+	{Code: "QA", Alpha3: "QAT", Name: "Qatar"},
+	{Code: "RE", Alpha3: "REU", Name: "Réunion"},
+	{Code: "RO", Alpha3: "ROU", Name: "Romania"},
+	{Code: "RS", Alpha3: "SRB", Name: "Serbia"},
+	{Code: "RU", Alpha3: "RUS", Name: "Russia"},
+	{Code: "RW", Alpha3: "RWA", Name: "Rwanda"},
+	{Code: "SA", Alpha3: "SAU", Name: "Saudi Arabia"},
+	{Code: "SB", Alpha3: "SLB", Name: "Solomon Islands"},
+	{Code: "SC", Alpha3: "SYC", Name: "Seychelles"},
+	{Code: "SD", Alpha3: "SDN", Name: "Sudan"},
+	{Code: "SE", Alpha3: "SWE", Name: "Sweden"},
+	{Code: "SG", Alpha3: "SGP", Name: "Singapore"},
+	{Code: "SH", Alpha3: "SHN", Name: "Saint Helena, Ascension and Tristan da Cunha"},
+	{Code: "SI", Alpha3: "SVN", Name: "Slovenia"},
+	{Code: "SJ", Alpha3: "SJM", Name: "Svalbard and Jan Mayen"},
+	{Code: "SK", Alpha3: "SVK", Name: "Slovakia"},
+	{Code: "SL", Alpha3: "SLE", Name: "Sierra Leone"},
+	{Code: "SM", Alpha3: "SMR", Name: "San Marino"},
+	{Code: "SN", Alpha3: "SEN", Name: "Senegal"},
+	{Code: "SO", Alpha3: "SOM", Name: "Somalia"},
+	{Code: "SR", Alpha3: "SUR", Name: "Suriname"},
+	{Code: "SS", Alpha3: "SSD", Name: "South Sudan"},
+	{Code: "ST", Alpha3: "STP", Name: "Sao Tome and Principe"},
+	{Code: "SV", Alpha3: "SLV", Name: "El Salvador"},
+	{Code: "SX", Alpha3: "SXM", Name: "Sint Maarten (Dutch part)"},
+	{Code: "SY", Alpha3: "SYR", Name: "Syria"},
+	{Code: "SZ", Alpha3: "SWZ", Name: "Eswatini"},
+	{Code: "TC", Alpha3: "TCA", Name: "Turks and Caicos Islands"},
+	{Code: "TD", Alpha3: "TCD", Name: "Chad"},
+	{Code: "TF", Alpha3: "ATF", Name: "French Southern Territories"},
+	{Code: "TG", Alpha3: "TGO", Name: "Togo"},
+	{Code: "TH", Alpha3: "THA", Name: "Thailand"},
+	{Code: "TJ", Alpha3: "TJK", Name: "Tajikistan"},
+	{Code: "TK", Alpha3: "TKL", Name: "Tokelau"},
+	{Code: "TL", Alpha3: "TLS", Name: "Timor-Leste"},
+	{Code: "TM", Alpha3: "TKM", Name: "Turkmenistan"},
+	{Code: "TN", Alpha3: "TUN", Name: "Tunisia"},
+	{Code: "TO", Alpha3: "TON", Name: "Tonga"},
+	{Code: "TR", Alpha3: "TUR", Name: "Türkiye"},
+	{Code: "TT", Alpha3: "TTO", Name: "Trinidad and Tobago"},
+	{Code: "TV", Alpha3: "TUV", Name: "Tuvalu"},
+	{Code: "TW", Alpha3: "TWN", Name: "Taiwan"},
+	{Code: "TZ", Alpha3: "TZA", Name: "Tanzania"},
+	{Code: "UA", Alpha3: "UKR", Name: "Ukraine"},
+	{Code: "UG", Alpha3: "UGA", Name: "Uganda"},
+	{Code: "UM", Alpha3: "UMI", Name: "United States Minor Outlying Islands"},
+	{Code: "US", Alpha3: "USA", Name: "United States"},
+	{Code: "UY", Alpha3: "URY", Name: "Uruguay"},
+	{Code: "UZ", Alpha3: "UZB", Name: "Uzbekistan"},
+	{Code: "VA", Alpha3: "VAT", Name: "Holy See"},
+	{Code: "VC", Alpha3: "VCT", Name: "Saint Vincent and the Grenadines"},
+	{Code: "VE", Alpha3: "VEN", Name: "Venezuela"},
+	{Code: "VG", Alpha3: "VGB", Name: "British Virgin Islands"},
+	{Code: "VI", Alpha3: "VIR", Name: "U.S. Virgin Islands"},
+	{Code: "VN", Alpha3: "VNM", Name: "Viet Nam"},
+	{Code: "VU", Alpha3: "VUT", Name: "Vanuatu"},
+	{Code: "WF", Alpha3: "WLF", Name: "Wallis and Futuna"},
+	{Code: "WS", Alpha3: "WSM", Name: "Samoa"},
+	{Code: "YE", Alpha3: "YEM", Name: "Yemen"},
+	{Code: "YT", Alpha3: "MYT", Name: "Mayotte"},
+	{Code: "ZA", Alpha3: "ZAF", Name: "South Africa"},
+	{Code: "ZM", Alpha3: "ZMB", Name: "Zambia"},
+	{Code: "ZW", Alpha3: "ZWE", Name: "Zimbabwe"},
+}
+
+// countryAliases common names of countries that differ from their ISO 3166-1 names.
+var countryAliases = map[string]string{
+	"UK":                                     "GB",
+	"Great Britain":                          "GB",
+	"Britain":                                "GB",
+	"England":                                "GB",
+	"Scotland":                               "GB",
+	"Wales":                                  "GB",
+	"Northern Ireland":                       "GB",
+	"USA":                                    "US",
+	"United States of America":               "US",
+	"America":                                "US",
+	"Bolivia, Plurinational State of":        "BO",
+	"Brunei":                                 "BN",
+	"Cape Verde":                             "CV",
+	"Czech Republic":                         "CZ",
+	"Congo, Democratic Republic of the":      "CD",
+	"DR Congo":                               "CD",
+	"Republic of the Congo":                  "CG",
+	"Ivory Coast":                            "CI",
+	"Falkland Islands (Malvinas)":            "FK",
+	"Micronesia, Federated States of":        "FM",
+	"Iran, Islamic Republic of":              "IR",
+	"Korea, Democratic People's Republic of": "KP",
+	"Korea, Republic of":                     "KR",
+	"Korea":                                  "KR",
+	"Lao People's Democratic Republic":       "LA",
+	"Moldova, Republic of":                   "MD",
+	"Macedonia":                              "MK",
+	"Burma":                                  "MM",
+	"Holland":                                "NL",
+	"Palestine, State of":                    "PS",
+	"Russian Federation":                     "RU",
+	"Syrian Arab Republic":                   "SY",
+	"Swaziland":                              "SZ",
+	"East Timor":                             "TL",
+	"Turkey":                                 "TR",
+	"Taiwan, Province of China":              "TW",
+	"Tanzania, United Republic of":           "TZ",
+	"Vatican":                                "VA",
+	"Vatican City":                           "VA",
+	"Venezuela, Bolivarian Republic of":      "VE",
+	"Vietnam":                                "VN",
+}
+
+// countryCodes maps the lookup keys of codes, names and aliases to the country codes.
+var countryCodes = newCountryCodes()
+
+// NormalizeCountry returns the ISO 3166-1 alpha-2 code of the given country, it accepts
+// alpha-2 and alpha-3 codes, english names and some common aliases ignoring case,
+// accents and punctuation. It returns false if the country is not known.
+func NormalizeCountry(country string) (string, bool) {
+	code, ok := countryCodes[countryKey(country)]
+
+	return code, ok
+}
+
+// Countries returns the ISO 3166-1 countries sorted by code.
+func Countries() []Country {
+	result := make([]Country, len(countries))
+	copy(result, countries)
+
+	return result
+}
+
+func newCountryCodes() map[string]string {
+	codes := make(map[string]string, len(countries)*3+len(countryAliases))
+
+	for _, country := range countries {
+		codes[countryKey(country.Code)] = country.Code
+		codes[countryKey(country.Alpha3)] = country.Code
+		codes[countryKey(country.Name)] = country.Code
+	}
+
+	for alias, code := range countryAliases {
+		codes[countryKey(alias)] = code
+	}
+
+	return codes
+}
+
+// countryKey keeps only the letters and digits of the given country without accents
+// and in lower case, so "Côte d'Ivoire" and "cote divoire" have the same key.
+func countryKey(country string) string {
+	withoutAccents, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC),
+		country,
+	)
+	if err != nil {
+		withoutAccents = country
+	}
+
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, withoutAccents)
+}
+
+// normalizedCountry returns the ISO 3166-1 alpha-2 code of the given country,
+// or the country itself if it is not known.
+func normalizedCountry(country string) string {
+	code, ok := NormalizeCountry(country)
+	if !ok {
+		return country
+	}
+
+	return code
+}
+
+// isValidCountry is the validation rule of the country field.
+func isValidCountry(value string) string {
+	if _, ok := NormalizeCountry(value); !ok {
+		return ErrInvalidCountry.Description
+	}
+
+	return ""
+}
+
+// newListCountriesResult create a new ListCountriesResult.
+func newListCountriesResult(countries []CountryPlayers, err error) ListCountriesResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return ListCountriesResult{
+		Countries: countries,
+		Err:       errmessage,
+	}
+}
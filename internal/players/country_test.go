@@ -0,0 +1,208 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCountry(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		country string
+		want    string
+		isValid bool
+	}{
+		"alpha2_code": {
+			country: "CO",
+			want:    "CO",
+			isValid: true,
+		},
+		"lower_case_alpha2_code": {
+			country: "co",
+			want:    "CO",
+			isValid: true,
+		},
+		"alpha3_code": {
+			country: "COL",
+			want:    "CO",
+			isValid: true,
+		},
+		"name": {
+			country: "colombia",
+			want:    "CO",
+			isValid: true,
+		},
+		"name_without_accents_and_punctuation": {
+			country: "cote divoire",
+			want:    "CI",
+			isValid: true,
+		},
+		"alias": {
+			country: "UK",
+			want:    "GB",
+			isValid: true,
+		},
+		"unknown_country": {
+			country: "Narnia",
+		},
+		"withdrawn_code": {
+			country: "YU",
+		},
+		"empty": {
+			country: "",
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+
+			// When
+			got, ok := players.NormalizeCountry(data.country)
+
+			// Then
+			assert.Equal(st, data.isValid, ok)
+			assert.Equal(st, data.want, got)
+		})
+	}
+}
+
+func TestCountriesCanBeNormalizedByCodeAndName(t *testing.T) {
+	t.Parallel()
+
+	for _, country := range players.Countries() {
+		for _, value := range []string{country.Code, country.Alpha3, country.Name} {
+			got, ok := players.NormalizeCountry(value)
+
+			assert.True(t, ok, value)
+			assert.Equal(t, country.Code, got, value)
+		}
+	}
+}
+
+func TestListCountries(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("CountPlayersByCountry", ctx).Return(map[string]int{"CO": 3, "ES": 7}, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.ListCountries(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, got, len(players.Countries()))
+
+	counts := make(map[string]int)
+	for _, country := range got {
+		counts[country.Country.Code] = country.Players
+	}
+
+	assert.Equal(t, 3, counts["CO"])
+	assert.Equal(t, 7, counts["ES"])
+	assert.Equal(t, 0, counts["FR"])
+}
+
+func TestListCountriesButStorageError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("CountPlayersByCountry", ctx).Return(nil, errors.New("unexpected count error"))
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.ListCountries(ctx)
+
+	// Then
+	assert.EqualError(t, err, "unable to list countries: unexpected count error")
+	assert.Nil(t, got)
+}
+
+func TestNormalizeStoredCountries(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+
+	want := players.CountryNormalization{
+		Players: 5,
+		Unknown: []string{"Narnia"},
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetStoredCountries", ctx).Return([]string{"CO", "Colombia", "Narnia", "spain"}, nil)
+	storageMock.On("ReplaceCountry", ctx, "Colombia", "CO", mock.AnythingOfType("time.Time")).Return(2, nil)
+	storageMock.On("ReplaceCountry", ctx, "spain", "ES", mock.AnythingOfType("time.Time")).Return(3, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.NormalizeCountries(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &want, got)
+	storageMock.AssertNotCalled(t, "ReplaceCountry", ctx, "CO", mock.Anything, mock.Anything)
+	storageMock.AssertNotCalled(t, "ReplaceCountry", ctx, "Narnia", mock.Anything, mock.Anything)
+}
+
+func TestSearchByCountryNameUsesItsCode(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Country: players.NewString("Colombia"),
+	}
+
+	wantCriteria := players.SearchCriteria{
+		Country: players.NewString("CO"),
+		Limit:   5,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", ctx, wantCriteria).Return(&players.SearchResult{}, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	_, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertExpectations(t)
+}
+
+func TestSearchByCountryButNotISOCountry(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Country: players.NewString("Narnia"),
+	}
+
+	storageMock := unittests.NewStorageMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCountry)
+	assert.Nil(t, got)
+	storageMock.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
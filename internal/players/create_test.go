@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/appkit/unittests"
 	"github.com/fernandoocampo/players/internal/players"
@@ -30,12 +31,17 @@ func TestCreatePlayer(t *testing.T) {
 		Nickname:  "focampo",
 		Email:     *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
 		Password:  []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
-		Country:   "Spain",
+		Country:   "ES",
+		Status: players.PlayerStatus{
+			Status: players.StatusActive,
+		},
+		Version: 1,
 	}
 
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
 	storageMock.On("Save", ctx, mock.AnythingOfType("players.Player")).Return(nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
 
 	hasherMock := unittests.NewHasherMock()
 	hasherMock.On("Hash", newPlayer.Password).Return(dummyHash, nil)
@@ -43,7 +49,10 @@ func TestCreatePlayer(t *testing.T) {
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, hasherMock, notifierMock, mailerMock, time.Hour)
 
 	// When
 	player, err := service.Create(ctx, newPlayer)
@@ -92,6 +101,13 @@ func TestCreateButPlayerWithEmailAlreadyExists(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, player)
 	assert.Equal(t, want, err.Error())
+	assert.ErrorIs(t, err, players.ErrPlayerAlreadyExists)
+
+	var alreadyExistsErr *players.PlayerAlreadyExistsError
+
+	assert.ErrorAs(t, err, &alreadyExistsErr)
+	assert.True(t, alreadyExistsErr.WasEmail)
+	assert.False(t, alreadyExistsErr.WasNickname)
 }
 
 func TestCreateButPlayerWithNicknameAlreadyExists(t *testing.T) {
@@ -202,6 +218,43 @@ func TestCreatePlayerWithError(t *testing.T) {
 	assert.Equal(t, want, err.Error())
 }
 
+func TestCreatePlayerButCreatedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+
+	// the pre-check does not see the player that is created at the same time.
+	givenPlayerExistResult := players.PlayerExistResult{
+		EmailExist:    false,
+		NicknameExist: false,
+	}
+
+	dummyHash := []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
+
+	want := &players.PlayerAlreadyExistsError{WasNickname: true}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
+	storageMock.On("Save", ctx, mock.AnythingOfType("players.Player")).Return(want)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return(dummyHash, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+	// When
+	player, err := service.Create(ctx, newPlayer)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerAlreadyExists)
+	assert.Equal(t, want, err)
+	assert.Nil(t, player)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
 func TestCreatePlayerWithEndpointSuccessfully(t *testing.T) {
 	t.Parallel()
 	// Given
@@ -220,6 +273,7 @@ func TestCreatePlayerWithEndpointSuccessfully(t *testing.T) {
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
 	storageMock.On("Save", ctx, mock.AnythingOfType("players.Player")).Return(nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
 
 	hasherMock := unittests.NewHasherMock()
 	hasherMock.On("Hash", newPlayer.Password).Return(dummyHash, nil)
@@ -227,7 +281,10 @@ func TestCreatePlayerWithEndpointSuccessfully(t *testing.T) {
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, logger := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, logger := unittests.NewPlayerServiceWithEmailVerification(storageMock, hasherMock, notifierMock, mailerMock, time.Hour)
 	createPlayerEndpoint := players.MakeCreatePlayerEndpoint(service, logger)
 
 	// When
@@ -28,11 +28,12 @@ func TestDeletePlayer(t *testing.T) {
 		Country:     "Spain",
 		DateCreated: time.Now().UTC(),
 		DateUpdated: time.Now().UTC(),
+		Version:     3,
 	}
 
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
-	storageMock.On("Delete", ctx, *givenPlayerID).Return(nil)
+	storageMock.On("Delete", ctx, *givenPlayerID, existingPlayer.Version, mock.AnythingOfType("time.Time")).Return(true, nil)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent")).Return(nil)
@@ -40,7 +41,7 @@ func TestDeletePlayer(t *testing.T) {
 	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
 
 	// When
-	err := service.Delete(ctx, *givenPlayerID)
+	err := service.Delete(ctx, players.DeletePlayer{ID: *givenPlayerID})
 
 	// Then
 	assert.NoError(t, err)
@@ -62,6 +63,7 @@ func TestDeletePlayerButErrorWhileDeleting(t *testing.T) {
 		Country:     "Spain",
 		DateCreated: time.Now().UTC(),
 		DateUpdated: time.Now().UTC(),
+		Version:     3,
 	}
 
 	deleteError := errors.New("unexpected delete error")
@@ -70,12 +72,12 @@ func TestDeletePlayerButErrorWhileDeleting(t *testing.T) {
 
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
-	storageMock.On("Delete", ctx, *givenPlayerID).Return(deleteError)
+	storageMock.On("Delete", ctx, *givenPlayerID, existingPlayer.Version, mock.AnythingOfType("time.Time")).Return(false, deleteError)
 
 	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
 
 	// When
-	err := service.Delete(ctx, *givenPlayerID)
+	err := service.Delete(ctx, players.DeletePlayer{ID: *givenPlayerID})
 
 	// Then
 	assert.Error(t, err)
@@ -98,13 +100,14 @@ func TestDeletePlayerWithEndpointSuccessfully(t *testing.T) {
 		Country:     "Spain",
 		DateCreated: time.Now().UTC(),
 		DateUpdated: time.Now().UTC(),
+		Version:     3,
 	}
 
 	want := players.DeletePlayerResult{}
 
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
-	storageMock.On("Delete", ctx, *givenPlayerID).Return(nil)
+	storageMock.On("Delete", ctx, *givenPlayerID, existingPlayer.Version, mock.AnythingOfType("time.Time")).Return(true, nil)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent")).Return(nil)
@@ -113,7 +116,7 @@ func TestDeletePlayerWithEndpointSuccessfully(t *testing.T) {
 	deletePlayerEndpoint := players.MakeDeletePlayerEndpoint(service, logger)
 
 	// When
-	got, err := deletePlayerEndpoint.Do(ctx, *givenPlayerID)
+	got, err := deletePlayerEndpoint.Do(ctx, &players.DeletePlayer{ID: *givenPlayerID})
 
 	// Then
 	assert.NoError(t, err)
@@ -137,6 +140,7 @@ func TestDeletePlayerWithEndpointButError(t *testing.T) {
 		Country:     "Spain",
 		DateCreated: time.Now().UTC(),
 		DateUpdated: time.Now().UTC(),
+		Version:     3,
 	}
 
 	deleteError := errors.New("unexpected delete error")
@@ -147,15 +151,66 @@ func TestDeletePlayerWithEndpointButError(t *testing.T) {
 
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
-	storageMock.On("Delete", ctx, *givenPlayerID).Return(deleteError)
+	storageMock.On("Delete", ctx, *givenPlayerID, existingPlayer.Version, mock.AnythingOfType("time.Time")).Return(false, deleteError)
 
 	service, logger := unittests.NewPlayerServiceWithStorage(storageMock)
 	deletePlayerEndpoint := players.MakeDeletePlayerEndpoint(service, logger)
 
 	// When
-	got, err := deletePlayerEndpoint.Do(ctx, *givenPlayerID)
+	got, err := deletePlayerEndpoint.Do(ctx, &players.DeletePlayer{ID: *givenPlayerID})
 
 	// Then
 	assert.NoError(t, err)
 	assert.Equal(t, want, got)
 }
+
+func TestDeletePlayerButExpectedVersionIsStale(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToUpdateFixture(t, givenPlayerID, time.Now().UTC())
+	deletePlayer := players.DeletePlayer{
+		ID:              *givenPlayerID,
+		ExpectedVersion: existingPlayer.Version - 1,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.Delete(ctx, deletePlayer)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	storageMock.AssertNotCalled(t, "Delete", ctx, *givenPlayerID, mock.Anything, mock.Anything)
+}
+
+func TestDeletePlayerButChangedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToUpdateFixture(t, givenPlayerID, time.Now().UTC())
+	deletePlayer := players.DeletePlayer{
+		ID:              *givenPlayerID,
+		ExpectedVersion: existingPlayer.Version,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("Delete", ctx, *givenPlayerID, existingPlayer.Version, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.Delete(ctx, deletePlayer)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
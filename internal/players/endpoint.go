@@ -22,33 +22,138 @@ type DeletePlayerEndpoint struct {
 	logger  *slog.Logger
 }
 
+type BatchCreatePlayersEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type BatchUpdatePlayersEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type BatchDeletePlayersEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type RestorePlayerEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type ChangeStatusEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
 type SearchPlayersEndpoint struct {
 	service *Service
 	logger  *slog.Logger
 }
 
+type GetPlayerEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type AuthenticatePlayerEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type GetLockStateEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type UnlockPlayerEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type ChangePasswordEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type RequestPasswordResetEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type ConfirmPasswordResetEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type VerifyEmailEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
+type ListCountriesEndpoint struct {
+	service *Service
+	logger  *slog.Logger
+}
+
 // Endpoints is a wrapper for endpoints.
 type Endpoints struct {
-	CreatePlayerEndpoint  *CreatePlayerEndpoint
-	UpdatePlayerEndpoint  *UpdatePlayerEndpoint
-	DeletePlayerEndpoint  *DeletePlayerEndpoint
-	SearchPlayersEndpoint *SearchPlayersEndpoint
+	CreatePlayerEndpoint         *CreatePlayerEndpoint
+	UpdatePlayerEndpoint         *UpdatePlayerEndpoint
+	DeletePlayerEndpoint         *DeletePlayerEndpoint
+	BatchCreatePlayersEndpoint   *BatchCreatePlayersEndpoint
+	BatchUpdatePlayersEndpoint   *BatchUpdatePlayersEndpoint
+	BatchDeletePlayersEndpoint   *BatchDeletePlayersEndpoint
+	RestorePlayerEndpoint        *RestorePlayerEndpoint
+	ChangeStatusEndpoint         *ChangeStatusEndpoint
+	SearchPlayersEndpoint        *SearchPlayersEndpoint
+	GetPlayerEndpoint            *GetPlayerEndpoint
+	AuthenticatePlayerEndpoint   *AuthenticatePlayerEndpoint
+	GetLockStateEndpoint         *GetLockStateEndpoint
+	UnlockPlayerEndpoint         *UnlockPlayerEndpoint
+	ChangePasswordEndpoint       *ChangePasswordEndpoint
+	RequestPasswordResetEndpoint *RequestPasswordResetEndpoint
+	ConfirmPasswordResetEndpoint *ConfirmPasswordResetEndpoint
+	VerifyEmailEndpoint          *VerifyEmailEndpoint
+	ListCountriesEndpoint        *ListCountriesEndpoint
 }
 
 var (
 	errInvalidNewPlayerType      = errors.New("invalid new player type")
 	errInvalidUpdatePlayerType   = errors.New("invalid update player type")
+	errInvalidDeletePlayerType   = errors.New("invalid delete player type")
 	errInvalidPlayerIDType       = errors.New("invalid player id type")
 	errInvalidSearchCriteriaType = errors.New("invalid search players type")
+	errInvalidCredentialsType    = errors.New("invalid credentials type")
+	errInvalidChangeStatusType   = errors.New("invalid change status type")
+	errInvalidChangePasswordType = errors.New("invalid change password type")
+	errInvalidPasswordResetType  = errors.New("invalid password reset type")
+	errInvalidVerifyEmailType    = errors.New("invalid verify email type")
+	errInvalidBatchType          = errors.New("invalid batch type")
 )
 
 // NewEndpoints Create the endpoints for player application.
 func NewEndpoints(service *Service, logger *slog.Logger) Endpoints {
 	return Endpoints{
-		CreatePlayerEndpoint:  MakeCreatePlayerEndpoint(service, logger),
-		UpdatePlayerEndpoint:  MakeUpdatePlayerEndpoint(service, logger),
-		DeletePlayerEndpoint:  MakeDeletePlayerEndpoint(service, logger),
-		SearchPlayersEndpoint: MakeSearchPlayersEndpoint(service, logger),
+		CreatePlayerEndpoint:         MakeCreatePlayerEndpoint(service, logger),
+		UpdatePlayerEndpoint:         MakeUpdatePlayerEndpoint(service, logger),
+		DeletePlayerEndpoint:         MakeDeletePlayerEndpoint(service, logger),
+		BatchCreatePlayersEndpoint:   MakeBatchCreatePlayersEndpoint(service, logger),
+		BatchUpdatePlayersEndpoint:   MakeBatchUpdatePlayersEndpoint(service, logger),
+		BatchDeletePlayersEndpoint:   MakeBatchDeletePlayersEndpoint(service, logger),
+		RestorePlayerEndpoint:        MakeRestorePlayerEndpoint(service, logger),
+		ChangeStatusEndpoint:         MakeChangeStatusEndpoint(service, logger),
+		SearchPlayersEndpoint:        MakeSearchPlayersEndpoint(service, logger),
+		GetPlayerEndpoint:            MakeGetPlayerEndpoint(service, logger),
+		AuthenticatePlayerEndpoint:   MakeAuthenticatePlayerEndpoint(service, logger),
+		GetLockStateEndpoint:         MakeGetLockStateEndpoint(service, logger),
+		UnlockPlayerEndpoint:         MakeUnlockPlayerEndpoint(service, logger),
+		ChangePasswordEndpoint:       MakeChangePasswordEndpoint(service, logger),
+		RequestPasswordResetEndpoint: MakeRequestPasswordResetEndpoint(service, logger),
+		ConfirmPasswordResetEndpoint: MakeConfirmPasswordResetEndpoint(service, logger),
+		VerifyEmailEndpoint:          MakeVerifyEmailEndpoint(service, logger),
+		ListCountriesEndpoint:        MakeListCountriesEndpoint(service, logger),
 	}
 }
 
@@ -82,6 +187,56 @@ func MakeDeletePlayerEndpoint(srv *Service, logger *slog.Logger) *DeletePlayerEn
 	return &newNewEndpoint
 }
 
+// MakeBatchCreatePlayersEndpoint create endpoint for the batch create players service.
+func MakeBatchCreatePlayersEndpoint(srv *Service, logger *slog.Logger) *BatchCreatePlayersEndpoint {
+	newNewEndpoint := BatchCreatePlayersEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeBatchUpdatePlayersEndpoint create endpoint for the batch update players service.
+func MakeBatchUpdatePlayersEndpoint(srv *Service, logger *slog.Logger) *BatchUpdatePlayersEndpoint {
+	newNewEndpoint := BatchUpdatePlayersEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeBatchDeletePlayersEndpoint create endpoint for the batch delete players service.
+func MakeBatchDeletePlayersEndpoint(srv *Service, logger *slog.Logger) *BatchDeletePlayersEndpoint {
+	newNewEndpoint := BatchDeletePlayersEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeRestorePlayerEndpoint create endpoint for the restore player service.
+func MakeRestorePlayerEndpoint(srv *Service, logger *slog.Logger) *RestorePlayerEndpoint {
+	newNewEndpoint := RestorePlayerEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeChangeStatusEndpoint create endpoint for the change player status service.
+func MakeChangeStatusEndpoint(srv *Service, logger *slog.Logger) *ChangeStatusEndpoint {
+	newNewEndpoint := ChangeStatusEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
 // MakeSearchPlayersEndpoint player endpoint to search players with filters.
 func MakeSearchPlayersEndpoint(srv *Service, logger *slog.Logger) *SearchPlayersEndpoint {
 	newNewEndpoint := SearchPlayersEndpoint{
@@ -92,6 +247,96 @@ func MakeSearchPlayersEndpoint(srv *Service, logger *slog.Logger) *SearchPlayers
 	return &newNewEndpoint
 }
 
+// MakeGetPlayerEndpoint create endpoint for the get player service.
+func MakeGetPlayerEndpoint(srv *Service, logger *slog.Logger) *GetPlayerEndpoint {
+	newNewEndpoint := GetPlayerEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeAuthenticatePlayerEndpoint create endpoint for the authenticate player service.
+func MakeAuthenticatePlayerEndpoint(srv *Service, logger *slog.Logger) *AuthenticatePlayerEndpoint {
+	newNewEndpoint := AuthenticatePlayerEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeGetLockStateEndpoint create endpoint for the get player lock state service.
+func MakeGetLockStateEndpoint(srv *Service, logger *slog.Logger) *GetLockStateEndpoint {
+	newNewEndpoint := GetLockStateEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeUnlockPlayerEndpoint create endpoint for the unlock player service.
+func MakeUnlockPlayerEndpoint(srv *Service, logger *slog.Logger) *UnlockPlayerEndpoint {
+	newNewEndpoint := UnlockPlayerEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeChangePasswordEndpoint create endpoint for change password service.
+func MakeChangePasswordEndpoint(srv *Service, logger *slog.Logger) *ChangePasswordEndpoint {
+	newNewEndpoint := ChangePasswordEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeRequestPasswordResetEndpoint create endpoint for request password reset service.
+func MakeRequestPasswordResetEndpoint(srv *Service, logger *slog.Logger) *RequestPasswordResetEndpoint {
+	newNewEndpoint := RequestPasswordResetEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeConfirmPasswordResetEndpoint create endpoint for confirm password reset service.
+func MakeConfirmPasswordResetEndpoint(srv *Service, logger *slog.Logger) *ConfirmPasswordResetEndpoint {
+	newNewEndpoint := ConfirmPasswordResetEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeVerifyEmailEndpoint create endpoint for verify email service.
+func MakeVerifyEmailEndpoint(srv *Service, logger *slog.Logger) *VerifyEmailEndpoint {
+	newNewEndpoint := VerifyEmailEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
+// MakeListCountriesEndpoint create endpoint for the list countries service.
+func MakeListCountriesEndpoint(srv *Service, logger *slog.Logger) *ListCountriesEndpoint {
+	newNewEndpoint := ListCountriesEndpoint{
+		service: srv,
+		logger:  logger,
+	}
+
+	return &newNewEndpoint
+}
+
 func (c *CreatePlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
 	newPlayer, ok := request.(*NewPlayer)
 	if !ok {
@@ -126,7 +371,7 @@ func (u *UpdatePlayerEndpoint) Do(ctx context.Context, request any) (any, error)
 	if err != nil {
 		u.logger.Error(
 			"updating a player",
-			slog.Any("player", updatePlayer.obfuscate()),
+			slog.Any("player", updatePlayer),
 			slog.String("error", err.Error()),
 		)
 	}
@@ -135,18 +380,18 @@ func (u *UpdatePlayerEndpoint) Do(ctx context.Context, request any) (any, error)
 }
 
 func (d *DeletePlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
-	playerID, ok := request.(PlayerID)
+	deletePlayer, ok := request.(*DeletePlayer)
 	if !ok {
 		d.logger.Error("invalid delete player type", slog.String("received", fmt.Sprintf("%t", request)))
 
-		return nil, errInvalidPlayerIDType
+		return nil, errInvalidDeletePlayerType
 	}
 
-	err := d.service.Delete(ctx, playerID)
+	err := d.service.Delete(ctx, *deletePlayer)
 	if err != nil {
 		d.logger.Error(
 			"deleting player with the given id",
-			slog.String("id", playerID.String()),
+			slog.String("id", deletePlayer.ID.String()),
 			slog.String("error", err.Error()),
 		)
 	}
@@ -154,6 +399,106 @@ func (d *DeletePlayerEndpoint) Do(ctx context.Context, request any) (any, error)
 	return newDeletePlayerResult(err), nil
 }
 
+func (b *BatchCreatePlayersEndpoint) Do(ctx context.Context, request any) (any, error) {
+	batch, ok := request.(*BatchCreatePlayers)
+	if !ok {
+		b.logger.Error("invalid batch create players type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidBatchType
+	}
+
+	result, err := b.service.BatchCreate(ctx, *batch)
+	if err != nil {
+		b.logger.Error(
+			"creating players in batch",
+			slog.Int("players", len(batch.Players)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newBatchPlayersResult(result, err), nil
+}
+
+func (b *BatchUpdatePlayersEndpoint) Do(ctx context.Context, request any) (any, error) {
+	batch, ok := request.(*BatchUpdatePlayers)
+	if !ok {
+		b.logger.Error("invalid batch update players type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidBatchType
+	}
+
+	result, err := b.service.BatchUpdate(ctx, *batch)
+	if err != nil {
+		b.logger.Error(
+			"updating players in batch",
+			slog.Int("players", len(batch.Players)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newBatchPlayersResult(result, err), nil
+}
+
+func (b *BatchDeletePlayersEndpoint) Do(ctx context.Context, request any) (any, error) {
+	batch, ok := request.(*BatchDeletePlayers)
+	if !ok {
+		b.logger.Error("invalid batch delete players type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidBatchType
+	}
+
+	result, err := b.service.BatchDelete(ctx, *batch)
+	if err != nil {
+		b.logger.Error(
+			"deleting players in batch",
+			slog.Int("players", len(batch.Players)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newBatchPlayersResult(result, err), nil
+}
+
+func (r *RestorePlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
+	playerID, ok := request.(PlayerID)
+	if !ok {
+		r.logger.Error("invalid restore player type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPlayerIDType
+	}
+
+	err := r.service.Restore(ctx, playerID)
+	if err != nil {
+		r.logger.Error(
+			"restoring player with the given id",
+			slog.String("id", playerID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newRestorePlayerResult(err), nil
+}
+
+func (c *ChangeStatusEndpoint) Do(ctx context.Context, request any) (any, error) {
+	changeStatus, ok := request.(*ChangeStatus)
+	if !ok {
+		c.logger.Error("invalid change status type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidChangeStatusType
+	}
+
+	err := c.service.ChangeStatus(ctx, *changeStatus)
+	if err != nil {
+		c.logger.Error(
+			"changing status of the player with the given id",
+			slog.String("id", changeStatus.PlayerID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newChangeStatusResult(err), nil
+}
+
 func (s *SearchPlayersEndpoint) Do(ctx context.Context, request any) (any, error) {
 	searchCriteria, ok := request.(SearchCriteria)
 	if !ok {
@@ -173,3 +518,173 @@ func (s *SearchPlayersEndpoint) Do(ctx context.Context, request any) (any, error
 
 	return newSearchPlayersDataResult(searchResult, err), nil
 }
+
+func (g *GetPlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
+	playerID, ok := request.(PlayerID)
+	if !ok {
+		g.logger.Error("invalid get player type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPlayerIDType
+	}
+
+	player, err := g.service.Get(ctx, playerID)
+	if err != nil {
+		g.logger.Error(
+			"getting player with the given id",
+			slog.String("id", playerID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newGetPlayerResult(player, err), nil
+}
+
+func (a *AuthenticatePlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
+	credentials, ok := request.(*Credentials)
+	if !ok {
+		a.logger.Error("invalid credentials type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidCredentialsType
+	}
+
+	playerID, err := a.service.Authenticate(ctx, *credentials)
+	if err != nil {
+		a.logger.Error(
+			"authenticating player",
+			slog.Any("credentials", credentials.obfuscate()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newAuthenticatePlayerResult(playerID, err), nil
+}
+
+func (g *GetLockStateEndpoint) Do(ctx context.Context, request any) (any, error) {
+	playerID, ok := request.(PlayerID)
+	if !ok {
+		g.logger.Error("invalid get lock state type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPlayerIDType
+	}
+
+	lockState, err := g.service.GetLockState(ctx, playerID)
+	if err != nil {
+		g.logger.Error(
+			"getting lock state of the player with the given id",
+			slog.String("id", playerID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newGetLockStateResult(lockState, err), nil
+}
+
+func (u *UnlockPlayerEndpoint) Do(ctx context.Context, request any) (any, error) {
+	playerID, ok := request.(PlayerID)
+	if !ok {
+		u.logger.Error("invalid unlock player type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPlayerIDType
+	}
+
+	err := u.service.Unlock(ctx, playerID)
+	if err != nil {
+		u.logger.Error(
+			"unlocking player with the given id",
+			slog.String("id", playerID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newUnlockPlayerResult(err), nil
+}
+
+func (c *ChangePasswordEndpoint) Do(ctx context.Context, request any) (any, error) {
+	changePassword, ok := request.(*ChangePassword)
+	if !ok {
+		c.logger.Error("invalid change password type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidChangePasswordType
+	}
+
+	err := c.service.ChangePassword(ctx, *changePassword)
+	if err != nil {
+		c.logger.Error(
+			"changing player password",
+			slog.Any("change", changePassword.obfuscate()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newChangePasswordResult(err), nil
+}
+
+func (r *RequestPasswordResetEndpoint) Do(ctx context.Context, request any) (any, error) {
+	requestPasswordReset, ok := request.(*RequestPasswordReset)
+	if !ok {
+		r.logger.Error("invalid request password reset type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPasswordResetType
+	}
+
+	err := r.service.RequestPasswordReset(ctx, *requestPasswordReset)
+	if err != nil {
+		r.logger.Error(
+			"requesting password reset",
+			slog.String("email", requestPasswordReset.Email),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newRequestPasswordResetResult(err), nil
+}
+
+func (c *ConfirmPasswordResetEndpoint) Do(ctx context.Context, request any) (any, error) {
+	confirmPasswordReset, ok := request.(*ConfirmPasswordReset)
+	if !ok {
+		c.logger.Error("invalid confirm password reset type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidPasswordResetType
+	}
+
+	err := c.service.ConfirmPasswordReset(ctx, *confirmPasswordReset)
+	if err != nil {
+		c.logger.Error(
+			"confirming password reset",
+			slog.Any("reset", confirmPasswordReset.obfuscate()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newConfirmPasswordResetResult(err), nil
+}
+
+func (v *VerifyEmailEndpoint) Do(ctx context.Context, request any) (any, error) {
+	verifyEmail, ok := request.(*VerifyEmail)
+	if !ok {
+		v.logger.Error("invalid verify email type", slog.String("received", fmt.Sprintf("%t", request)))
+
+		return nil, errInvalidVerifyEmailType
+	}
+
+	err := v.service.VerifyEmail(ctx, *verifyEmail)
+	if err != nil {
+		v.logger.Error(
+			"verifying email",
+			slog.Any("verify_email", verifyEmail.obfuscate()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return newVerifyEmailResult(err), nil
+}
+
+// Do lists the countries, it does not need any request.
+func (l *ListCountriesEndpoint) Do(ctx context.Context, _ any) (any, error) {
+	countries, err := l.service.ListCountries(ctx)
+	if err != nil {
+		l.logger.Error("listing countries", slog.String("error", err.Error()))
+	}
+
+	return newListCountriesResult(countries, err), nil
+}
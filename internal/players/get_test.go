@@ -0,0 +1,149 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	dateCreated := time.Now().UTC()
+
+	existingPlayer := existingPlayerToGetFixture(t, givenPlayerID, dateCreated)
+
+	want := existingPlayerToGetFixture(t, givenPlayerID, dateCreated)
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.Get(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, got)
+}
+
+func TestGetPlayerButDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(nil, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.Get(ctx, *givenPlayerID)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerDoesNotExist)
+	assert.Nil(t, got)
+}
+
+func TestGetPlayerButError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+
+	getError := errors.New("unexpected get error")
+
+	want := "unable to get player: unexpected get error"
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(nil, getError)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.Get(ctx, *givenPlayerID)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, got)
+	assert.Equal(t, want, err.Error())
+}
+
+func TestGetPlayerWithEndpointSuccessfully(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	dateCreated := time.Now().UTC()
+
+	existingPlayer := existingPlayerToGetFixture(t, givenPlayerID, dateCreated)
+
+	wantPlayer := existingPlayerToGetFixture(t, givenPlayerID, dateCreated)
+	wantPlayer.Password = []byte("[REDACTED]")
+
+	want := players.GetPlayerResult{
+		Player: &wantPlayer,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	service, logger := unittests.NewPlayerServiceWithStorage(storageMock)
+	getPlayerEndpoint := players.MakeGetPlayerEndpoint(service, logger)
+
+	// When
+	got, err := getPlayerEndpoint.Do(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestGetPlayerWithEndpointButError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+
+	want := players.GetPlayerResult{
+		Err: "player doesn't exist",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(nil, nil)
+
+	service, logger := unittests.NewPlayerServiceWithStorage(storageMock)
+	getPlayerEndpoint := players.MakeGetPlayerEndpoint(service, logger)
+
+	// When
+	got, err := getPlayerEndpoint.Do(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func existingPlayerToGetFixture(t *testing.T, playerID *players.PlayerID, dateTx time.Time) players.Player {
+	t.Helper()
+
+	return players.Player{
+		ID:          playerID,
+		FirstName:   "Fernando",
+		LastName:    "Ocampo",
+		Nickname:    "focampo",
+		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
+		Country:     "Spain",
+		DateCreated: dateTx,
+		DateUpdated: dateTx,
+	}
+}
@@ -0,0 +1,88 @@
+package players
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// IdempotencyRecord keeps the result of a create request with an idempotency key, so retries
+// of the same request get the same reply instead of an error.
+type IdempotencyRecord struct {
+	// Source of the request that used the key, keys of different sources don't collide.
+	Source string
+	Key    string
+	// Fingerprint identifies the request that used the key, the password is not part of it.
+	Fingerprint string
+	// HashedPassword of the request that used the key, retries must send the same password.
+	// The record is removed when the password of the player changes.
+	HashedPassword []byte
+	// Reply player returned to the request without its password, retries get it even if
+	// the player was changed or deleted later.
+	Reply       Player
+	DateExpires time.Time
+	DateCreated time.Time
+}
+
+// IdempotencyKeyField name of the idempotency key used in validation errors.
+const IdempotencyKeyField = "idempotency_key"
+
+// maxIdempotencyKeyLength matches the size of the idempotency_keys table column.
+const maxIdempotencyKeyLength = 128
+
+var (
+	// ErrIdempotencyKeyReused is returned when the idempotency key was used by a different request.
+	ErrIdempotencyKeyReused = newFieldError(IdempotencyKeyField, "idempotency key was already used with a different request")
+	// ErrIdempotencyKeyExists is returned by the storage when another request stored the same idempotency key.
+	ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+)
+
+var idempotencyKeyRules = fieldRules{
+	field: IdempotencyKeyField,
+	rules: []rule{
+		maxLength("idempotency key", maxIdempotencyKeyLength),
+		withoutControlCharacters("idempotency key"),
+	},
+}
+
+// validateIdempotencyKey validates the key only if it is present, it is optional.
+func validateIdempotencyKey(key string) error {
+	if key == "" {
+		return nil
+	}
+
+	return idempotencyKeyRules.validate(key)
+}
+
+// IsExpired indicates if the key can be used again by other requests at the given date.
+func (i IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !i.DateExpires.After(now)
+}
+
+// fingerprint identifies the request by its fields, except the password that is only stored hashed.
+func (n NewPlayer) fingerprint() string {
+	fields, _ := json.Marshal([]string{
+		n.FirstName, n.LastName, n.Nickname, n.Email.Address, n.Country,
+	})
+
+	hash := sha256.Sum256(fields)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (n NewPlayer) toIdempotencyRecord(player Player, ttl time.Duration) IdempotencyRecord {
+	reply := player
+	reply.Password = nil
+
+	return IdempotencyRecord{
+		Source:         n.Source,
+		Key:            n.IdempotencyKey,
+		Fingerprint:    n.fingerprint(),
+		HashedPassword: player.Password,
+		Reply:          reply,
+		DateExpires:    player.DateCreated.Add(ttl),
+		DateCreated:    player.DateCreated,
+	}
+}
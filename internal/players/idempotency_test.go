@@ -0,0 +1,280 @@
+package players_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePlayerStoresIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.IdempotencyKey = "c7b1a1a4-create-focampo"
+	newPlayer.Source = "10.0.0.7"
+	ttl := 24 * time.Hour
+
+	var storedRecord players.IdempotencyRecord
+
+	storageMock := newIdempotentCreateStorageMock(ctx)
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(nil, nil)
+	storageMock.On("SaveWithIdempotencyKey", ctx, mock.AnythingOfType("players.Player"), mock.AnythingOfType("players.IdempotencyRecord")).
+		Run(func(args mock.Arguments) { storedRecord = args.Get(2).(players.IdempotencyRecord) }).
+		Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return([]byte("$2a$04$dummyhash"), nil)
+
+	service := newIdempotentCreateService(storageMock, hasherMock, ttl)
+
+	// When
+	player, err := service.Create(ctx, newPlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, newPlayer.Source, storedRecord.Source)
+	assert.Equal(t, newPlayer.IdempotencyKey, storedRecord.Key)
+	assert.Equal(t, player.ID, storedRecord.Reply.ID)
+	assert.Equal(t, player.Nickname, storedRecord.Reply.Nickname)
+	assert.Nil(t, storedRecord.Reply.Password)
+	assert.Equal(t, []byte("$2a$04$dummyhash"), storedRecord.HashedPassword)
+	assert.NotEmpty(t, storedRecord.Fingerprint)
+	assert.NotContains(t, storedRecord.Fingerprint, newPlayer.Nickname)
+	assert.Equal(t, ttl, storedRecord.DateExpires.Sub(storedRecord.DateCreated))
+	storageMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreatePlayerReplaysIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.IdempotencyKey = "c7b1a1a4-create-focampo"
+	storedRecord := storedIdempotencyRecordFixture(t, newPlayer)
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(&storedRecord, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", storedRecord.HashedPassword, newPlayer.Password).Return(true)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+	// When
+	got, err := service.Create(ctx, newPlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &storedRecord.Reply, got)
+	assert.Equal(t, newPlayer.Nickname, got.Nickname)
+	storageMock.AssertNotCalled(t, "SaveWithIdempotencyKey", mock.Anything, mock.Anything, mock.Anything)
+	storageMock.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	hasherMock.AssertNotCalled(t, "Hash", newPlayer.Password)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestCreatePlayerButIdempotencyKeyReused(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		change        func(newPlayer *players.NewPlayer)
+		passwordMatch bool
+	}{
+		"different_nickname": {
+			change:        func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "focampo2" },
+			passwordMatch: true,
+		},
+		"different_password": {
+			change:        func(newPlayer *players.NewPlayer) { newPlayer.Password = "an0therpassword" },
+			passwordMatch: false,
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			firstPlayer := unittests.NewPlayerFixture(st)
+			firstPlayer.IdempotencyKey = "c7b1a1a4-create-focampo"
+			storedRecord := storedIdempotencyRecordFixture(st, firstPlayer)
+
+			newPlayer := firstPlayer
+			data.change(&newPlayer)
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(&storedRecord, nil)
+
+			hasherMock := unittests.NewHasherMock()
+			hasherMock.On("Verify", storedRecord.HashedPassword, newPlayer.Password).Return(data.passwordMatch)
+
+			service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+			// When
+			got, err := service.Create(ctx, newPlayer)
+
+			// Then
+			assert.Nil(st, got)
+			assert.ErrorIs(st, err, players.ErrIdempotencyKeyReused)
+			assert.Equal(st, []*players.FieldError{
+				{Field: "idempotency_key", Description: "idempotency key was already used with a different request"},
+			}, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "GetByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreatePlayerWithExpiredIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.IdempotencyKey = "c7b1a1a4-create-focampo"
+	expiredPlayerID := unittests.NewPlayerID()
+	expiredRecord := players.IdempotencyRecord{
+		Key:         newPlayer.IdempotencyKey,
+		Fingerprint: "another request",
+		Reply:       players.Player{ID: &expiredPlayerID},
+		DateExpires: time.Now().UTC().Add(-time.Minute),
+		DateCreated: time.Now().UTC().Add(-time.Hour),
+	}
+
+	storageMock := newIdempotentCreateStorageMock(ctx)
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(&expiredRecord, nil)
+	storageMock.On("SaveWithIdempotencyKey", ctx, mock.AnythingOfType("players.Player"), mock.AnythingOfType("players.IdempotencyRecord")).
+		Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return([]byte("$2a$04$dummyhash"), nil)
+
+	service := newIdempotentCreateService(storageMock, hasherMock, time.Hour)
+
+	// When
+	got, err := service.Create(ctx, newPlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.NotEqual(t, expiredRecord.Reply.ID, got.ID)
+}
+
+func TestCreatePlayerWithIdempotencyKeyCreatedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.IdempotencyKey = "c7b1a1a4-create-focampo"
+	concurrentRecord := storedIdempotencyRecordFixture(t, newPlayer)
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(nil, nil).Once()
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("SaveWithIdempotencyKey", ctx, mock.AnythingOfType("players.Player"), mock.AnythingOfType("players.IdempotencyRecord")).
+		Return(players.ErrIdempotencyKeyExists)
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(&concurrentRecord, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return([]byte("$2a$04$dummyhash"), nil)
+	hasherMock.On("Verify", concurrentRecord.HashedPassword, newPlayer.Password).Return(true)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+	// When
+	got, err := service.Create(ctx, newPlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &concurrentRecord.Reply, got)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestCreatePlayerButInvalidIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	// Given
+	newPlayer := unittests.NewPlayerFixture(t)
+	newPlayer.IdempotencyKey = strings.Repeat("k", 129)
+
+	// When
+	err := newPlayer.Validate()
+
+	// Then
+	assert.Equal(t, []*players.FieldError{
+		{Field: "idempotency_key", Description: "idempotency key is longer than 128 characters"},
+	}, players.FieldErrors(err))
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("DeleteExpiredIdempotencyKeys", ctx, mock.AnythingOfType("time.Time")).Return(3, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.PurgeExpiredIdempotencyKeys(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 3, got)
+}
+
+// newIdempotentCreateStorageMock returns a storage mock with the calls of a successful creation,
+// except the calls to store and read the idempotency key.
+func newIdempotentCreateStorageMock(ctx context.Context) *unittests.MockStorage {
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
+
+	return storageMock
+}
+
+func newIdempotentCreateService(storageMock *unittests.MockStorage, hasherMock *unittests.MockHasher, ttl time.Duration) *players.Service {
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", mock.Anything, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithIdempotencyKeys(storageMock, hasherMock, notifierMock, mailerMock, ttl)
+
+	return service
+}
+
+// storedIdempotencyRecordFixture returns the record stored by the creation of the given player.
+func storedIdempotencyRecordFixture(t *testing.T, newPlayer players.NewPlayer) players.IdempotencyRecord {
+	t.Helper()
+
+	ctx := context.TODO()
+
+	var storedRecord players.IdempotencyRecord
+
+	storageMock := newIdempotentCreateStorageMock(ctx)
+	storageMock.On("GetIdempotencyRecord", ctx, newPlayer.Source, newPlayer.IdempotencyKey).Return(nil, nil)
+	storageMock.On("SaveWithIdempotencyKey", ctx, mock.AnythingOfType("players.Player"), mock.AnythingOfType("players.IdempotencyRecord")).
+		Run(func(args mock.Arguments) { storedRecord = args.Get(2).(players.IdempotencyRecord) }).
+		Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return([]byte("$2a$04$firsthash"), nil)
+
+	service := newIdempotentCreateService(storageMock, hasherMock, time.Hour)
+
+	_, err := service.Create(ctx, newPlayer)
+	require.NoError(t, err)
+
+	return storedRecord
+}
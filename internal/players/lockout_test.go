@@ -0,0 +1,250 @@
+package players_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticatePlayerLocksPlayerAfterMaxFailedAttempts(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.LockState.FailedAttempts = 2
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "wrongpwd",
+		Source:   "10.0.0.1",
+	}
+
+	wantEvent := players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player was locked",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("CountFailedLoginAttemptsBySource", ctx, "10.0.0.1", mock.AnythingOfType("time.Time")).Return(0, nil)
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+	storageMock.On("IncrementFailedLoginAttempts", ctx, *givenPlayerID, mock.AnythingOfType("time.Time")).Return(3, nil)
+	storageMock.On("UpdateLockState", ctx, *givenPlayerID, mock.MatchedBy(func(lockState players.LockState) bool {
+		return lockState.FailedAttempts == 3 && lockState.IsLocked(time.Now().UTC())
+	})).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(false)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", wantEvent)
+
+	service, _ := unittests.NewPlayerServiceWithLockoutPolicy(storageMock, hasherMock, notifierMock, lockoutPolicyFixture())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCredentials)
+	assert.Nil(t, got)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestAuthenticatePlayerButPlayerIsLocked(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	lockedUntil := time.Now().UTC().Add(time.Hour)
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.LockState = players.LockState{
+		FailedAttempts: 3,
+		LockedUntil:    &lockedUntil,
+	}
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	dummyHash := []byte("$2a$04$Ht0b6zNi2sEKvW5KZbC7OO6hyVjKXhpo0O0kk6vZOWyvr4BkUi0cS")
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", mock.AnythingOfType("string")).Return(dummyHash, nil)
+	hasherMock.On("Verify", dummyHash, credentials.Password).Return(false)
+
+	service, _ := unittests.NewPlayerServiceWithLockoutPolicy(storageMock, hasherMock, unittests.NewNotifierMock(), lockoutPolicyFixture())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCredentials)
+	assert.Nil(t, got)
+	hasherMock.AssertNotCalled(t, "Verify", existingPlayer.Password, credentials.Password)
+}
+
+func TestAuthenticatePlayerButSourceExceededFailedAttempts(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+		Source:   "10.0.0.1",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("CountFailedLoginAttemptsBySource", ctx, "10.0.0.1", mock.AnythingOfType("time.Time")).Return(10, nil)
+
+	service, _ := unittests.NewPlayerServiceWithLockoutPolicy(
+		storageMock, unittests.NewHasherMock(), unittests.NewNotifierMock(), lockoutPolicyFixture(),
+	)
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrTooManyLoginAttempts)
+	assert.Nil(t, got)
+	storageMock.AssertNotCalled(t, "GetByEmailOrNickname", ctx, "focampo")
+}
+
+func TestAuthenticatePlayerResetsFailedAttempts(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.LockState.FailedAttempts = 2
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+	storageMock.On("UpdateLockState", ctx, *givenPlayerID, players.LockState{}).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(false)
+
+	service, _ := unittests.NewPlayerServiceWithLockoutPolicy(storageMock, hasherMock, unittests.NewNotifierMock(), lockoutPolicyFixture())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, givenPlayerID, got)
+	storageMock.AssertExpectations(t)
+}
+
+func TestGetPlayerLockState(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	lockedUntil := time.Now().UTC().Add(time.Hour)
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.LockState = players.LockState{
+		FailedAttempts: 3,
+		LockedUntil:    &lockedUntil,
+	}
+
+	want := players.LockState{
+		FailedAttempts: 3,
+		LockedUntil:    &lockedUntil,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.GetLockState(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &want, got)
+}
+
+func TestUnlockPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	lockedUntil := time.Now().UTC().Add(time.Hour)
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.LockState = players.LockState{
+		FailedAttempts: 3,
+		LockedUntil:    &lockedUntil,
+	}
+
+	wantEvent := players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player was unlocked",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("UpdateLockState", ctx, *givenPlayerID, players.LockState{}).Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", wantEvent)
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.Unlock(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestUnlockPlayerWithEndpointButDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+
+	want := players.UnlockPlayerResult{
+		Err: "unable to unlock player: player doesn't exist",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(nil, nil)
+
+	service, logger := unittests.NewPlayerServiceWithStorage(storageMock)
+	unlockPlayerEndpoint := players.MakeUnlockPlayerEndpoint(service, logger)
+
+	// When
+	got, err := unlockPlayerEndpoint.Do(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func lockoutPolicyFixture() players.LockoutPolicy {
+	return players.LockoutPolicy{
+		MaxFailedAttempts:          3,
+		LockDuration:               15 * time.Minute,
+		MaxFailedAttemptsPerSource: 10,
+		SourceWindow:               15 * time.Minute,
+	}
+}
@@ -3,7 +3,6 @@ package players
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/mail"
 	"time"
 
@@ -12,23 +11,121 @@ import (
 
 // Storage defines behaviour for player repositories.
 type Storage interface {
-	// Save persists a new player in the player repository.
+	// Save persists a new player in the player repository. It returns a PlayerAlreadyExistsError
+	// if another player already has the given email or nickname.
 	Save(ctx context.Context, player Player) error
-	// Update player in the player repository.
-	Update(ctx context.Context, player Player) error
-	// Delete player in the repository.
-	Delete(ctx context.Context, playerID PlayerID) error
+	// SaveWithIdempotencyKey persists a new player and the idempotency key used to create it. It returns
+	// ErrIdempotencyKeyExists if the key was stored by another request and did not expire yet.
+	SaveWithIdempotencyKey(ctx context.Context, player Player, record IdempotencyRecord) error
+	// SaveAll persists the new players with a single multi-row insert, none of them is stored if any fails.
+	// It returns a PlayerAlreadyExistsError if another player already has one of the emails or nicknames.
+	SaveAll(ctx context.Context, players []Player) error
+	// Update player in the player repository if its stored version is still player.Version.
+	// It returns false if the player was changed or deleted in the meantime and a
+	// PlayerAlreadyExistsError if another player already has the given email or nickname.
+	Update(ctx context.Context, player Player) (bool, error)
+	// UpdateAll updates the players in a single transaction if their stored versions are still their
+	// versions. It returns a BatchItemError with ErrPlayerVersionConflict or a PlayerAlreadyExistsError
+	// for the first player that can't be updated, none of them is updated then.
+	UpdateAll(ctx context.Context, players []Player) error
+	// Delete marks the player as deleted in the repository if its stored version is still
+	// the given version, deleted players are hidden from reads and uniqueness checks until
+	// they are restored or purged. It returns false if the player was changed or deleted in the meantime.
+	Delete(ctx context.Context, playerID PlayerID, version int64, deletedAt time.Time) (bool, error)
+	// DeleteAll marks the players as deleted in a single transaction if their stored versions are still
+	// their versions. It returns a BatchItemError with ErrPlayerVersionConflict for the first player
+	// that was changed or deleted in the meantime, none of them is deleted then.
+	DeleteAll(ctx context.Context, players []Player, deletedAt time.Time) error
+	// Restore removes the deleted mark of the given player. It returns a PlayerAlreadyExistsError
+	// if another player took the email or nickname in the meantime.
+	Restore(ctx context.Context, playerID PlayerID) error
+	// PurgeDeletedBefore physically removes at most limit players deleted before the given date
+	// and returns their ids.
+	PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) ([]PlayerID, error)
+	// GetDeletedByID get a deleted player with the given id.
+	GetDeletedByID(ctx context.Context, id PlayerID) (*Player, error)
+	// ChangeStatus moves the player from change.From to change.To and records the change.
+	// It returns false if the player status is no longer change.From.
+	ChangeStatus(ctx context.Context, change StatusChange) (bool, error)
+	// ExpireSuspensions activates the suspended players whose suspension expired at change.DateCreated
+	// and returns their ids.
+	ExpireSuspensions(ctx context.Context, change StatusChange) ([]PlayerID, error)
+	// UpdatePassword replaces the hashed password of the given player.
+	UpdatePassword(ctx context.Context, playerID PlayerID, hashedPassword []byte) error
+	// ChangePassword stores the new hashed password of the player if its stored version is still
+	// change.Version, adds the previous one to its password history, removes all its password
+	// reset tokens and idempotency keys and clears its lock if change.Unlock is set. It returns
+	// false if the player was changed or deleted in the meantime, or if change.ResetTokenHash
+	// is given and the token was already used.
+	ChangePassword(ctx context.Context, change PasswordChange) (bool, error)
+	// GetPasswordHistory returns at most limit previous hashed passwords of the player, newest first.
+	GetPasswordHistory(ctx context.Context, playerID PlayerID, limit int) ([][]byte, error)
+	// SavePasswordResetToken persists a new password reset token.
+	SavePasswordResetToken(ctx context.Context, token PasswordResetToken) error
+	// CountPasswordResetTokens counts the password reset tokens of the player that are not expired at the given date.
+	CountPasswordResetTokens(ctx context.Context, playerID PlayerID, now time.Time) (int, error)
+	// GetPasswordResetToken get the password reset token with the given hash, nil if it does not exist.
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	// SaveEmailVerificationToken persists a new email verification token.
+	SaveEmailVerificationToken(ctx context.Context, token EmailVerificationToken) error
+	// GetEmailVerificationToken get the email verification token with the given hash, nil if it does not exist.
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+	// VerifyEmail consumes the token and stores the verified email of the player if its stored version
+	// is still verification.Version. It returns false if the player was changed or deleted in the meantime
+	// or the token was already used, and a PlayerAlreadyExistsError if another player has the email.
+	VerifyEmail(ctx context.Context, verification EmailVerification) (bool, error)
+	// GetIdempotencyRecord get the record of the given idempotency key sent from the given source,
+	// nil if it does not exist.
+	GetIdempotencyRecord(ctx context.Context, source, key string) (*IdempotencyRecord, error)
+	// DeleteExpiredIdempotencyKeys removes the idempotency keys expired at the given date and
+	// returns the number of removed keys.
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
 	// GetByID get a player with the given id.
 	GetByID(ctx context.Context, id PlayerID) (*Player, error)
+	// GetByIDs get the players with the given ids, players that do not exist are left out.
+	GetByIDs(ctx context.Context, ids []PlayerID) ([]Player, error)
+	// GetByEmailOrNickname get the player whose email or nickname is the given login.
+	GetByEmailOrNickname(ctx context.Context, login string) (*Player, error)
 	// GetPlayersWithEmailOrNickName get players with given email or nickname.
 	GetPlayersWithEmailOrNickName(ctx context.Context, filter PlayerFilter) (*PlayerExistResult, error)
+	// GetPlayersWithEmailsOrNicknames get the keys of the players with any of the emails or nicknames
+	// of the given filters in a single query, IgnoreID of the filters is not applied.
+	GetPlayersWithEmailsOrNicknames(ctx context.Context, filters []PlayerFilter) ([]PlayerKeys, error)
 	// Search looks up players that match the given filter criteria.
 	Search(ctx context.Context, searchCriteria SearchCriteria) (*SearchResult, error)
+	// SaveLoginAttempt persists an authentication attempt.
+	SaveLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+	// CountFailedLoginAttemptsBySource counts failed authentication attempts from the given source since the given date.
+	CountFailedLoginAttemptsBySource(ctx context.Context, source string, since time.Time) (int, error)
+	// IncrementFailedLoginAttempts increments the consecutive failed authentication attempts of the player
+	// and returns the new count. The count starts again if the player lock already expired.
+	IncrementFailedLoginAttempts(ctx context.Context, playerID PlayerID, now time.Time) (int, error)
+	// UpdateLockState updates the lock state of the player.
+	UpdateLockState(ctx context.Context, playerID PlayerID, lockState LockState) error
+	// CountPlayersByCountry counts the players that are not deleted by their stored country.
+	CountPlayersByCountry(ctx context.Context) (map[string]int, error)
+	// GetStoredCountries returns the distinct countries stored for all players, including deleted ones.
+	GetStoredCountries(ctx context.Context) ([]string, error)
+	// ReplaceCountry replaces the country of all the players whose country is from, including
+	// deleted ones, and returns the number of changed players.
+	ReplaceCountry(ctx context.Context, from, to string, updatedAt time.Time) (int, error)
+	// GetPlayersWithoutCanonicalKeys returns the players without canonical nickname or email,
+	// including deleted ones, the oldest first. Only the id, nickname and email are read.
+	GetPlayersWithoutCanonicalKeys(ctx context.Context) ([]Player, error)
+	// SaveCanonicalKeys stores the canonical nickname and email of the player, it returns a
+	// PlayerAlreadyExistsError if they are taken by another active player.
+	SaveCanonicalKeys(ctx context.Context, keys PlayerKeys) error
 }
 
 // Hasher defines behaviour for crypto mechanisms.
 type Hasher interface {
+	// Hash hashes the given password.
 	Hash(password string) ([]byte, error)
+	// Verify checks if the given password matches the hashed password.
+	Verify(hashedPassword []byte, password string) bool
+	// NeedsRehash checks if the hashed password was generated with outdated
+	// settings or algorithm and should be hashed again.
+	NeedsRehash(hashedPassword []byte) bool
 }
 
 // Notifier defines behavior to notify about player events.
@@ -37,6 +134,12 @@ type Notifier interface {
 	Notify(event NewEvent)
 }
 
+// Mailer defines behavior to send mails to players.
+type Mailer interface {
+	// Send delivers the given mail.
+	Send(ctx context.Context, mail Mail) error
+}
+
 // PlayerID defines player id.
 type PlayerID uuid.UUID
 
@@ -51,6 +154,46 @@ type Player struct {
 	Country     string
 	DateCreated time.Time
 	DateUpdated time.Time
+	// DateDeleted is nil if the player was not deleted.
+	DateDeleted *time.Time
+	LockState   LockState
+	Status      PlayerStatus
+	// Version is incremented on every change of the player, it starts at 1.
+	Version int64
+	// EmailVerified indicates if the player confirmed it owns Email.
+	EmailVerified bool
+	// PendingEmail new email of the player waiting to be verified, Email is used until then.
+	PendingEmail *mail.Address
+}
+
+// LockState contains data about consecutive failed authentications of a player.
+type LockState struct {
+	// FailedAttempts number of consecutive failed authentication attempts.
+	FailedAttempts int
+	// LockedUntil date until the player cannot authenticate, nil if the player is not locked.
+	LockedUntil *time.Time
+}
+
+// LockoutPolicy defines when players get locked after failed authentications.
+// Zero or negative values disable the respective protection.
+type LockoutPolicy struct {
+	// MaxFailedAttempts consecutive failed attempts allowed before locking the player.
+	MaxFailedAttempts int
+	// LockDuration time the player stays locked.
+	LockDuration time.Duration
+	// MaxFailedAttemptsPerSource failed attempts allowed from the same source within SourceWindow.
+	MaxFailedAttemptsPerSource int
+	// SourceWindow time window used to count failed attempts per source.
+	SourceWindow time.Duration
+}
+
+// LoginAttempt contains data about an authentication attempt.
+type LoginAttempt struct {
+	// PlayerID is nil if the login does not belong to any player.
+	PlayerID    *PlayerID
+	Source      string
+	Succeeded   bool
+	DateCreated time.Time
 }
 
 // NewPlayer contains data required to create a new player.
@@ -61,6 +204,11 @@ type NewPlayer struct {
 	Email     mail.Address
 	Password  string
 	Country   string
+	// IdempotencyKey optional key to retry the creation safely, requests with the
+	// same key and source get the player created by the first one.
+	IdempotencyKey string
+	// Source address of the client that sent the request, it scopes the idempotency key.
+	Source string
 }
 
 // UpdatePlayer contains data required to update player.
@@ -70,8 +218,30 @@ type UpdatePlayer struct {
 	LastName  *string
 	Nickname  *string
 	Email     *mail.Address
-	Password  *string
 	Country   *string
+	// ClearPendingEmail cancels the email change waiting to be verified.
+	ClearPendingEmail bool
+	// ExpectedVersion is the version the client read, zero skips the check.
+	ExpectedVersion int64
+	// UpdateMask paths of the fields to update, fields out of the mask are left alone and fields
+	// in the mask without value are cleared. If it is empty, the fields with value are updated.
+	UpdateMask []string
+}
+
+// DeletePlayer contains data required to delete a player.
+type DeletePlayer struct {
+	ID PlayerID
+	// ExpectedVersion is the version the client read, zero skips the check.
+	ExpectedVersion int64
+}
+
+// Credentials contains data required to authenticate a player.
+type Credentials struct {
+	// Login player email or nickname.
+	Login    string
+	Password string
+	// Source identifies the client that tries to authenticate, e.g. its ip address.
+	Source string
 }
 
 // PlayerFilter email or nicknames fields.
@@ -90,17 +260,58 @@ type PlayerExistResult struct {
 // PlayerAlreadyExistsError defines error for trying to create a player
 // that already exists with the given email or nickname.
 type PlayerAlreadyExistsError struct {
-	wasEmail    bool
-	wasNickname bool
+	// WasEmail indicates that another player already uses the given email.
+	WasEmail bool
+	// WasNickname indicates that another player already uses the given nickname.
+	WasNickname bool
+}
+
+// FieldError defines a validation error of a specific player field.
+// It can be inspected with errors.Is(err, ErrInvalidPlayerData) or errors.As.
+type FieldError struct {
+	// Field name of the player field that is not valid.
+	Field string
+	// Description human readable description of the violation.
+	Description string
 }
 
 // SearchCriteria criteria data to search players.
 type SearchCriteria struct {
 	Country *string
+	// Status filters players by their stored status.
+	Status *Status
+	// NicknamePrefix filters players whose nickname starts with it, they are compared by their canonical form.
+	NicknamePrefix *string
+	// FirstName and LastName filter players whose names contain them, ignoring case.
+	FirstName *string
+	LastName  *string
+	// Email filters the player with that email, they are compared by their canonical form.
+	// Only admins can search players by email.
+	Email *string
+	// DateCreated and DateUpdated filter players by the dates they were created and last updated.
+	DateCreated DateRange
+	DateUpdated DateRange
+	// Admin indicates if the search is made by an admin.
+	Admin bool `json:"-"`
+	// Sort sorts the players by the given keys in order, players with the same values
+	// are always sorted by id so pages are stable.
+	Sort []SortKey
 	// determines the number of rows.
 	Limit uint16 `json:"limit"`
 	// skips the offset rows before beginning to return the rows.
 	Offset uint16 `json:"offset"`
+	// PageToken continues a previous search after its last player, it can't be combined with an offset.
+	PageToken string `json:"page_token,omitempty"`
+	// After is the cursor of the page token, the storage returns the players that follow it.
+	After *Cursor `json:"-"`
+	// ListAll lists all the players when no filter is given, it requires a limit.
+	// Without it a search without filters returns an empty result.
+	ListAll bool `json:"list_all,omitempty"`
+	// SkipTotal skips counting the players that match the criteria, the total of the result is zero.
+	SkipTotal bool `json:"skip_total,omitempty"`
+	// Fuzzy searches players whose nickname, first or last name look like it, e.g. with typos.
+	// Players are ranked by their score before the sort keys.
+	Fuzzy *string `json:"fuzzy,omitempty"`
 }
 
 // PlayerItem contains few data about a player.
@@ -110,6 +321,8 @@ type PlayerItem struct {
 	LastName  string
 	Nickname  string
 	Country   string
+	// Score relevance of the player for a fuzzy search, from 0 to 1. It is zero for other searches.
+	Score float64
 }
 
 // SearchResult contains data about the result of a specific player search.
@@ -121,6 +334,11 @@ type SearchResult struct {
 	Limit uint16 `json:"limit"`
 	// skips the offset rows before beginning to return the rows.
 	Offset uint16 `json:"offset"`
+	// NextPageToken continues the search after the last player of the result, it is empty
+	// if there are no more players.
+	NextPageToken string `json:"next_page_token,omitempty"`
+	// Next is the cursor of the last player of the result, set by the storage if there are more players.
+	Next *Cursor `json:"-"`
 }
 
 // CreatePlayerResult standard response for creating a Player.
@@ -139,6 +357,34 @@ type DeletePlayerResult struct {
 	Err string
 }
 
+// RestorePlayerResult standard response for restoring a deleted Player.
+type RestorePlayerResult struct {
+	Err string
+}
+
+// AuthenticatePlayerResult standard response for authenticating a Player.
+type AuthenticatePlayerResult struct {
+	ID  *PlayerID
+	Err string
+}
+
+// GetLockStateResult standard response for getting the lock state of a Player.
+type GetLockStateResult struct {
+	LockState *LockState
+	Err       string
+}
+
+// UnlockPlayerResult standard response for unlocking a Player.
+type UnlockPlayerResult struct {
+	Err string
+}
+
+// GetPlayerResult standard response for getting a Player.
+type GetPlayerResult struct {
+	Player *Player
+	Err    string
+}
+
 // SearchPlayersDataResult standard response for search players.
 type SearchPlayersDataResult struct {
 	SearchResult *SearchResult
@@ -155,21 +401,52 @@ type NewEvent struct {
 type updateToPlayerResult struct {
 	player  *Player
 	changes bool
+	// emailToVerify new email of the player, it must be verified before replacing the current one.
+	emailToVerify string
 }
 
 const (
 	redactedValue = "[REDACTED]"
+	// initialVersion version of new players.
+	initialVersion = 1
+)
+
+// player field names used in validation errors.
+const (
+	FirstNameField = "firstname"
+	LastNameField  = "lastname"
+	NicknameField  = "nickname"
+	EmailField     = "email"
+	PasswordField  = "password"
+	CountryField   = "country"
+	LoginField     = "login"
 )
 
 var (
-	ErrInvalidPlayerID    = errors.New("invalid player id")
+	// ErrInvalidPlayerID is returned when the given player id is not a valid uuid.
+	ErrInvalidPlayerID = errors.New("invalid player id")
+	// ErrPlayerDoesNotExist is returned when the requested player does not exist.
 	ErrPlayerDoesNotExist = errors.New("player doesn't exist")
-	errEmptyFirstName     = errors.New("first name is empty")
-	errEmptyLastName      = errors.New("last name is empty")
-	errEmptyNickname      = errors.New("nickname is empty")
-	errEmptyCountry       = errors.New("country is empty")
-	errEmptyPassword      = errors.New("password is empty")
-	errEmptyEmail         = errors.New("email is empty")
+	// ErrPlayerAlreadyExists is matched by any PlayerAlreadyExistsError.
+	ErrPlayerAlreadyExists = errors.New("player already exists")
+	// ErrInvalidCredentials is returned for any failed authentication, it does not
+	// reveal if the player exists or the password was wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrPlayerVersionConflict is returned when the player was changed after the client read it.
+	ErrPlayerVersionConflict = errors.New("player was changed by another request, read it again and retry")
+	// ErrRestoreWindowExpired is returned when the player was deleted before the retention window.
+	ErrRestoreWindowExpired = errors.New("player can no longer be restored, the retention window expired")
+	// ErrTooManyLoginAttempts is returned when a source exceeded the allowed failed authentications.
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+	// ErrInvalidPlayerData is matched by any FieldError.
+	ErrInvalidPlayerData = errors.New("invalid player data")
+	ErrEmptyFirstName    = newFieldError(FirstNameField, "first name is empty")
+	ErrEmptyLastName     = newFieldError(LastNameField, "last name is empty")
+	ErrEmptyNickname     = newFieldError(NicknameField, "nickname is empty")
+	ErrEmptyCountry      = newFieldError(CountryField, "country is empty")
+	ErrEmptyPassword     = newFieldError(PasswordField, "password is empty")
+	ErrEmptyEmail        = newFieldError(EmailField, "email is empty")
+	ErrEmptyLogin        = newFieldError(LoginField, "login is empty")
 )
 
 func (u Player) obfuscate() Player {
@@ -180,21 +457,65 @@ func (u Player) obfuscate() Player {
 }
 
 func (u *PlayerAlreadyExistsError) Error() string {
-	if u.wasEmail && u.wasNickname {
+	if u.WasEmail && u.WasNickname {
 		return "player with the given email or nickname already exists"
 	}
 
-	if u.wasEmail {
+	if u.WasEmail {
 		return "player with the given email already exists"
 	}
 
 	return "player with the given nickname already exists"
 }
 
+// Is allows to match any PlayerAlreadyExistsError with ErrPlayerAlreadyExists.
+func (u *PlayerAlreadyExistsError) Is(target error) bool {
+	return target == ErrPlayerAlreadyExists
+}
+
+func newFieldError(field, description string) *FieldError {
+	return &FieldError{
+		Field:       field,
+		Description: description,
+	}
+}
+
+func (f *FieldError) Error() string {
+	return f.Description
+}
+
+// Is allows to match any FieldError with ErrInvalidPlayerData.
+func (f *FieldError) Is(target error) bool {
+	return target == ErrInvalidPlayerData
+}
+
+// FieldErrors returns all the field errors contained in the given error,
+// it walks wrapped and joined errors.
+func FieldErrors(err error) []*FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var result []*FieldError
+
+	switch wrappedErr := err.(type) {
+	case *FieldError:
+		result = append(result, wrappedErr)
+	case interface{ Unwrap() []error }:
+		for _, joinedErr := range wrappedErr.Unwrap() {
+			result = append(result, FieldErrors(joinedErr)...)
+		}
+	case interface{ Unwrap() error }:
+		result = append(result, FieldErrors(wrappedErr.Unwrap())...)
+	}
+
+	return result
+}
+
 func (u PlayerExistResult) toPlayerAlreadyExistsError() *PlayerAlreadyExistsError {
 	newError := PlayerAlreadyExistsError{
-		wasEmail:    u.EmailExist,
-		wasNickname: u.NicknameExist,
+		WasEmail:    u.EmailExist,
+		WasNickname: u.NicknameExist,
 	}
 
 	return &newError
@@ -216,7 +537,7 @@ func (u PlayerID) String() string {
 func (n NewPlayer) toPlayer(hashedPassword []byte) Player {
 	newPlayerID := newPlayerID()
 
-	now := time.Now().UTC()
+	now := storedNow()
 
 	return Player{
 		ID:          &newPlayerID,
@@ -225,40 +546,27 @@ func (n NewPlayer) toPlayer(hashedPassword []byte) Player {
 		Nickname:    n.Nickname,
 		Email:       n.Email,
 		Password:    hashedPassword,
-		Country:     n.Country,
+		Country:     normalizedCountry(n.Country),
 		DateCreated: now,
 		DateUpdated: now,
+		Status: PlayerStatus{
+			Status: StatusActive,
+		},
+		Version: initialVersion,
 	}
 }
 
+// Validate returns all the violations of the new player fields joined.
 func (n NewPlayer) Validate() error {
-	var err error
-
-	if n.FirstName == "" {
-		err = errors.Join(err, errEmptyFirstName)
-	}
-
-	if n.LastName == "" {
-		err = errors.Join(err, errEmptyLastName)
-	}
-
-	if n.Nickname == "" {
-		err = errors.Join(err, errEmptyNickname)
-	}
-
-	if n.Email.Address == "" {
-		err = errors.Join(err, errEmptyEmail)
-	}
-
-	if n.Country == "" {
-		err = errors.Join(err, errEmptyCountry)
-	}
-
-	if n.Password == "" {
-		err = errors.Join(err, errEmptyPassword)
-	}
-
-	return err
+	return errors.Join(
+		firstNameRules.validate(n.FirstName),
+		lastNameRules.validate(n.LastName),
+		nicknameRules.validate(n.Nickname),
+		emailRules.validate(n.Email.Address),
+		countryRules.validate(n.Country),
+		passwordRules.validate(n.Password),
+		validateIdempotencyKey(n.IdempotencyKey),
+	)
 }
 
 func (n NewPlayer) obfuscate() NewPlayer {
@@ -276,44 +584,99 @@ func (n NewPlayer) toPlayerFilter() PlayerFilter {
 	}
 }
 
-func (u UpdatePlayer) Validate() error {
-	var err error
-
-	if isStringEmpty(u.FirstName) {
-		err = errors.Join(err, errEmptyFirstName)
+func (u Player) toPlayerFilter() PlayerFilter {
+	return PlayerFilter{
+		Email:    u.Email.Address,
+		Nickname: u.Nickname,
+		IgnoreID: u.ID,
 	}
+}
 
-	if isStringEmpty(u.LastName) {
-		err = errors.Join(err, errEmptyLastName)
-	}
+// isStale indicates if the player was changed after the client read the expected version,
+// zero means the client does not expect any version.
+func (u Player) isStale(expectedVersion int64) bool {
+	return expectedVersion != 0 && expectedVersion != u.Version
+}
+
+// canBeRestored indicates if the deleted player is still within the given retention window.
+func (u Player) canBeRestored(now time.Time, retention time.Duration) bool {
+	return u.DateDeleted != nil && u.DateDeleted.Add(retention).After(now)
+}
 
-	if isStringEmpty(u.Nickname) {
-		err = errors.Join(err, errEmptyNickname)
+// IsLocked indicates if the player is locked at the given date.
+func (l LockState) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && l.LockedUntil.After(now)
+}
+
+func (l LockState) hasFailedAttempts() bool {
+	return l.FailedAttempts > 0 || l.LockedUntil != nil
+}
+
+func (l LockoutPolicy) lockPlayersEnabled() bool {
+	return l.MaxFailedAttempts > 0 && l.LockDuration > 0
+}
+
+func (l LockoutPolicy) throttleSourcesEnabled() bool {
+	return l.MaxFailedAttemptsPerSource > 0 && l.SourceWindow > 0
+}
+
+func newLoginAttempt(playerID *PlayerID, source string, succeeded bool) LoginAttempt {
+	return LoginAttempt{
+		PlayerID:    playerID,
+		Source:      source,
+		Succeeded:   succeeded,
+		DateCreated: time.Now().UTC(),
 	}
+}
 
-	if u.Email != nil && u.Email.Address == "" {
-		err = errors.Join(err, errEmptyEmail)
+func newUnlockedState() LockState {
+	return LockState{
+		FailedAttempts: 0,
+		LockedUntil:    nil,
 	}
+}
+
+func (c Credentials) Validate() error {
+	var err error
 
-	if isStringEmpty(u.Country) {
-		err = errors.Join(err, errEmptyCountry)
+	if c.Login == "" {
+		err = errors.Join(err, ErrEmptyLogin)
 	}
 
-	if isStringEmpty(u.Password) {
-		err = errors.Join(err, errEmptyPassword)
+	if c.Password == "" {
+		err = errors.Join(err, ErrEmptyPassword)
 	}
 
 	return err
 }
 
-func (u UpdatePlayer) obfuscate() UpdatePlayer {
-	obfuscated := u
-	passwordObfuscated := redactedValue
-	obfuscated.Password = &passwordObfuscated
+func (c Credentials) obfuscate() Credentials {
+	obfuscated := c
+	obfuscated.Password = redactedValue
 
 	return obfuscated
 }
 
+// Validate returns all the violations of the update mask and of the fields to update joined,
+// fields that are not updated are not validated.
+func (u UpdatePlayer) Validate() error {
+	masked := u.masked()
+
+	err := errors.Join(
+		u.validateUpdateMask(),
+		firstNameRules.validateOptional(masked.FirstName),
+		lastNameRules.validateOptional(masked.LastName),
+		nicknameRules.validateOptional(masked.Nickname),
+		countryRules.validateOptional(masked.Country),
+	)
+
+	if masked.Email != nil {
+		err = errors.Join(err, emailRules.validate(masked.Email.Address))
+	}
+
+	return err
+}
+
 func (u UpdatePlayer) toPlayerFilter() PlayerFilter {
 	var newPlayerFilter PlayerFilter
 
@@ -331,7 +694,7 @@ func (u UpdatePlayer) toPlayerFilter() PlayerFilter {
 }
 
 // toPlayer updates the data for the given player with the data in updateplayer.
-func (u UpdatePlayer) toPlayer(player Player, hasher Hasher) (*updateToPlayerResult, error) {
+func (u UpdatePlayer) toPlayer(player Player) *updateToPlayerResult {
 	var result updateToPlayerResult
 	if areStringDifferent(u.FirstName, player.FirstName) {
 		result.changes = true
@@ -348,33 +711,43 @@ func (u UpdatePlayer) toPlayer(player Player, hasher Hasher) (*updateToPlayerRes
 		player.Nickname = *u.Nickname
 	}
 
-	if areStringDifferent(u.Country, player.Country) {
+	if u.Country != nil && normalizedCountry(*u.Country) != player.Country {
 		result.changes = true
-		player.Country = *u.Country
+		player.Country = normalizedCountry(*u.Country)
 	}
 
-	if u.Email != nil && u.Email.Address != player.Email.Address {
+	if u.ClearPendingEmail && player.PendingEmail != nil {
 		result.changes = true
-		player.Email = *u.Email
+		player.PendingEmail = nil
 	}
 
-	if u.Password != nil {
-		result.changes = true
-
-		hashedPassword, err := hasher.Hash(*u.Password)
-		if err != nil {
-			return nil, fmt.Errorf("unable to hash password: %w", err)
+	switch {
+	case u.Email == nil:
+	case CanonicalEmail(u.Email.Address) == CanonicalEmail(player.Email.Address):
+		// same mailbox, it is not verified again and a pending change is cancelled.
+		if u.Email.Address != player.Email.Address || player.PendingEmail != nil {
+			result.changes = true
+			player.Email = *u.Email
+			player.PendingEmail = nil
 		}
-
-		player.Password = hashedPassword
+	default:
+		result.changes = true
+		result.emailToVerify = u.Email.Address
+		player.PendingEmail = u.Email
 	}
 
 	if result.changes {
-		player.DateUpdated = time.Now().UTC()
+		player.DateUpdated = storedNow()
 		result.player = &player
 	}
 
-	return &result, nil
+	return &result
+}
+
+// storedNow returns the current date with the precision of the dates in the database, so
+// the player returned after it is created or updated has the same dates it is read with later.
+func storedNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
 }
 
 func (u UpdatePlayer) updateKeyValues() bool {
@@ -384,16 +757,44 @@ func (u UpdatePlayer) updateKeyValues() bool {
 func (s SearchCriteria) isEmpty() bool {
 	empty := true
 
-	if !isStringEmpty(s.Country) {
+	if hasValue(s.Country) {
+		empty = false
+	}
+
+	if s.Status != nil {
+		empty = false
+	}
+
+	if hasValue(s.NicknamePrefix) || hasValue(s.FirstName) || hasValue(s.LastName) || hasValue(s.Email) || hasValue(s.Fuzzy) {
+		empty = false
+	}
+
+	if !s.DateCreated.IsEmpty() || !s.DateUpdated.IsEmpty() {
 		empty = false
 	}
 
 	return empty
 }
 
+// normalizeCountry replaces the country filter with its ISO 3166-1 alpha-2 code.
+func (s *SearchCriteria) normalizeCountry() error {
+	if !hasValue(s.Country) {
+		return nil
+	}
+
+	code, ok := NormalizeCountry(*s.Country)
+	if !ok {
+		return ErrInvalidCountry
+	}
+
+	s.Country = &code
+
+	return nil
+}
+
 func (s *SearchCriteria) setDefaultPaginationIfEmpty() {
 	if s.Limit == 0 {
-		s.Limit = 5
+		s.Limit = defaultSearchLimit
 	}
 }
 
@@ -445,6 +846,76 @@ func newDeletePlayerResult(err error) DeletePlayerResult {
 	}
 }
 
+// newRestorePlayerResult create a new RestorePlayerResult.
+func newRestorePlayerResult(err error) RestorePlayerResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return RestorePlayerResult{
+		Err: errmessage,
+	}
+}
+
+// newAuthenticatePlayerResult create a new AuthenticatePlayerResult.
+func newAuthenticatePlayerResult(playerID *PlayerID, err error) AuthenticatePlayerResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return AuthenticatePlayerResult{
+		ID:  playerID,
+		Err: errmessage,
+	}
+}
+
+// newGetLockStateResult create a new GetLockStateResult.
+func newGetLockStateResult(lockState *LockState, err error) GetLockStateResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return GetLockStateResult{
+		LockState: lockState,
+		Err:       errmessage,
+	}
+}
+
+// newUnlockPlayerResult create a new UnlockPlayerResult.
+func newUnlockPlayerResult(err error) UnlockPlayerResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return UnlockPlayerResult{
+		Err: errmessage,
+	}
+}
+
+// newGetPlayerResult create a new GetPlayerResult without the password hash.
+func newGetPlayerResult(player *Player, err error) GetPlayerResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	var obfuscatedPlayer *Player
+
+	if player != nil {
+		obfuscated := player.obfuscate()
+		obfuscatedPlayer = &obfuscated
+	}
+
+	return GetPlayerResult{
+		Player: obfuscatedPlayer,
+		Err:    errmessage,
+	}
+}
+
 // newSearchPlayersResult create a new SearchPlayersResult.
 func newSearchPlayersDataResult(result *SearchResult, err error) SearchPlayersDataResult {
 	var errmessage string
@@ -459,15 +930,12 @@ func newSearchPlayersDataResult(result *SearchResult, err error) SearchPlayersDa
 }
 
 func areStringDifferent(newValue *string, oldValue string) bool {
-	if newValue == nil || *newValue == "" {
-		return false
-	}
-
-	return *newValue != oldValue
+	return newValue != nil && *newValue != oldValue
 }
 
-func isStringEmpty(value *string) bool {
-	return value != nil && *value == ""
+// hasValue indicates if the given optional value is present and not empty.
+func hasValue(value *string) bool {
+	return value != nil && *value != ""
 }
 
 func NewString(value string) *string {
@@ -517,3 +985,31 @@ func newDeletePlayerEvent(playerID PlayerID) NewEvent {
 		Event:    "player was deleted",
 	}
 }
+
+func newRestorePlayerEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player was restored",
+	}
+}
+
+func newPurgePlayerEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player was purged",
+	}
+}
+
+func newPlayerLockedEvent(playerID *PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player was locked",
+	}
+}
+
+func newPlayerUnlockedEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player was unlocked",
+	}
+}
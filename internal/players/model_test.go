@@ -137,7 +137,6 @@ func TestValidateUpdatePlayer(t *testing.T) {
 				LastName:  players.NewString("Ocampo"),
 				Nickname:  players.NewString("belsonnoles"),
 				Email:     unittests.NewEmailAddress(t, "belsonnoles@anyemail.com"),
-				Password:  players.NewString(passwordFixture()),
 				Country:   players.NewString("Spain"),
 			},
 		},
@@ -177,22 +176,14 @@ func TestValidateUpdatePlayer(t *testing.T) {
 			want:    "country is empty",
 			isError: true,
 		},
-		"invalid_empty_password_update": {
-			newPlayer: players.UpdatePlayer{
-				Password: players.NewString(""),
-			},
-
-			want:    "password is empty",
-			isError: true,
-		},
 		"invalid_empty_multiple_fields_update": {
 			newPlayer: players.UpdatePlayer{
 				Nickname: players.NewString(""),
-				Password: players.NewString(""),
+				Country:  players.NewString(""),
 			},
 
 			want: `nickname is empty
-password is empty`,
+country is empty`,
 			isError: true,
 		},
 	}
@@ -215,6 +206,31 @@ password is empty`,
 	}
 }
 
+func TestValidationErrorsCanBeInspected(t *testing.T) {
+	// Given
+	newPlayer := players.NewPlayer{
+		FirstName: "Fernando",
+		LastName:  "Ocampo",
+		Email:     *unittests.NewEmailAddress(t, "belsonnoles@anyemail.com"),
+		Country:   "Spain",
+	}
+
+	want := []*players.FieldError{
+		{Field: "nickname", Description: "nickname is empty"},
+		{Field: "password", Description: "password is empty"},
+	}
+
+	// When
+	err := newPlayer.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidPlayerData)
+	assert.ErrorIs(t, err, players.ErrEmptyNickname)
+	assert.ErrorIs(t, err, players.ErrEmptyPassword)
+	assert.NotErrorIs(t, err, players.ErrEmptyEmail)
+	assert.Equal(t, want, players.FieldErrors(err))
+}
+
 func passwordFixture() string {
 	return "A3BB605190830A01828F4D987A8C26CCBE3D4DAC0FAEF9482FBCB2B3CCB19CB8"
 }
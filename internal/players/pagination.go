@@ -0,0 +1,139 @@
+package players
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Cursor position of a player in the sorted search results. Values contains the values
+// of the sort keys of the player, in the order of the sort keys, and ID breaks the ties.
+type Cursor struct {
+	Values []string `json:"v,omitempty"`
+	ID     string   `json:"id"`
+}
+
+// pageToken content of the page tokens sent to clients.
+type pageToken struct {
+	// Criteria fingerprint of the search criteria the token belongs to, a token
+	// can't be used to page through the results of another search.
+	Criteria string `json:"c"`
+	After    Cursor `json:"a"`
+}
+
+// pagination field names used in validation errors.
+const (
+	PageTokenField = "page_token"
+	LimitField     = "limit"
+)
+
+// search page sizes.
+const (
+	// defaultSearchLimit page size of searches without limit.
+	defaultSearchLimit = 5
+	// MaxSearchLimit greatest page size of a search.
+	MaxSearchLimit = 100
+)
+
+// pageTokenSeparator separates the content of a page token from its signature.
+const pageTokenSeparator = "."
+
+var (
+	// ErrInvalidPageToken is returned for page tokens that were not issued by the service,
+	// were changed or belong to another search.
+	ErrInvalidPageToken    = newFieldError(PageTokenField, "page token is invalid or belongs to another search")
+	ErrPageTokenWithOffset = newFieldError(PageTokenField, "page token can't be combined with an offset")
+	ErrSearchLimitTooLarge = newFieldError(LimitField, fmt.Sprintf("limit is greater than %d", MaxSearchLimit))
+	// ErrListAllWithoutLimit is returned when all players are listed without a page size.
+	ErrListAllWithoutLimit = newFieldError(LimitField, "listing all players requires a limit")
+)
+
+// validatePagination checks the page size is capped and that pages are requested in a single way.
+func (s SearchCriteria) validatePagination() error {
+	var err error
+
+	if s.Limit > MaxSearchLimit {
+		err = errors.Join(err, ErrSearchLimitTooLarge)
+	}
+
+	if s.ListAll && s.Limit == 0 {
+		err = errors.Join(err, ErrListAllWithoutLimit)
+	}
+
+	if s.PageToken != "" && s.Offset > 0 {
+		err = errors.Join(err, ErrPageTokenWithOffset)
+	}
+
+	return err
+}
+
+// fingerprint identifies the filters and sort keys of the search criteria, the pagination
+// fields are left out because they change from page to page.
+func (s SearchCriteria) fingerprint() (string, error) {
+	criteria := s
+	criteria.Limit = 0
+	criteria.Offset = 0
+	criteria.PageToken = ""
+	criteria.After = nil
+	criteria.SkipTotal = false
+
+	content, err := json.Marshal(criteria)
+	if err != nil {
+		return "", fmt.Errorf("unable to fingerprint search criteria: %w", err)
+	}
+
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// newPageToken signs a page token to continue the search after the given cursor.
+func newPageToken(key []byte, criteria string, after Cursor) (string, error) {
+	content, err := json.Marshal(pageToken{Criteria: criteria, After: after})
+	if err != nil {
+		return "", fmt.Errorf("unable to encode page token: %w", err)
+	}
+
+	encodedContent := base64.RawURLEncoding.EncodeToString(content)
+
+	return encodedContent + pageTokenSeparator + signPageToken(key, encodedContent), nil
+}
+
+// parsePageToken verifies the signature of the page token and that it belongs to the
+// search criteria with the given fingerprint, it returns the cursor to continue after.
+func parsePageToken(key []byte, criteria, token string) (*Cursor, error) {
+	encodedContent, signature, ok := strings.Cut(token, pageTokenSeparator)
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(signPageToken(key, encodedContent))) {
+		return nil, ErrInvalidPageToken
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(encodedContent)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var decodedToken pageToken
+
+	err = json.Unmarshal(content, &decodedToken)
+	if err != nil || decodedToken.Criteria != criteria {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &decodedToken.After, nil
+}
+
+func signPageToken(key []byte, encodedContent string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encodedContent))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
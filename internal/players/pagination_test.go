@@ -0,0 +1,133 @@
+package players_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchWithPageToken(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Country: players.NewString("CO"),
+		Sort: []players.SortKey{
+			{Field: players.SortByDateCreated, Descending: true},
+		},
+		Limit: 2,
+	}
+	lastPlayer := players.Cursor{
+		Values: []string{"2024-06-01T10:00:00Z"},
+		ID:     "b10d6af5-22f3-4db2-ade6-94cfcc819f91",
+	}
+
+	wantNextCriteria := searchCriteria
+	wantNextCriteria.After = &lastPlayer
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", ctx, searchCriteria).Return(&players.SearchResult{Limit: 2, Next: &lastPlayer}, nil).Once()
+	storageMock.On("Search", ctx, mock.MatchedBy(func(criteria players.SearchCriteria) bool {
+		// the page token is only known after the first page.
+		wantNextCriteria.PageToken = criteria.PageToken
+
+		return assert.ObjectsAreEqual(wantNextCriteria, criteria)
+	})).Return(&players.SearchResult{Limit: 2}, nil).Once()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	firstPage, err := service.List(ctx, searchCriteria)
+	require.NoError(t, err)
+
+	nextCriteria := searchCriteria
+	nextCriteria.PageToken = firstPage.NextPageToken
+
+	lastPage, err := service.List(ctx, nextCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotEmpty(t, firstPage.NextPageToken)
+	assert.Empty(t, lastPage.NextPageToken, "the last page has no next page")
+	storageMock.AssertExpectations(t)
+}
+
+func TestSearchButInvalidPageToken(t *testing.T) {
+	t.Parallel()
+
+	searchCriteria := players.SearchCriteria{
+		Country: players.NewString("CO"),
+		Limit:   2,
+	}
+	lastPlayer := players.Cursor{ID: "b10d6af5-22f3-4db2-ade6-94cfcc819f91"}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", context.TODO(), searchCriteria).Return(&players.SearchResult{Limit: 2, Next: &lastPlayer}, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	firstPage, err := service.List(context.TODO(), searchCriteria)
+	require.NoError(t, err)
+
+	content, signature, _ := strings.Cut(firstPage.NextPageToken, ".")
+
+	testCases := map[string]struct {
+		searchCriteria players.SearchCriteria
+	}{
+		"not_issued_by_the_service": {
+			searchCriteria: players.SearchCriteria{Country: players.NewString("CO"), PageToken: "token"},
+		},
+		"changed_content": {
+			searchCriteria: players.SearchCriteria{
+				Country:   players.NewString("CO"),
+				PageToken: strings.ToUpper(content) + "." + signature,
+			},
+		},
+		"changed_signature": {
+			searchCriteria: players.SearchCriteria{
+				Country:   players.NewString("CO"),
+				PageToken: content + "." + strings.ToUpper(signature),
+			},
+		},
+		"other_criteria": {
+			searchCriteria: players.SearchCriteria{
+				Country:   players.NewString("UK"),
+				PageToken: firstPage.NextPageToken,
+			},
+		},
+		"other_sort_keys": {
+			searchCriteria: players.SearchCriteria{
+				Country:   players.NewString("CO"),
+				Sort:      []players.SortKey{{Field: players.SortByNickname}},
+				PageToken: firstPage.NextPageToken,
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			storageMock := unittests.NewStorageMock()
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			got, err := service.List(ctx, data.searchCriteria)
+
+			// Then
+			assert.Nil(st, got)
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			assert.Equal(st, []*players.FieldError{
+				{Field: "page_token", Description: "page token is invalid or belongs to another search"},
+			}, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "Search", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package players
+
+import (
+	"errors"
+	"time"
+)
+
+// ChangePassword contains data required to change the password of a player.
+type ChangePassword struct {
+	PlayerID        PlayerID
+	CurrentPassword string
+	NewPassword     string
+	// Source identifies the client that changes the password, e.g. its ip address.
+	Source string
+}
+
+// PasswordChange contains data to store a new password of a player.
+type PasswordChange struct {
+	PlayerID PlayerID
+	// Version of the player whose current password was verified, the change
+	// is not stored if the player was changed in the meantime.
+	Version        int64
+	HashedPassword []byte
+	// PreviousHashedPassword is added to the password history of the player.
+	PreviousHashedPassword []byte
+	// HistorySize number of previous passwords kept, older ones are removed.
+	HistorySize int
+	// ResetTokenHash hash of the password reset token used to change the password,
+	// empty if the current password was verified. The token is consumed with the change.
+	ResetTokenHash string
+	// Unlock clears the failed authentications and the lock of the player, players who
+	// reset their password proved they own their email.
+	Unlock      bool
+	DateChanged time.Time
+}
+
+// ChangePasswordResult standard response for changing the password of a Player.
+type ChangePasswordResult struct {
+	Err string
+}
+
+// password change field names used in validation errors.
+const (
+	CurrentPasswordField = "current_password"
+	NewPasswordField     = "new_password"
+)
+
+var (
+	ErrEmptyCurrentPassword = newFieldError(CurrentPasswordField, "current password is empty")
+	ErrEmptyNewPassword     = newFieldError(NewPasswordField, "new password is empty")
+	// ErrPasswordReused is returned when the new password is one of the last passwords of the player.
+	ErrPasswordReused = newFieldError(NewPasswordField, "new password was used recently, choose another one")
+)
+
+// newPasswordRules applies the password policy to the new password.
+var newPasswordRules = fieldRules{
+	field:    NewPasswordField,
+	emptyErr: ErrEmptyNewPassword,
+	rules:    passwordRules.rules,
+}
+
+// Validate returns all the violations of the password change joined.
+func (c ChangePassword) Validate() error {
+	var err error
+
+	if c.CurrentPassword == "" {
+		err = errors.Join(err, ErrEmptyCurrentPassword)
+	}
+
+	return errors.Join(err, newPasswordRules.validate(c.NewPassword))
+}
+
+func (c ChangePassword) obfuscate() ChangePassword {
+	obfuscated := c
+	obfuscated.CurrentPassword = redactedValue
+	obfuscated.NewPassword = redactedValue
+
+	return obfuscated
+}
+
+func (c ChangePassword) toPasswordChange(player Player, hashedPassword []byte, historySize int) PasswordChange {
+	return newPasswordChange(player, hashedPassword, historySize)
+}
+
+// newPasswordChange replaces the password of the given player with the new hashed password.
+func newPasswordChange(player Player, hashedPassword []byte, historySize int) PasswordChange {
+	return PasswordChange{
+		PlayerID:               *player.ID,
+		Version:                player.Version,
+		HashedPassword:         hashedPassword,
+		PreviousHashedPassword: player.Password,
+		HistorySize:            historySize,
+		DateChanged:            time.Now().UTC(),
+	}
+}
+
+// newChangePasswordResult create a new ChangePasswordResult.
+func newChangePasswordResult(err error) ChangePasswordResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return ChangePasswordResult{
+		Err: errmessage,
+	}
+}
+
+func newPasswordChangedEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player password was changed",
+	}
+}
@@ -0,0 +1,286 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.Version = 3
+	changePassword := players.ChangePassword{
+		PlayerID:        *givenPlayerID,
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+	newHash := []byte("$2a$04$Ch5hv2NTmrjQSnzR6Kj0U.Qgtbvf1d0N7w1m0kRqmn5YzMIc.LZ1C")
+	previousHashes := [][]byte{[]byte("$2a$04$previous1"), []byte("$2a$04$previous2")}
+
+	wantChange := func(change players.PasswordChange) bool {
+		return change.PlayerID == *givenPlayerID &&
+			change.Version == 3 &&
+			string(change.HashedPassword) == string(newHash) &&
+			string(change.PreviousHashedPassword) == string(existingPlayer.Password) &&
+			change.HistorySize == 2
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("GetPasswordHistory", ctx, *givenPlayerID, 2).Return(previousHashes, nil)
+	storageMock.On("ChangePassword", ctx, mock.MatchedBy(wantChange)).Return(true, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, "th1s1s@dummypwd").Return(true)
+	hasherMock.On("Verify", existingPlayer.Password, "n3wpassword").Return(false)
+	hasherMock.On("Verify", previousHashes[0], "n3wpassword").Return(false)
+	hasherMock.On("Verify", previousHashes[1], "n3wpassword").Return(false)
+	hasherMock.On("Hash", "n3wpassword").Return(newHash, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player password was changed",
+	})
+
+	service, _ := unittests.NewPlayerServiceWithPasswordHistory(storageMock, hasherMock, notifierMock, 3)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	require.NoError(t, err)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestChangePasswordButWrongCurrentPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	changePassword := players.ChangePassword{
+		PlayerID:        *givenPlayerID,
+		CurrentPassword: "wr0ngpassword",
+		NewPassword:     "n3wpassword",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, "wr0ngpassword").Return(false)
+
+	service, _ := unittests.NewPlayerServiceWithPasswordHistory(storageMock, hasherMock, unittests.NewNotifierMock(), 3)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidCredentials)
+	storageMock.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything)
+	hasherMock.AssertNotCalled(t, "Hash", "n3wpassword")
+}
+
+func TestChangePasswordButPlayerIsNotActive(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	existingPlayer.Status = players.PlayerStatus{Status: players.StatusBanned, Reason: "cheating", Actor: "moderator"}
+	changePassword := players.ChangePassword{
+		PlayerID:        *givenPlayerID,
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, "th1s1s@dummypwd").Return(true)
+
+	service, _ := unittests.NewPlayerServiceWithPasswordHistory(storageMock, hasherMock, unittests.NewNotifierMock(), 3)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerIsNotActive)
+	storageMock.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything)
+	hasherMock.AssertNotCalled(t, "Hash", "n3wpassword")
+}
+
+func TestChangePasswordButReused(t *testing.T) {
+	t.Parallel()
+
+	previousHash := []byte("$2a$04$previous1")
+
+	testCases := map[string]struct {
+		newPassword   string
+		isCurrent     bool
+		isPrevious    bool
+		historySize   int
+		wantReuseFail bool
+	}{
+		"current_password": {
+			newPassword:   "th1s1s@dummypwd2",
+			isCurrent:     true,
+			historySize:   3,
+			wantReuseFail: true,
+		},
+		"previous_password": {
+			newPassword:   "0ldpassword",
+			isPrevious:    true,
+			historySize:   3,
+			wantReuseFail: true,
+		},
+		"history_disabled": {
+			newPassword: "0ldpassword",
+			isCurrent:   true,
+			historySize: 0,
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			givenPlayerID := unittests.PlayerIDFixture(st, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+			existingPlayer := existingPlayerToAuthenticateFixture(st, givenPlayerID)
+			changePassword := players.ChangePassword{
+				PlayerID:        *givenPlayerID,
+				CurrentPassword: "th1s1s@dummypwd",
+				NewPassword:     data.newPassword,
+			}
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+			storageMock.On("GetPasswordHistory", ctx, *givenPlayerID, 2).Return([][]byte{previousHash}, nil)
+			storageMock.On("ChangePassword", ctx, mock.AnythingOfType("players.PasswordChange")).Return(true, nil)
+
+			hasherMock := unittests.NewHasherMock()
+			hasherMock.On("Verify", existingPlayer.Password, "th1s1s@dummypwd").Return(true)
+			hasherMock.On("Verify", existingPlayer.Password, data.newPassword).Return(data.isCurrent)
+			hasherMock.On("Verify", previousHash, data.newPassword).Return(data.isPrevious)
+			hasherMock.On("Hash", data.newPassword).Return([]byte("$2a$04$newhash"), nil)
+
+			notifierMock := unittests.NewNotifierMock()
+			notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+			service, _ := unittests.NewPlayerServiceWithPasswordHistory(storageMock, hasherMock, notifierMock, data.historySize)
+
+			// When
+			err := service.ChangePassword(ctx, changePassword)
+
+			// Then
+			if !data.wantReuseFail {
+				assert.NoError(st, err)
+
+				return
+			}
+
+			assert.ErrorIs(st, err, players.ErrPasswordReused)
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			storageMock.AssertNotCalled(st, "ChangePassword", mock.Anything, mock.Anything)
+			notifierMock.AssertNotCalled(st, "Notify", mock.Anything)
+		})
+	}
+}
+
+func TestChangePasswordButInvalidNewPassword(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	changePassword := players.ChangePassword{
+		PlayerID:    unittests.NewPlayerID(),
+		NewPassword: "short",
+	}
+
+	want := []*players.FieldError{
+		{Field: "current_password", Description: "current password is empty"},
+		{Field: "new_password", Description: "password is shorter than 8 characters"},
+		{Field: "new_password", Description: "password must contain a digit"},
+	}
+
+	storageMock := unittests.NewStorageMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidPlayerData)
+	assert.Equal(t, want, players.FieldErrors(err))
+	storageMock.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestChangePasswordButVersionConflict(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	changePassword := players.ChangePassword{
+		PlayerID:        *givenPlayerID,
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangePassword", ctx, mock.AnythingOfType("players.PasswordChange")).Return(false, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, "th1s1s@dummypwd").Return(true)
+	hasherMock.On("Hash", "n3wpassword").Return([]byte("$2a$04$newhash"), nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestChangePasswordButStorageError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	changePassword := players.ChangePassword{
+		PlayerID:        *givenPlayerID,
+		CurrentPassword: "th1s1s@dummypwd",
+		NewPassword:     "n3wpassword",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(nil, errors.New("unexpected get error"))
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.ChangePassword(ctx, changePassword)
+
+	// Then
+	assert.EqualError(t, err, "unable to change player password: unexpected get error")
+}
@@ -0,0 +1,144 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RequestPasswordReset contains data required to send a password reset token to a player.
+type RequestPasswordReset struct {
+	Email string
+}
+
+// ConfirmPasswordReset contains data required to reset the password of a player with a token.
+type ConfirmPasswordReset struct {
+	Token       string
+	NewPassword string
+}
+
+// PasswordResetToken is a single-use token to reset the password of a player.
+// Only the hash of the token is stored, the token itself is only sent to the player.
+type PasswordResetToken struct {
+	TokenHash   string
+	PlayerID    PlayerID
+	DateExpires time.Time
+	DateCreated time.Time
+}
+
+// Mail contains a message sent to a player.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// RequestPasswordResetResult standard response for requesting a password reset.
+type RequestPasswordResetResult struct {
+	Err string
+}
+
+// ConfirmPasswordResetResult standard response for resetting a password with a token.
+type ConfirmPasswordResetResult struct {
+	Err string
+}
+
+// ErrInvalidResetToken is returned when the token does not exist, expired or was already used.
+var ErrInvalidResetToken = newFieldError(TokenField, "token is invalid or expired")
+
+// Validate checks the email is a valid address.
+func (r RequestPasswordReset) Validate() error {
+	return emailRules.validate(r.Email)
+}
+
+// Validate returns all the violations of the password reset joined.
+func (c ConfirmPasswordReset) Validate() error {
+	var err error
+
+	if c.Token == "" {
+		err = errors.Join(err, ErrEmptyToken)
+	}
+
+	return errors.Join(err, newPasswordRules.validate(c.NewPassword))
+}
+
+func (c ConfirmPasswordReset) obfuscate() ConfirmPasswordReset {
+	obfuscated := c
+	obfuscated.Token = redactedValue
+	obfuscated.NewPassword = redactedValue
+
+	return obfuscated
+}
+
+func (c ConfirmPasswordReset) toPasswordChange(player Player, hashedPassword []byte, historySize int) PasswordChange {
+	passwordChange := newPasswordChange(player, hashedPassword, historySize)
+	passwordChange.ResetTokenHash = hashToken(c.Token)
+	passwordChange.Unlock = true
+
+	return passwordChange
+}
+
+// IsExpired indicates if the token cannot be used anymore at the given date.
+func (p PasswordResetToken) IsExpired(now time.Time) bool {
+	return !p.DateExpires.After(now)
+}
+
+// newResetToken generates a random token and the PasswordResetToken to store for it.
+func newResetToken(playerID PlayerID, now time.Time, ttl time.Duration) (string, PasswordResetToken, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", PasswordResetToken{}, err
+	}
+
+	resetToken := PasswordResetToken{
+		TokenHash:   hashToken(token),
+		PlayerID:    playerID,
+		DateExpires: now.Add(ttl),
+		DateCreated: now,
+	}
+
+	return token, resetToken, nil
+}
+
+func newPasswordResetMail(player *Player, token string, expires time.Time) Mail {
+	return Mail{
+		To:      player.Email.Address,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nuse this token to reset your password: %s\n\nIt expires at %s and can be used only once. "+
+				"If you did not request it, you can ignore this message.",
+			player.Nickname, token, expires.Format(time.RFC3339),
+		),
+	}
+}
+
+// newRequestPasswordResetResult create a new RequestPasswordResetResult.
+func newRequestPasswordResetResult(err error) RequestPasswordResetResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return RequestPasswordResetResult{
+		Err: errmessage,
+	}
+}
+
+// newConfirmPasswordResetResult create a new ConfirmPasswordResetResult.
+func newConfirmPasswordResetResult(err error) ConfirmPasswordResetResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return ConfirmPasswordResetResult{
+		Err: errmessage,
+	}
+}
+
+func newPasswordResetEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player password was reset",
+	}
+}
@@ -0,0 +1,343 @@
+package players_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestPasswordReset(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	request := players.RequestPasswordReset{
+		Email: "focampo@anyemail.com",
+	}
+	ttl := time.Hour
+
+	var savedToken players.PasswordResetToken
+
+	var sentMail players.Mail
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo@anyemail.com").Return(&existingPlayer, nil)
+	storageMock.On("SavePasswordResetToken", ctx, mock.AnythingOfType("players.PasswordResetToken")).
+		Run(func(args mock.Arguments) { savedToken = args.Get(1).(players.PasswordResetToken) }).
+		Return(nil)
+
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).
+		Run(func(args mock.Arguments) { sentMail = args.Get(1).(players.Mail) }).
+		Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithMailer(storageMock, unittests.NewHasherMock(), unittests.NewNotifierMock(), mailerMock, ttl)
+
+	// When
+	err := service.RequestPasswordReset(ctx, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, *givenPlayerID, savedToken.PlayerID)
+	assert.Equal(t, ttl, savedToken.DateExpires.Sub(savedToken.DateCreated))
+	assert.Equal(t, "focampo@anyemail.com", sentMail.To)
+
+	token := tokenFromMail(t, sentMail)
+	assert.Equal(t, hashToken(token), savedToken.TokenHash)
+	assert.NotContains(t, savedToken.TokenHash, token)
+}
+
+func TestRequestPasswordResetWithPendingResets(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		pendingResets int
+		wantSent      bool
+	}{
+		"below_the_limit": {
+			pendingResets: 2,
+			wantSent:      true,
+		},
+		"at_the_limit": {
+			pendingResets: 3,
+			wantSent:      false,
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			givenPlayerID := unittests.PlayerIDFixture(st, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+			existingPlayer := existingPlayerToAuthenticateFixture(st, givenPlayerID)
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetByEmailOrNickname", ctx, "focampo@anyemail.com").Return(&existingPlayer, nil)
+			storageMock.On("CountPasswordResetTokens", ctx, *givenPlayerID, mock.AnythingOfType("time.Time")).
+				Return(data.pendingResets, nil)
+			storageMock.On("SavePasswordResetToken", ctx, mock.AnythingOfType("players.PasswordResetToken")).Return(nil)
+
+			mailerMock := unittests.NewMailerMock()
+			mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).Return(nil)
+
+			service, _ := unittests.NewPlayerServiceWithPasswordResetLimit(storageMock, mailerMock, 3)
+
+			// When
+			err := service.RequestPasswordReset(ctx, players.RequestPasswordReset{Email: "focampo@anyemail.com"})
+
+			// Then
+			// players with too many pending resets get no error, so they can't be told apart.
+			require.NoError(st, err)
+
+			if data.wantSent {
+				mailerMock.AssertNumberOfCalls(st, "Send", 1)
+				storageMock.AssertNumberOfCalls(st, "SavePasswordResetToken", 1)
+
+				return
+			}
+
+			mailerMock.AssertNotCalled(st, "Send", mock.Anything, mock.Anything)
+			storageMock.AssertNotCalled(st, "SavePasswordResetToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRequestPasswordResetButPlayerDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := players.RequestPasswordReset{
+		Email: "unknown@anyemail.com",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "unknown@anyemail.com").Return(nil, nil)
+
+	mailerMock := unittests.NewMailerMock()
+
+	service, _ := unittests.NewPlayerServiceWithMailer(storageMock, unittests.NewHasherMock(), unittests.NewNotifierMock(), mailerMock, time.Hour)
+
+	// When
+	err := service.RequestPasswordReset(ctx, request)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertNotCalled(t, "SavePasswordResetToken", mock.Anything, mock.Anything)
+	mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestRequestPasswordResetButInvalidEmail(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	request := players.RequestPasswordReset{
+		Email: "focampo",
+	}
+
+	storageMock := unittests.NewStorageMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.RequestPasswordReset(ctx, request)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidPlayerData)
+	storageMock.AssertNotCalled(t, "GetByEmailOrNickname", mock.Anything, mock.Anything)
+}
+
+func TestConfirmPasswordReset(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := existingPlayerToAuthenticateFixture(t, givenPlayerID)
+	reset := players.ConfirmPasswordReset{
+		Token:       "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+		NewPassword: "n3wpassword",
+	}
+	storedToken := players.PasswordResetToken{
+		TokenHash:   hashToken(reset.Token),
+		PlayerID:    *givenPlayerID,
+		DateExpires: time.Now().UTC().Add(time.Hour),
+		DateCreated: time.Now().UTC(),
+	}
+	newHash := []byte("$2a$04$newhash")
+
+	wantChange := func(change players.PasswordChange) bool {
+		return change.PlayerID == *givenPlayerID &&
+			change.ResetTokenHash == storedToken.TokenHash &&
+			change.Unlock &&
+			string(change.HashedPassword) == string(newHash)
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPasswordResetToken", ctx, storedToken.TokenHash).Return(&storedToken, nil)
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangePassword", ctx, mock.MatchedBy(wantChange)).Return(true, nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", "n3wpassword").Return(newHash, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player password was reset",
+	})
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+	// When
+	err := service.ConfirmPasswordReset(ctx, reset)
+
+	// Then
+	require.NoError(t, err)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestConfirmPasswordResetButInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		storedToken func(tokenHash string) *players.PasswordResetToken
+	}{
+		"unknown_token": {
+			storedToken: func(string) *players.PasswordResetToken { return nil },
+		},
+		"expired_token": {
+			storedToken: func(tokenHash string) *players.PasswordResetToken {
+				return &players.PasswordResetToken{
+					TokenHash:   tokenHash,
+					PlayerID:    unittests.NewPlayerID(),
+					DateExpires: time.Now().UTC().Add(-time.Minute),
+					DateCreated: time.Now().UTC().Add(-time.Hour),
+				}
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			reset := players.ConfirmPasswordReset{
+				Token:       "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+				NewPassword: "n3wpassword",
+			}
+			tokenHash := hashToken(reset.Token)
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetPasswordResetToken", ctx, tokenHash).Return(data.storedToken(tokenHash), nil)
+
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			err := service.ConfirmPasswordReset(ctx, reset)
+
+			// Then
+			assert.ErrorIs(st, err, players.ErrInvalidResetToken)
+			assert.Equal(st, []*players.FieldError{
+				{Field: "token", Description: "token is invalid or expired"},
+			}, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "ChangePassword", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestConfirmPasswordResetButChangedConcurrently(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tokenAfterChange func(storedToken players.PasswordResetToken) *players.PasswordResetToken
+		want             error
+	}{
+		"token_used_by_another_reset": {
+			tokenAfterChange: func(players.PasswordResetToken) *players.PasswordResetToken { return nil },
+			want:             players.ErrInvalidResetToken,
+		},
+		"token_expired": {
+			tokenAfterChange: func(storedToken players.PasswordResetToken) *players.PasswordResetToken {
+				storedToken.DateExpires = time.Now().UTC().Add(-time.Second)
+
+				return &storedToken
+			},
+			want: players.ErrInvalidResetToken,
+		},
+		"player_changed": {
+			tokenAfterChange: func(storedToken players.PasswordResetToken) *players.PasswordResetToken { return &storedToken },
+			want:             players.ErrPlayerVersionConflict,
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			givenPlayerID := unittests.PlayerIDFixture(st, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+			existingPlayer := existingPlayerToAuthenticateFixture(st, givenPlayerID)
+			reset := players.ConfirmPasswordReset{
+				Token:       "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+				NewPassword: "n3wpassword",
+			}
+			storedToken := players.PasswordResetToken{
+				TokenHash:   hashToken(reset.Token),
+				PlayerID:    *givenPlayerID,
+				DateExpires: time.Now().UTC().Add(time.Hour),
+			}
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetPasswordResetToken", ctx, storedToken.TokenHash).Return(&storedToken, nil).Once()
+			storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+			storageMock.On("ChangePassword", ctx, mock.AnythingOfType("players.PasswordChange")).Return(false, nil)
+			storageMock.On("GetPasswordResetToken", ctx, storedToken.TokenHash).Return(data.tokenAfterChange(storedToken), nil)
+
+			hasherMock := unittests.NewHasherMock()
+			hasherMock.On("Hash", "n3wpassword").Return([]byte("$2a$04$newhash"), nil)
+
+			notifierMock := unittests.NewNotifierMock()
+
+			service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+
+			// When
+			err := service.ConfirmPasswordReset(ctx, reset)
+
+			// Then
+			assert.ErrorIs(st, err, data.want)
+			notifierMock.AssertNotCalled(st, "Notify", mock.Anything)
+		})
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func tokenFromMail(t *testing.T, mail players.Mail) string {
+	t.Helper()
+
+	_, after, found := strings.Cut(mail.Body, "use this token to ")
+	require.True(t, found)
+
+	_, after, found = strings.Cut(after, ": ")
+	require.True(t, found)
+
+	token, _, _ := strings.Cut(after, "\n")
+
+	return token
+}
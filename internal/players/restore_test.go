@@ -0,0 +1,182 @@
+package players_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const deletionRetention = 30 * 24 * time.Hour
+
+func TestRestorePlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	deletedPlayer := deletedPlayerFixture(t, givenPlayerID, time.Now().UTC().Add(-time.Hour))
+	givenFilter := players.PlayerFilter{
+		Email:    "focampo@anyemail.com",
+		Nickname: "focampo",
+		IgnoreID: givenPlayerID,
+	}
+
+	wantEvent := players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player was restored",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetDeletedByID", ctx, *givenPlayerID).Return(&deletedPlayer, nil)
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, givenFilter).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("Restore", ctx, *givenPlayerID).Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", wantEvent)
+
+	service, _ := unittests.NewPlayerServiceWithDeletionRetention(storageMock, notifierMock, deletionRetention)
+
+	// When
+	err := service.Restore(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRestorePlayerButRetentionWindowExpired(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	deletedPlayer := deletedPlayerFixture(t, givenPlayerID, time.Now().UTC().Add(-deletionRetention-time.Hour))
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetDeletedByID", ctx, *givenPlayerID).Return(&deletedPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithDeletionRetention(storageMock, unittests.NewNotifierMock(), deletionRetention)
+
+	// When
+	err := service.Restore(ctx, *givenPlayerID)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrRestoreWindowExpired)
+	storageMock.AssertNotCalled(t, "Restore", ctx, *givenPlayerID)
+}
+
+func TestRestorePlayerButEmailWasTaken(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	deletedPlayer := deletedPlayerFixture(t, givenPlayerID, time.Now().UTC().Add(-time.Hour))
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetDeletedByID", ctx, *givenPlayerID).Return(&deletedPlayer, nil)
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).
+		Return(&players.PlayerExistResult{EmailExist: true}, nil)
+
+	service, _ := unittests.NewPlayerServiceWithDeletionRetention(storageMock, unittests.NewNotifierMock(), deletionRetention)
+
+	// When
+	err := service.Restore(ctx, *givenPlayerID)
+
+	// Then
+	var alreadyExistsErr *players.PlayerAlreadyExistsError
+	assert.ErrorAs(t, err, &alreadyExistsErr)
+	assert.True(t, alreadyExistsErr.WasEmail)
+	storageMock.AssertNotCalled(t, "Restore", ctx, *givenPlayerID)
+}
+
+func TestRestorePlayerWithEndpointButDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+
+	want := players.RestorePlayerResult{
+		Err: "unable to restore player: player doesn't exist",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetDeletedByID", ctx, *givenPlayerID).Return(nil, nil)
+
+	service, logger := unittests.NewPlayerServiceWithDeletionRetention(storageMock, unittests.NewNotifierMock(), deletionRetention)
+	restorePlayerEndpoint := players.MakeRestorePlayerEndpoint(service, logger)
+
+	// When
+	got, err := restorePlayerEndpoint.Do(ctx, *givenPlayerID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestPurgeDeletedPlayers(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	purgedIDs := []players.PlayerID{unittests.NewPlayerID(), unittests.NewPlayerID()}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("PurgeDeletedBefore", ctx, mock.AnythingOfType("time.Time"), 100).Return(purgedIDs, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", players.NewEvent{PlayerID: purgedIDs[0].String(), Event: "player was purged"})
+	notifierMock.On("Notify", players.NewEvent{PlayerID: purgedIDs[1].String(), Event: "player was purged"})
+
+	service, _ := unittests.NewPlayerServiceWithDeletionRetention(storageMock, notifierMock, deletionRetention)
+
+	// When
+	got, err := service.PurgeDeletedPlayers(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+	notifierMock.AssertExpectations(t)
+	storageMock.AssertCalled(t, "PurgeDeletedBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().UTC().Add(-deletionRetention + time.Minute))
+	}), 100)
+}
+
+func TestPurgeDeletedPlayersButError(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("PurgeDeletedBefore", ctx, mock.AnythingOfType("time.Time"), 100).
+		Return(nil, errors.New("unexpected purge error"))
+
+	service, _ := unittests.NewPlayerServiceWithDeletionRetention(storageMock, unittests.NewNotifierMock(), deletionRetention)
+
+	// When
+	got, err := service.PurgeDeletedPlayers(ctx)
+
+	// Then
+	assert.EqualError(t, err, "unable to purge deleted players: unexpected purge error")
+	assert.Zero(t, got)
+}
+
+func deletedPlayerFixture(t *testing.T, playerID *players.PlayerID, dateDeleted time.Time) players.Player {
+	t.Helper()
+
+	return players.Player{
+		ID:          playerID,
+		FirstName:   "Fernando",
+		LastName:    "Ocampo",
+		Nickname:    "focampo",
+		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
+		Country:     "Spain",
+		DateCreated: time.Now().UTC(),
+		DateUpdated: time.Now().UTC(),
+		DateDeleted: &dateDeleted,
+	}
+}
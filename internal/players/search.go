@@ -0,0 +1,171 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DateRange filters dates between From and To, both included. A zero date leaves
+// that side of the range open.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// SortField player field search results can be sorted by.
+type SortField string
+
+// sort fields.
+const (
+	SortByNickname    SortField = "nickname"
+	SortByFirstName   SortField = "firstname"
+	SortByLastName    SortField = "lastname"
+	SortByCountry     SortField = "country"
+	SortByStatus      SortField = "status"
+	SortByDateCreated SortField = "date_created"
+	SortByDateUpdated SortField = "date_updated"
+)
+
+// SortKey sorts search results by a player field.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// search field names used in validation errors.
+const (
+	NicknamePrefixField = "nickname_prefix"
+	DateCreatedField    = "date_created"
+	DateUpdatedField    = "date_updated"
+	SortKeysField       = "sort"
+	FuzzyField          = "fuzzy"
+)
+
+var (
+	// ErrEmailSearchNotAllowed is returned when a player that is not an admin searches players by email.
+	ErrEmailSearchNotAllowed   = errors.New("only admins can search players by email")
+	ErrInvalidDateCreatedRange = newFieldError(DateCreatedField, "date created range ends before it starts")
+	ErrInvalidDateUpdatedRange = newFieldError(DateUpdatedField, "date updated range ends before it starts")
+)
+
+// sortFields fields search results can be sorted by, other fields are rejected.
+var sortFields = map[SortField]struct{}{
+	SortByNickname:    {},
+	SortByFirstName:   {},
+	SortByLastName:    {},
+	SortByCountry:     {},
+	SortByStatus:      {},
+	SortByDateCreated: {},
+	SortByDateUpdated: {},
+}
+
+// text filters of the search criteria, they are limited as the player fields they are compared with.
+var (
+	nicknamePrefixRules = fieldRules{
+		field: NicknamePrefixField,
+		rules: []rule{
+			maxLength("nickname prefix", maxNicknameLength, CanonicalNickname),
+			withoutControlCharacters("nickname prefix"),
+		},
+	}
+	firstNameFilterRules = fieldRules{
+		field: FirstNameField,
+		rules: []rule{
+			maxLength("first name", maxNameLength),
+			withoutControlCharacters("first name"),
+		},
+	}
+	lastNameFilterRules = fieldRules{
+		field: LastNameField,
+		rules: []rule{
+			maxLength("last name", maxNameLength),
+			withoutControlCharacters("last name"),
+		},
+	}
+	fuzzyRules = fieldRules{
+		field: FuzzyField,
+		rules: []rule{
+			maxLength("fuzzy text", maxNameLength),
+			withoutControlCharacters("fuzzy text"),
+		},
+	}
+)
+
+// Validate returns all the violations of the search criteria joined.
+func (s SearchCriteria) Validate() error {
+	var err error
+
+	if s.Status != nil && !s.Status.IsValid() {
+		err = errors.Join(err, ErrInvalidStatus)
+	}
+
+	if hasValue(s.NicknamePrefix) {
+		err = errors.Join(err, nicknamePrefixRules.validate(*s.NicknamePrefix))
+	}
+
+	if hasValue(s.FirstName) {
+		err = errors.Join(err, firstNameFilterRules.validate(*s.FirstName))
+	}
+
+	if hasValue(s.LastName) {
+		err = errors.Join(err, lastNameFilterRules.validate(*s.LastName))
+	}
+
+	if hasValue(s.Fuzzy) {
+		err = errors.Join(err, fuzzyRules.validate(*s.Fuzzy))
+	}
+
+	if !s.DateCreated.isValid() {
+		err = errors.Join(err, ErrInvalidDateCreatedRange)
+	}
+
+	if !s.DateUpdated.isValid() {
+		err = errors.Join(err, ErrInvalidDateUpdatedRange)
+	}
+
+	err = errors.Join(err, s.validatePagination())
+
+	return errors.Join(err, validateSort(s.Sort))
+}
+
+// validateSort checks the sort keys use supported fields and each field only once.
+func validateSort(sortKeys []SortKey) error {
+	var err error
+
+	seen := make(map[SortField]struct{}, len(sortKeys))
+
+	for _, sortKey := range sortKeys {
+		if _, ok := sortFields[sortKey.Field]; !ok {
+			err = errors.Join(err, newFieldError(SortKeysField, fmt.Sprintf("sort field %q is not supported", sortKey.Field)))
+
+			continue
+		}
+
+		if _, ok := seen[sortKey.Field]; ok {
+			err = errors.Join(err, newFieldError(SortKeysField, fmt.Sprintf("sort field %q is repeated", sortKey.Field)))
+		}
+
+		seen[sortKey.Field] = struct{}{}
+	}
+
+	return err
+}
+
+// allowed indicates if the filters of the search criteria can be used by the searcher.
+func (s SearchCriteria) allowed() error {
+	if hasValue(s.Email) && !s.Admin {
+		return ErrEmailSearchNotAllowed
+	}
+
+	return nil
+}
+
+// IsEmpty indicates if the range does not filter any date.
+func (d DateRange) IsEmpty() bool {
+	return d.From.IsZero() && d.To.IsZero()
+}
+
+func (d DateRange) isValid() bool {
+	return d.From.IsZero() || d.To.IsZero() || !d.To.Before(d.From)
+}
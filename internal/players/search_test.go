@@ -3,7 +3,9 @@ package players_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fernandoocampo/players/internal/appkit/unittests"
 	"github.com/fernandoocampo/players/internal/players"
@@ -141,3 +143,265 @@ func TestSearchPlayersWithEndpointButError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, want, got)
 }
+
+func TestSearchWithMultipleFilters(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	createdFrom := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	searchCriteria := players.SearchCriteria{
+		NicknamePrefix: players.NewString("foc"),
+		LastName:       players.NewString("camp"),
+		Email:          players.NewString("focampo@anyemail.com"),
+		DateCreated:    players.DateRange{From: createdFrom},
+		Admin:          true,
+	}
+
+	wantCriteria := searchCriteria
+	wantCriteria.Limit = 5
+
+	searchResult := players.SearchResult{
+		Total: 1,
+		Items: unittests.SearchPlayersResultFixture(t)[:1],
+		Limit: 5,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", ctx, wantCriteria).Return(&searchResult, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &searchResult, got)
+	storageMock.AssertExpectations(t)
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Fuzzy: players.NewString("jraynr"),
+	}
+
+	wantCriteria := searchCriteria
+	wantCriteria.Limit = 5
+
+	items := unittests.SearchPlayersResultFixture(t)[:1]
+	items[0].Score = 0.625
+	searchResult := players.SearchResult{
+		Total: 1,
+		Items: items,
+		Limit: 5,
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", ctx, wantCriteria).Return(&searchResult, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &searchResult, got)
+	storageMock.AssertExpectations(t)
+}
+
+func TestSearchByEmailButNotAdmin(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Email: players.NewString("focampo@anyemail.com"),
+	}
+
+	storageMock := unittests.NewStorageMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, players.ErrEmailSearchNotAllowed)
+	storageMock.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestSearchButInvalidCriteria(t *testing.T) {
+	t.Parallel()
+
+	createdFrom := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		searchCriteria players.SearchCriteria
+		want           []*players.FieldError
+	}{
+		"nickname_prefix_too_long": {
+			searchCriteria: players.SearchCriteria{NicknamePrefix: players.NewString(strings.Repeat("f", 65))},
+			want: []*players.FieldError{
+				{Field: "nickname_prefix", Description: "nickname prefix is longer than 64 characters"},
+			},
+		},
+		"first_name_with_control_characters": {
+			searchCriteria: players.SearchCriteria{FirstName: players.NewString("Fer\x00nando")},
+			want: []*players.FieldError{
+				{Field: "firstname", Description: "first name contains control characters"},
+			},
+		},
+		"fuzzy_text_with_control_characters": {
+			searchCriteria: players.SearchCriteria{Fuzzy: players.NewString("jray\x07nor")},
+			want: []*players.FieldError{
+				{Field: "fuzzy", Description: "fuzzy text contains control characters"},
+			},
+		},
+		"unsupported_sort_fields": {
+			searchCriteria: players.SearchCriteria{
+				Country: players.NewString("CO"),
+				Sort: []players.SortKey{
+					{Field: players.SortByNickname},
+					{Field: "usrpwd"},
+					{Field: players.SortByNickname, Descending: true},
+				},
+			},
+			want: []*players.FieldError{
+				{Field: "sort", Description: "sort field \"usrpwd\" is not supported"},
+				{Field: "sort", Description: "sort field \"nickname\" is repeated"},
+			},
+		},
+		"date_ranges_end_before_they_start": {
+			searchCriteria: players.SearchCriteria{
+				DateCreated: players.DateRange{From: createdFrom, To: createdFrom.Add(-time.Hour)},
+				DateUpdated: players.DateRange{From: createdFrom, To: createdFrom.Add(-time.Second)},
+			},
+			want: []*players.FieldError{
+				{Field: "date_created", Description: "date created range ends before it starts"},
+				{Field: "date_updated", Description: "date updated range ends before it starts"},
+			},
+		},
+		"limit_greater_than_max": {
+			searchCriteria: players.SearchCriteria{Country: players.NewString("CO"), Limit: 101},
+			want: []*players.FieldError{
+				{Field: "limit", Description: "limit is greater than 100"},
+			},
+		},
+		"limit_greater_than_max_without_filters": {
+			searchCriteria: players.SearchCriteria{Limit: 101},
+			want: []*players.FieldError{
+				{Field: "limit", Description: "limit is greater than 100"},
+			},
+		},
+		"list_all_without_limit": {
+			searchCriteria: players.SearchCriteria{ListAll: true},
+			want: []*players.FieldError{
+				{Field: "limit", Description: "listing all players requires a limit"},
+			},
+		},
+		"page_token_with_offset": {
+			searchCriteria: players.SearchCriteria{Country: players.NewString("CO"), PageToken: "token", Offset: 10},
+			want: []*players.FieldError{
+				{Field: "page_token", Description: "page token can't be combined with an offset"},
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			storageMock := unittests.NewStorageMock()
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			got, err := service.List(ctx, data.searchCriteria)
+
+			// Then
+			assert.Nil(st, got)
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			assert.Equal(st, data.want, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "Search", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSearchListingAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		searchCriteria players.SearchCriteria
+		callsStorage   bool
+	}{
+		"list_all": {
+			searchCriteria: players.SearchCriteria{ListAll: true, Limit: 20},
+			callsStorage:   true,
+		},
+		"list_all_without_total": {
+			searchCriteria: players.SearchCriteria{ListAll: true, Limit: 20, SkipTotal: true},
+			callsStorage:   true,
+		},
+		"without_criteria": {
+			searchCriteria: players.SearchCriteria{Limit: 20},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("Search", ctx, data.searchCriteria).Return(&players.SearchResult{Limit: 20}, nil)
+
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			got, err := service.List(ctx, data.searchCriteria)
+
+			// Then
+			assert.NoError(st, err)
+			assert.NotNil(st, got)
+
+			if data.callsStorage {
+				storageMock.AssertExpectations(st)
+			} else {
+				storageMock.AssertNotCalled(st, "Search", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	searchCriteria := players.SearchCriteria{
+		Country: players.NewString("CO"),
+		Sort: []players.SortKey{
+			{Field: players.SortByDateCreated, Descending: true},
+			{Field: players.SortByNickname},
+		},
+	}
+
+	wantCriteria := searchCriteria
+	wantCriteria.Limit = 5
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("Search", ctx, wantCriteria).Return(&players.SearchResult{Limit: 5}, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.List(ctx, searchCriteria)
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+	storageMock.AssertExpectations(t)
+}
@@ -2,8 +2,10 @@ package players
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 )
 
 // ServiceSetup encapsulates service parameters.
@@ -14,7 +16,27 @@ type ServiceSetup struct {
 	Notifier Notifier
 	// password hasher
 	Hasher Hasher
-	Logger *slog.Logger
+	// LockoutPolicy rules to lock players after failed authentications.
+	LockoutPolicy LockoutPolicy
+	// DeletionRetention time a deleted player can be restored before being purged.
+	DeletionRetention time.Duration
+	// PasswordHistorySize number of last passwords, including the current one,
+	// a player cannot reuse. Zero disables the check.
+	PasswordHistorySize int
+	// Mailer delivers password reset and email verification tokens to players.
+	Mailer Mailer
+	// PasswordResetTokenTTL time a password reset token can be used.
+	PasswordResetTokenTTL time.Duration
+	// MaxPendingPasswordResets number of unexpired password reset tokens a player can have, further
+	// requests are ignored so players' mailboxes can't be flooded. Zero or negative values disable the limit.
+	MaxPendingPasswordResets int
+	// EmailVerificationTokenTTL time an email verification token can be used.
+	EmailVerificationTokenTTL time.Duration
+	// IdempotencyKeyTTL time a create request can be retried with the same idempotency key.
+	IdempotencyKeyTTL time.Duration
+	// PageTokenKey signs the page tokens of the searches, so clients can't change them.
+	PageTokenKey []byte
+	Logger       *slog.Logger
 }
 
 // Service defines business logic for this service.
@@ -25,23 +47,70 @@ type Service struct {
 	hasher Hasher
 	// player events notifier
 	notifier Notifier
-	logger   *slog.Logger
+	// rules to lock players after failed authentications.
+	lockoutPolicy LockoutPolicy
+	// time a deleted player can be restored before being purged.
+	deletionRetention time.Duration
+	// number of last passwords, including the current one, a player cannot reuse.
+	passwordHistorySize int
+	mailer              Mailer
+	// time a password reset token can be used.
+	passwordResetTokenTTL time.Duration
+	// number of unexpired password reset tokens a player can have.
+	maxPendingPasswordResets int
+	// time an email verification token can be used.
+	emailVerificationTokenTTL time.Duration
+	// time a create request can be retried with the same idempotency key.
+	idempotencyKeyTTL time.Duration
+	// key to sign the page tokens of the searches.
+	pageTokenKey []byte
+	logger       *slog.Logger
+	// dummyHash is verified when the player to authenticate does not exist,
+	// so authentication takes about the same time for known and unknown players.
+	dummyHash []byte
 }
 
-// NewService create a new service instance.
-func NewService(setup *ServiceSetup) *Service {
+// dummyPassword is only used to generate the dummy hash.
+const dummyPassword = "players-dummy-password"
+
+// purgeBatchSize max number of players purged per storage call.
+const purgeBatchSize = 100
+
+// NewService create a new service instance. It fails if the dummy password can't be hashed
+// with the hasher, authentications of unknown players need it.
+func NewService(setup *ServiceSetup) (*Service, error) {
 	newService := Service{
-		storage:  setup.Storage,
-		hasher:   setup.Hasher,
-		notifier: setup.Notifier,
-		logger:   setup.Logger,
+		storage:                   setup.Storage,
+		hasher:                    setup.Hasher,
+		notifier:                  setup.Notifier,
+		lockoutPolicy:             setup.LockoutPolicy,
+		deletionRetention:         setup.DeletionRetention,
+		passwordHistorySize:       setup.PasswordHistorySize,
+		mailer:                    setup.Mailer,
+		passwordResetTokenTTL:     setup.PasswordResetTokenTTL,
+		maxPendingPasswordResets:  setup.MaxPendingPasswordResets,
+		emailVerificationTokenTTL: setup.EmailVerificationTokenTTL,
+		idempotencyKeyTTL:         setup.IdempotencyKeyTTL,
+		pageTokenKey:              setup.PageTokenKey,
+		logger:                    setup.Logger,
+	}
+
+	// services that don't authenticate players, e.g. to run a command, may not have a hasher.
+	if setup.Hasher != nil {
+		dummyHash, err := setup.Hasher.Hash(dummyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash dummy password: %w", err)
+		}
+
+		newService.dummyHash = dummyHash
 	}
 
-	return &newService
+	return &newService, nil
 }
 
 // Create creates a new player with the given data. Validates new player information and verify
-// that other players with same email or nickname already exist.
+// that other players with same email or nickname already exist. Retries of a request with an
+// idempotency key get the reply of the first request.
 func (s *Service) Create(ctx context.Context, newPlayer NewPlayer) (*Player, error) {
 	s.logger.Debug("starting to create a new player", slog.Any("new_player", newPlayer.obfuscate()))
 
@@ -50,6 +119,17 @@ func (s *Service) Create(ctx context.Context, newPlayer NewPlayer) (*Player, err
 		return nil, fmt.Errorf("unable to create player: %w", err)
 	}
 
+	if newPlayer.IdempotencyKey != "" {
+		replayedPlayer, err := s.replayCreate(ctx, newPlayer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create player: %w", err)
+		}
+
+		if replayedPlayer != nil {
+			return replayedPlayer, nil
+		}
+	}
+
 	hashedPassword, err := s.hasher.Hash(newPlayer.Password)
 	if err != nil {
 		s.logger.Error("hashing password", "error", err.Error())
@@ -74,7 +154,21 @@ func (s *Service) Create(ctx context.Context, newPlayer NewPlayer) (*Player, err
 
 	player := newPlayer.toPlayer(hashedPassword)
 
-	err = s.storage.Save(ctx, player)
+	// the check above is an optimisation, the storage rejects players created concurrently
+	// with the same email or nickname.
+	err = s.save(ctx, newPlayer, player)
+	if errors.Is(err, ErrIdempotencyKeyExists) {
+		s.logger.Debug("player with the same idempotency key was created concurrently", slog.Any("id", player.ID))
+
+		return s.replayConcurrentCreate(ctx, newPlayer)
+	}
+
+	if errors.Is(err, ErrPlayerAlreadyExists) {
+		s.logger.Debug("player with the given email or nickname was created concurrently", slog.Any("id", player.ID))
+
+		return nil, err
+	}
+
 	if err != nil {
 		s.logger.Error("creating player", "error", err)
 
@@ -85,19 +179,71 @@ func (s *Service) Create(ctx context.Context, newPlayer NewPlayer) (*Player, err
 
 	s.notifier.Notify(newCreatePlayerEvent(player.ID))
 
+	s.sendEmailVerification(ctx, &player, player.Email.Address)
+
 	return &player, nil
 }
 
+// save stores the new player with its idempotency key, if any.
+func (s *Service) save(ctx context.Context, newPlayer NewPlayer, player Player) error {
+	if newPlayer.IdempotencyKey == "" {
+		return s.storage.Save(ctx, player)
+	}
+
+	return s.storage.SaveWithIdempotencyKey(ctx, player, newPlayer.toIdempotencyRecord(player, s.idempotencyKeyTTL))
+}
+
+// replayCreate returns the reply of a previous request with the same idempotency key, nil if the key
+// was not used or it expired. It returns ErrIdempotencyKeyReused if the previous request was different.
+func (s *Service) replayCreate(ctx context.Context, newPlayer NewPlayer) (*Player, error) {
+	record, err := s.storage.GetIdempotencyRecord(ctx, newPlayer.Source, newPlayer.IdempotencyKey)
+	if err != nil {
+		s.logger.Error("getting idempotency record", slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	if record == nil || record.IsExpired(time.Now().UTC()) {
+		return nil, nil
+	}
+
+	if record.Fingerprint != newPlayer.fingerprint() || !s.hasher.Verify(record.HashedPassword, newPlayer.Password) {
+		s.logger.Debug("idempotency key was used with a different request", slog.String("id", record.Reply.ID.String()))
+
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	s.logger.Debug("replaying player created with the same idempotency key", slog.String("id", record.Reply.ID.String()))
+
+	return &record.Reply, nil
+}
+
+// replayConcurrentCreate returns the player created by a concurrent request with the same idempotency key.
+func (s *Service) replayConcurrentCreate(ctx context.Context, newPlayer NewPlayer) (*Player, error) {
+	replayedPlayer, err := s.replayCreate(ctx, newPlayer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create player: %w", err)
+	}
+
+	if replayedPlayer == nil {
+		return nil, fmt.Errorf("unable to create player: %w", ErrIdempotencyKeyExists)
+	}
+
+	return replayedPlayer, nil
+}
+
 // Update updates existing player with the given data. Validates the new player data and verify
 // that other players with same email or nickname already exist.
 func (s *Service) Update(ctx context.Context, updatePlayer UpdatePlayer) (*Player, error) {
-	s.logger.Debug("starting to update player", slog.Any("player", updatePlayer.obfuscate()))
+	s.logger.Debug("starting to update player", slog.Any("player", updatePlayer))
 
 	err := updatePlayer.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("unable to update player: %w", err)
 	}
 
+	updatePlayer = updatePlayer.masked()
+
 	if updatePlayer.updateKeyValues() {
 		playerExistResult, err := s.doesThePlayerAlreadyExist(ctx, updatePlayer.toPlayerFilter())
 		if err != nil {
@@ -120,98 +266,1522 @@ func (s *Service) Update(ctx context.Context, updatePlayer UpdatePlayer) (*Playe
 		return nil, ErrPlayerDoesNotExist
 	}
 
-	playerToUpdate, err := updatePlayer.toPlayer(*player, s.hasher)
-	if err != nil {
-		s.logger.Error("converting updateplayer to player", slog.String("id", updatePlayer.ID.String()), slog.String("error", err.Error()))
-
-		return nil, fmt.Errorf("unable to update player: %w", err)
+	if player.isStale(updatePlayer.ExpectedVersion) {
+		return nil, fmt.Errorf("unable to update player: %w", ErrPlayerVersionConflict)
 	}
 
+	playerToUpdate := updatePlayer.toPlayer(*player)
+
 	if !playerToUpdate.changes {
 		s.logger.Debug("there is nothing to update in the player", slog.Any("player", player.obfuscate()))
 
 		return player, nil
 	}
 
-	err = s.storage.Update(ctx, *playerToUpdate.player)
+	// the version read is used to detect concurrent changes between the read and the write.
+	updated, err := s.storage.Update(ctx, *playerToUpdate.player)
+	if errors.Is(err, ErrPlayerAlreadyExists) {
+		s.logger.Debug("player with the given email or nickname was stored concurrently", slog.Any("id", player.ID))
+
+		return nil, err
+	}
+
 	if err != nil {
 		s.logger.Error("updating player", "error", err)
 
 		return nil, fmt.Errorf("unable to update player: %w", err)
 	}
 
+	if !updated {
+		return nil, fmt.Errorf("unable to update player: %w", ErrPlayerVersionConflict)
+	}
+
+	playerToUpdate.player.Version++
+
 	s.logger.Debug("player was updated", slog.Any("id", player.ID))
 
 	s.notifier.Notify(newUpdatePlayerEvent(player.ID))
 
+	if playerToUpdate.emailToVerify != "" {
+		s.sendEmailVerification(ctx, playerToUpdate.player, playerToUpdate.emailToVerify)
+	}
+
 	return playerToUpdate.player, nil
 }
 
-func (s *Service) Delete(ctx context.Context, playerID PlayerID) error {
-	s.logger.Debug("starting to delete player", slog.Any("player_id", playerID.String()))
+// Get gets the player with the given id.
+func (s *Service) Get(ctx context.Context, playerID PlayerID) (*Player, error) {
+	s.logger.Debug("starting to get player", slog.String("player_id", playerID.String()))
 
 	player, err := s.storage.GetByID(ctx, playerID)
 	if err != nil {
 		s.logger.Error("getting player by id", slog.String("id", playerID.String()), slog.String("error", err.Error()))
 
-		return fmt.Errorf("unable to delete player: %w", err)
+		return nil, fmt.Errorf("unable to get player: %w", err)
 	}
 
 	if player == nil {
-		return ErrPlayerDoesNotExist
+		return nil, ErrPlayerDoesNotExist
+	}
+
+	return player, nil
+}
+
+// Authenticate verifies the given credentials against the stored password hash and returns
+// the id of the authenticated player. Wrong credentials return ErrInvalidCredentials, even if
+// the player is locked, so it cannot be used to find out which players exist. The right password
+// of a player that is not active returns ErrPlayerIsNotActive instead, only its owner can tell.
+func (s *Service) Authenticate(ctx context.Context, credentials Credentials) (*PlayerID, error) {
+	s.logger.Debug("starting to authenticate player", slog.Any("credentials", credentials.obfuscate()))
+
+	err := credentials.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate player: %w", err)
 	}
 
-	err = s.storage.Delete(ctx, playerID)
+	err = s.checkSourceThrottle(ctx, credentials.Source)
 	if err != nil {
-		s.logger.Error("deleting player", "error", err)
+		return nil, err
+	}
 
-		return fmt.Errorf("unable to delete player: %w", err)
+	player, err := s.storage.GetByEmailOrNickname(ctx, credentials.Login)
+	if err != nil {
+		s.logger.Error("getting player by email or nickname", slog.String("login", credentials.Login), slog.String("error", err.Error()))
+
+		return nil, fmt.Errorf("unable to authenticate player: %w", err)
 	}
 
-	s.logger.Debug("player was deleted", slog.Any("id", playerID))
+	if player == nil {
+		s.hasher.Verify(s.dummyHash, credentials.Password)
 
-	s.notifier.Notify(newDeletePlayerEvent(playerID))
+		s.logger.Debug("player to authenticate does not exist", slog.String("login", credentials.Login))
+
+		s.saveLoginAttempt(ctx, newLoginAttempt(nil, credentials.Source, false))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if player.LockState.IsLocked(time.Now().UTC()) {
+		s.hasher.Verify(s.dummyHash, credentials.Password)
+
+		s.logger.Debug("player to authenticate is locked", slog.String("id", player.ID.String()))
+
+		s.saveLoginAttempt(ctx, newLoginAttempt(player.ID, credentials.Source, false))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if !s.hasher.Verify(player.Password, credentials.Password) {
+		s.logger.Debug("player password does not match", slog.String("id", player.ID.String()))
+
+		return nil, s.registerFailedAuthentication(ctx, player, credentials.Source)
+	}
+
+	if player.Status.Current(time.Now().UTC()) != StatusActive {
+		s.logger.Debug("player to authenticate is not active", slog.String("id", player.ID.String()), slog.String("status", string(player.Status.Status)))
+
+		s.saveLoginAttempt(ctx, newLoginAttempt(player.ID, credentials.Source, false))
+
+		return nil, ErrPlayerIsNotActive
+	}
+
+	err = s.registerSuccessfulAuthentication(ctx, player, credentials.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rehashPassword(ctx, player, credentials.Password)
+
+	s.logger.Debug("player was authenticated", slog.String("id", player.ID.String()))
+
+	return player.ID, nil
+}
+
+// GetLockState gets the lock state of the player with the given id.
+func (s *Service) GetLockState(ctx context.Context, playerID PlayerID) (*LockState, error) {
+	player, err := s.Get(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get player lock state: %w", err)
+	}
+
+	return &player.LockState, nil
+}
+
+// Unlock removes the lock and failed authentication attempts of the player with the given id.
+func (s *Service) Unlock(ctx context.Context, playerID PlayerID) error {
+	s.logger.Debug("starting to unlock player", slog.String("player_id", playerID.String()))
+
+	player, err := s.Get(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("unable to unlock player: %w", err)
+	}
+
+	if !player.LockState.hasFailedAttempts() {
+		return nil
+	}
+
+	err = s.storage.UpdateLockState(ctx, playerID, newUnlockedState())
+	if err != nil {
+		s.logger.Error("unlocking player", slog.String("id", playerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to unlock player: %w", err)
+	}
+
+	s.logger.Debug("player was unlocked", slog.String("id", playerID.String()))
+
+	s.notifier.Notify(newPlayerUnlockedEvent(playerID))
 
 	return nil
 }
 
-func (s *Service) doesThePlayerAlreadyExist(ctx context.Context, playerFilter PlayerFilter) (*PlayerExistResult, error) {
-	s.logger.Debug(
-		"checking if a player with the given email and nickname already exists",
-		slog.String("email", playerFilter.Email),
-		slog.String("nickname", playerFilter.Nickname),
-	)
+// checkSourceThrottle returns ErrTooManyLoginAttempts if the given source
+// exceeded the allowed failed authentication attempts.
+func (s *Service) checkSourceThrottle(ctx context.Context, source string) error {
+	if source == "" || !s.lockoutPolicy.throttleSourcesEnabled() {
+		return nil
+	}
 
-	playerWithEmailOrNickname, err := s.storage.GetPlayersWithEmailOrNickName(ctx, playerFilter)
+	since := time.Now().UTC().Add(-s.lockoutPolicy.SourceWindow)
+
+	failedAttempts, err := s.storage.CountFailedLoginAttemptsBySource(ctx, source, since)
 	if err != nil {
-		s.logger.Error(
-			"checking if a player with the given email or nickname already exists",
-			slog.String("email", playerFilter.Email),
-			slog.String("nickname", playerFilter.Nickname),
-			slog.String("error", err.Error()),
-		)
+		s.logger.Error("counting failed login attempts by source", slog.String("source", source), slog.String("error", err.Error()))
 
-		return nil, fmt.Errorf("unable to check if player already exists: %w", err)
+		return fmt.Errorf("unable to authenticate player: %w", err)
 	}
 
-	return playerWithEmailOrNickname, nil
+	if failedAttempts >= s.lockoutPolicy.MaxFailedAttemptsPerSource {
+		s.logger.Debug("source exceeded failed login attempts", slog.String("source", source), slog.Int("attempts", failedAttempts))
+
+		return ErrTooManyLoginAttempts
+	}
+
+	return nil
 }
 
-func (s *Service) List(ctx context.Context, searchCriteria SearchCriteria) (*SearchResult, error) {
-	s.logger.Debug("starting to search players", slog.Any("criteria", searchCriteria))
+// registerFailedAuthentication counts the failed attempt and locks the player if
+// the lockout policy says so. It always returns an error.
+func (s *Service) registerFailedAuthentication(ctx context.Context, player *Player, source string) error {
+	s.saveLoginAttempt(ctx, newLoginAttempt(player.ID, source, false))
 
-	if searchCriteria.isEmpty() {
-		return newEmptySearchResult(), nil
+	if !s.lockoutPolicy.lockPlayersEnabled() {
+		return ErrInvalidCredentials
 	}
 
-	searchCriteria.setDefaultPaginationIfEmpty()
+	now := time.Now().UTC()
 
-	result, err := s.storage.Search(ctx, searchCriteria)
+	failedAttempts, err := s.storage.IncrementFailedLoginAttempts(ctx, *player.ID, now)
 	if err != nil {
-		s.logger.Error("searching players", slog.String("error", err.Error()))
+		s.logger.Error("incrementing failed login attempts", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
 
-		return nil, fmt.Errorf("unable to list players: %w", err)
+		return fmt.Errorf("unable to authenticate player: %w", err)
 	}
 
-	return result, nil
+	if failedAttempts < s.lockoutPolicy.MaxFailedAttempts {
+		return ErrInvalidCredentials
+	}
+
+	lockedUntil := now.Add(s.lockoutPolicy.LockDuration)
+	lockState := LockState{
+		FailedAttempts: failedAttempts,
+		LockedUntil:    &lockedUntil,
+	}
+
+	err = s.storage.UpdateLockState(ctx, *player.ID, lockState)
+	if err != nil {
+		s.logger.Error("locking player", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to authenticate player: %w", err)
+	}
+
+	s.logger.Info("player was locked", slog.String("id", player.ID.String()), slog.Time("until", lockedUntil))
+
+	s.notifier.Notify(newPlayerLockedEvent(player.ID))
+
+	return ErrInvalidCredentials
+}
+
+// registerSuccessfulAuthentication saves the attempt and resets previous failed attempts.
+func (s *Service) registerSuccessfulAuthentication(ctx context.Context, player *Player, source string) error {
+	s.saveLoginAttempt(ctx, newLoginAttempt(player.ID, source, true))
+
+	if !player.LockState.hasFailedAttempts() {
+		return nil
+	}
+
+	err := s.storage.UpdateLockState(ctx, *player.ID, newUnlockedState())
+	if err != nil {
+		s.logger.Error("resetting failed login attempts", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to authenticate player: %w", err)
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of the player after verifying its current password.
+// Wrong current passwords count as failed authentications. The new password must satisfy
+// the password policy and must not be one of the last passwords of the player.
+func (s *Service) ChangePassword(ctx context.Context, changePassword ChangePassword) error {
+	s.logger.Debug("starting to change player password", slog.Any("change", changePassword.obfuscate()))
+
+	err := changePassword.Validate()
+	if err != nil {
+		return fmt.Errorf("unable to change player password: %w", err)
+	}
+
+	err = s.checkSourceThrottle(ctx, changePassword.Source)
+	if err != nil {
+		return err
+	}
+
+	player, err := s.storage.GetByID(ctx, changePassword.PlayerID)
+	if err != nil {
+		s.logger.Error("getting player by id", slog.String("id", changePassword.PlayerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to change player password: %w", err)
+	}
+
+	if player == nil {
+		return ErrPlayerDoesNotExist
+	}
+
+	if player.LockState.IsLocked(time.Now().UTC()) {
+		s.hasher.Verify(s.dummyHash, changePassword.CurrentPassword)
+
+		s.logger.Debug("player to change password is locked", slog.String("id", player.ID.String()))
+
+		return ErrInvalidCredentials
+	}
+
+	if !s.hasher.Verify(player.Password, changePassword.CurrentPassword) {
+		s.logger.Debug("current player password does not match", slog.String("id", player.ID.String()))
+
+		return s.registerFailedAuthentication(ctx, player, changePassword.Source)
+	}
+
+	if player.Status.Current(time.Now().UTC()) != StatusActive {
+		s.logger.Debug("player to change password is not active", slog.String("id", player.ID.String()), slog.String("status", string(player.Status.Status)))
+
+		return ErrPlayerIsNotActive
+	}
+
+	err = s.checkPasswordReuse(ctx, player, changePassword.NewPassword)
+	if err != nil {
+		return fmt.Errorf("unable to change player password: %w", err)
+	}
+
+	hashedPassword, err := s.hasher.Hash(changePassword.NewPassword)
+	if err != nil {
+		s.logger.Error("hashing password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to change player password: %w", err)
+	}
+
+	passwordChange := changePassword.toPasswordChange(*player, hashedPassword, s.previousPasswordsToKeep())
+
+	changed, err := s.storage.ChangePassword(ctx, passwordChange)
+	if err != nil {
+		s.logger.Error("changing player password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to change player password: %w", err)
+	}
+
+	if !changed {
+		return fmt.Errorf("unable to change player password: %w", ErrPlayerVersionConflict)
+	}
+
+	s.logger.Debug("player password was changed", slog.String("id", player.ID.String()))
+
+	s.notifier.Notify(newPasswordChangedEvent(changePassword.PlayerID))
+
+	return nil
+}
+
+// RequestPasswordReset sends a single-use token to reset the password to the player with
+// the given email. It does not fail if there is no active player with that email or the player
+// has too many pending resets, so it cannot be used to find out which players exist.
+func (s *Service) RequestPasswordReset(ctx context.Context, request RequestPasswordReset) error {
+	s.logger.Debug("starting to request password reset", slog.String("email", request.Email))
+
+	err := request.Validate()
+	if err != nil {
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	player, err := s.storage.GetByEmailOrNickname(ctx, request.Email)
+	if err != nil {
+		s.logger.Error("getting player by email", slog.String("email", request.Email), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	if player == nil || player.Status.Current(now) != StatusActive {
+		s.logger.Debug("there is no active player to reset its password", slog.String("email", request.Email))
+
+		return nil
+	}
+
+	tooManyResets, err := s.hasTooManyPendingResets(ctx, *player.ID, now)
+	if err != nil {
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	if tooManyResets {
+		s.logger.Debug("player has too many pending password resets", slog.String("id", player.ID.String()))
+
+		return nil
+	}
+
+	token, resetToken, err := newResetToken(*player.ID, now, s.passwordResetTokenTTL)
+	if err != nil {
+		s.logger.Error("generating password reset token", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	err = s.storage.SavePasswordResetToken(ctx, resetToken)
+	if err != nil {
+		s.logger.Error("saving password reset token", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, newPasswordResetMail(player, token, resetToken.DateExpires))
+	if err != nil {
+		s.logger.Error("sending password reset mail", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to request password reset: %w", err)
+	}
+
+	s.logger.Debug("password reset token was sent", slog.String("id", player.ID.String()))
+
+	return nil
+}
+
+// hasTooManyPendingResets checks if the player already has the max number of unexpired reset tokens.
+func (s *Service) hasTooManyPendingResets(ctx context.Context, playerID PlayerID, now time.Time) (bool, error) {
+	if s.maxPendingPasswordResets <= 0 {
+		return false, nil
+	}
+
+	pendingResets, err := s.storage.CountPasswordResetTokens(ctx, playerID, now)
+	if err != nil {
+		s.logger.Error("counting password reset tokens", slog.String("id", playerID.String()), slog.String("error", err.Error()))
+
+		return false, err
+	}
+
+	return pendingResets >= s.maxPendingPasswordResets, nil
+}
+
+// ConfirmPasswordReset replaces the password of the player the token was sent to. The token
+// can be used only once, all the outstanding tokens of the player are invalidated with it.
+func (s *Service) ConfirmPasswordReset(ctx context.Context, reset ConfirmPasswordReset) error {
+	s.logger.Debug("starting to confirm password reset", slog.Any("reset", reset.obfuscate()))
+
+	err := reset.Validate()
+	if err != nil {
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	resetToken, err := s.storage.GetPasswordResetToken(ctx, hashToken(reset.Token))
+	if err != nil {
+		s.logger.Error("getting password reset token", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	if resetToken == nil || resetToken.IsExpired(time.Now().UTC()) {
+		return fmt.Errorf("unable to reset player password: %w", ErrInvalidResetToken)
+	}
+
+	player, err := s.storage.GetByID(ctx, resetToken.PlayerID)
+	if err != nil {
+		s.logger.Error("getting player by id", slog.String("id", resetToken.PlayerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	if player == nil {
+		return fmt.Errorf("unable to reset player password: %w", ErrInvalidResetToken)
+	}
+
+	err = s.checkPasswordReuse(ctx, player, reset.NewPassword)
+	if err != nil {
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	hashedPassword, err := s.hasher.Hash(reset.NewPassword)
+	if err != nil {
+		s.logger.Error("hashing password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	passwordChange := reset.toPasswordChange(*player, hashedPassword, s.previousPasswordsToKeep())
+
+	changed, err := s.storage.ChangePassword(ctx, passwordChange)
+	if err != nil {
+		s.logger.Error("resetting player password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	if !changed {
+		return s.resetConflict(ctx, resetToken.TokenHash)
+	}
+
+	s.logger.Debug("player password was reset", slog.String("id", player.ID.String()))
+
+	s.notifier.Notify(newPasswordResetEvent(*player.ID))
+
+	return nil
+}
+
+// resetConflict returns why a password reset did not change the password. A token that was
+// used by a concurrent reset or expired in the meantime can't be used again, while a player
+// that was changed in the meantime can still be reset with the same token.
+func (s *Service) resetConflict(ctx context.Context, tokenHash string) error {
+	resetToken, err := s.storage.GetPasswordResetToken(ctx, tokenHash)
+	if err != nil {
+		s.logger.Error("getting password reset token", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to reset player password: %w", err)
+	}
+
+	if resetToken == nil || resetToken.IsExpired(time.Now().UTC()) {
+		return fmt.Errorf("unable to reset player password: %w", ErrInvalidResetToken)
+	}
+
+	return fmt.Errorf("unable to reset player password: %w", ErrPlayerVersionConflict)
+}
+
+// VerifyEmail marks as verified the email the token was sent to. If it is the pending
+// email of the player, it replaces the current one.
+func (s *Service) VerifyEmail(ctx context.Context, verifyEmail VerifyEmail) error {
+	s.logger.Debug("starting to verify email", slog.Any("verify_email", verifyEmail.obfuscate()))
+
+	err := verifyEmail.Validate()
+	if err != nil {
+		return fmt.Errorf("unable to verify email: %w", err)
+	}
+
+	verificationToken, err := s.storage.GetEmailVerificationToken(ctx, hashToken(verifyEmail.Token))
+	if err != nil {
+		s.logger.Error("getting email verification token", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to verify email: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	if verificationToken == nil || verificationToken.IsExpired(now) {
+		return fmt.Errorf("unable to verify email: %w", ErrInvalidVerificationToken)
+	}
+
+	player, err := s.storage.GetByID(ctx, verificationToken.PlayerID)
+	if err != nil {
+		s.logger.Error("getting player by id", slog.String("id", verificationToken.PlayerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to verify email: %w", err)
+	}
+
+	if player == nil {
+		return fmt.Errorf("unable to verify email: %w", ErrInvalidVerificationToken)
+	}
+
+	verification, ok := verificationToken.toEmailVerification(*player, now)
+	if !ok {
+		s.logger.Debug("player does not use the email to verify anymore", slog.String("id", player.ID.String()))
+
+		return fmt.Errorf("unable to verify email: %w", ErrInvalidVerificationToken)
+	}
+
+	verified, err := s.storage.VerifyEmail(ctx, verification)
+	if errors.Is(err, ErrPlayerAlreadyExists) {
+		s.logger.Debug("email to verify was taken by another player", slog.String("id", player.ID.String()))
+
+		return err
+	}
+
+	if err != nil {
+		s.logger.Error("verifying email", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to verify email: %w", err)
+	}
+
+	if !verified {
+		return fmt.Errorf("unable to verify email: %w", ErrPlayerVersionConflict)
+	}
+
+	s.logger.Debug("player email was verified", slog.String("id", player.ID.String()))
+
+	s.notifier.Notify(newEmailVerifiedEvent(*player.ID))
+
+	return nil
+}
+
+// sendEmailVerification sends a token to verify the given email of the player. Failures are
+// only logged because the player was already stored, changing the email again sends a new token.
+func (s *Service) sendEmailVerification(ctx context.Context, player *Player, email string) {
+	token, verificationToken, err := newEmailVerificationToken(*player.ID, email, time.Now().UTC(), s.emailVerificationTokenTTL)
+	if err != nil {
+		s.logger.Error("generating email verification token", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return
+	}
+
+	err = s.storage.SaveEmailVerificationToken(ctx, verificationToken)
+	if err != nil {
+		s.logger.Error("saving email verification token", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return
+	}
+
+	err = s.mailer.Send(ctx, newEmailVerificationMail(player, email, token, verificationToken.DateExpires))
+	if err != nil {
+		s.logger.Error("sending email verification mail", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return
+	}
+
+	s.logger.Debug("email verification token was sent", slog.String("id", player.ID.String()))
+}
+
+// checkPasswordReuse returns ErrPasswordReused if the new password is the current
+// password of the player or one of the previous ones kept in its history.
+func (s *Service) checkPasswordReuse(ctx context.Context, player *Player, newPassword string) error {
+	if s.passwordHistorySize <= 0 {
+		return nil
+	}
+
+	if s.hasher.Verify(player.Password, newPassword) {
+		return ErrPasswordReused
+	}
+
+	previousPasswords, err := s.storage.GetPasswordHistory(ctx, *player.ID, s.previousPasswordsToKeep())
+	if err != nil {
+		s.logger.Error("getting password history", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return err
+	}
+
+	for _, previousPassword := range previousPasswords {
+		if s.hasher.Verify(previousPassword, newPassword) {
+			return ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// previousPasswordsToKeep number of previous passwords kept in the history of each player,
+// the current password is not part of the history.
+func (s *Service) previousPasswordsToKeep() int {
+	return max(s.passwordHistorySize-1, 0)
+}
+
+// rehashPassword hashes again the password of the player if the stored hash
+// is outdated. Failures are only logged because the player was already authenticated,
+// the rehash will be tried again in the next authentication.
+func (s *Service) rehashPassword(ctx context.Context, player *Player, password string) {
+	if !s.hasher.NeedsRehash(player.Password) {
+		return
+	}
+
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		s.logger.Error("rehashing player password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return
+	}
+
+	err = s.storage.UpdatePassword(ctx, *player.ID, hashedPassword)
+	if err != nil {
+		s.logger.Error("updating rehashed player password", slog.String("id", player.ID.String()), slog.String("error", err.Error()))
+
+		return
+	}
+
+	s.logger.Debug("player password was rehashed", slog.String("id", player.ID.String()))
+}
+
+// saveLoginAttempt persists the attempt, failures are only logged because
+// login attempts are an audit trail and must not block authentications.
+func (s *Service) saveLoginAttempt(ctx context.Context, attempt LoginAttempt) {
+	err := s.storage.SaveLoginAttempt(ctx, attempt)
+	if err != nil {
+		s.logger.Error("saving login attempt", slog.String("source", attempt.Source), slog.String("error", err.Error()))
+	}
+}
+
+func (s *Service) Delete(ctx context.Context, deletePlayer DeletePlayer) error {
+	playerID := deletePlayer.ID
+
+	s.logger.Debug("starting to delete player", slog.Any("player_id", playerID.String()))
+
+	player, err := s.storage.GetByID(ctx, playerID)
+	if err != nil {
+		s.logger.Error("getting player by id", slog.String("id", playerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to delete player: %w", err)
+	}
+
+	if player == nil {
+		return ErrPlayerDoesNotExist
+	}
+
+	if player.isStale(deletePlayer.ExpectedVersion) {
+		return fmt.Errorf("unable to delete player: %w", ErrPlayerVersionConflict)
+	}
+
+	deleted, err := s.storage.Delete(ctx, playerID, player.Version, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("deleting player", "error", err)
+
+		return fmt.Errorf("unable to delete player: %w", err)
+	}
+
+	if !deleted {
+		return fmt.Errorf("unable to delete player: %w", ErrPlayerVersionConflict)
+	}
+
+	s.logger.Debug("player was deleted", slog.Any("id", playerID))
+
+	s.notifier.Notify(newDeletePlayerEvent(playerID))
+
+	return nil
+}
+
+// BatchCreate creates the given players. In transactional mode every player is created or none of them,
+// in best effort mode the valid players are created even if other players fail. The emails and nicknames
+// of all the players are checked with a single query and the players are stored with a single insert.
+func (s *Service) BatchCreate(ctx context.Context, batch BatchCreatePlayers) (*BatchResult, error) {
+	s.logger.Debug("starting to create players in batch",
+		slog.Int("players", len(batch.Players)), slog.String("mode", string(batch.Mode)))
+
+	err := batch.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create players: %w", err)
+	}
+
+	result := newBatchResult(len(batch.Players))
+	filters := make([]PlayerFilter, len(batch.Players))
+
+	for index, newPlayer := range batch.Players {
+		result.fail(index, newPlayer.validateInBatch())
+		filters[index] = newPlayer.toPlayerFilter()
+	}
+
+	err = s.checkBatchUniqueness(ctx, result, filters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create players: %w", err)
+	}
+
+	if result.mustAbort(batch.Mode) {
+		result.abort()
+
+		return result, nil
+	}
+
+	pending := make([]batchPlayer, 0, len(batch.Players))
+
+	for index, newPlayer := range batch.Players {
+		if !result.isPending(index) {
+			continue
+		}
+
+		hashedPassword, err := s.hasher.Hash(newPlayer.Password)
+		if err != nil {
+			s.logger.Error("hashing password", "error", err.Error())
+
+			return nil, fmt.Errorf("unable to hash password: %w", err)
+		}
+
+		player := newPlayer.toPlayer(hashedPassword)
+		pending = append(pending, batchPlayer{index: index, player: &player})
+	}
+
+	err = s.saveBatch(ctx, batch.Mode, result, pending, filters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create players: %w", err)
+	}
+
+	for _, item := range pending {
+		if !result.isPending(item.index) {
+			continue
+		}
+
+		s.notifier.Notify(newCreatePlayerEvent(item.player.ID))
+
+		s.sendEmailVerification(ctx, item.player, item.player.Email.Address)
+	}
+
+	s.logger.Debug("players were created in batch", slog.Int("players", len(pending)))
+
+	return result, nil
+}
+
+// saveBatch stores the new players with a single insert. If other requests took some of the emails or
+// nicknames in the meantime, in best effort mode the players are stored one by one and in transactional
+// mode they are checked again to report the players that failed.
+func (s *Service) saveBatch(ctx context.Context, mode BatchMode, result *BatchResult, pending []batchPlayer, filters []PlayerFilter) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := s.storage.SaveAll(ctx, toPlayers(pending))
+	if err == nil {
+		for _, item := range pending {
+			result.succeed(item.index, item.player)
+		}
+
+		return nil
+	}
+
+	if !errors.Is(err, ErrPlayerAlreadyExists) {
+		s.logger.Error("creating players", "error", err)
+
+		return err
+	}
+
+	s.logger.Debug("players with the given emails or nicknames were created concurrently")
+
+	if mode == BatchBestEffort {
+		for _, item := range pending {
+			result.fail(item.index, s.saveBatchPlayer(ctx, item.player))
+
+			if result.isPending(item.index) {
+				result.succeed(item.index, item.player)
+			}
+		}
+
+		return nil
+	}
+
+	checkErr := s.checkBatchUniqueness(ctx, result, filters)
+	if checkErr != nil {
+		return checkErr
+	}
+
+	if !result.Failed() {
+		// the players that took the emails or nicknames were deleted in the meantime.
+		for _, item := range pending {
+			result.fail(item.index, err)
+		}
+	}
+
+	result.abort()
+
+	return nil
+}
+
+func (s *Service) saveBatchPlayer(ctx context.Context, player *Player) error {
+	err := s.storage.Save(ctx, *player)
+	if errors.Is(err, ErrPlayerAlreadyExists) {
+		return err
+	}
+
+	if err != nil {
+		s.logger.Error("creating player", slog.Any("id", player.ID), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to create player: %w", err)
+	}
+
+	return nil
+}
+
+// BatchUpdate updates the given players. In transactional mode every player is updated or none of them,
+// in best effort mode the valid players are updated even if other players fail. The emails and nicknames
+// of all the players are checked with a single query and the players are read with another one.
+func (s *Service) BatchUpdate(ctx context.Context, batch BatchUpdatePlayers) (*BatchResult, error) {
+	s.logger.Debug("starting to update players in batch",
+		slog.Int("players", len(batch.Players)), slog.String("mode", string(batch.Mode)))
+
+	err := batch.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to update players: %w", err)
+	}
+
+	result := newBatchResult(len(batch.Players))
+	updatePlayers := make([]UpdatePlayer, len(batch.Players))
+	filters := make([]PlayerFilter, len(batch.Players))
+	playerIDs := make([]PlayerID, len(batch.Players))
+
+	for index, updatePlayer := range batch.Players {
+		result.fail(index, updatePlayer.Validate())
+
+		updatePlayers[index] = updatePlayer.masked()
+		playerIDs[index] = updatePlayer.ID
+
+		if updatePlayers[index].updateKeyValues() {
+			filters[index] = updatePlayers[index].toPlayerFilter()
+		}
+	}
+
+	result.failRepeated(playerIDs)
+
+	err = s.checkBatchUniqueness(ctx, result, filters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update players: %w", err)
+	}
+
+	storedPlayers, err := s.getBatchPlayers(ctx, result, playerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update players: %w", err)
+	}
+
+	pending := make([]batchPlayer, 0, len(batch.Players))
+
+	for index, updatePlayer := range updatePlayers {
+		if !result.isPending(index) {
+			continue
+		}
+
+		player := storedPlayers[updatePlayer.ID]
+
+		if player.isStale(updatePlayer.ExpectedVersion) {
+			result.fail(index, fmt.Errorf("unable to update player: %w", ErrPlayerVersionConflict))
+
+			continue
+		}
+
+		playerToUpdate := updatePlayer.toPlayer(*player)
+
+		if !playerToUpdate.changes {
+			result.succeed(index, player)
+
+			continue
+		}
+
+		pending = append(pending, batchPlayer{
+			index:         index,
+			player:        playerToUpdate.player,
+			emailToVerify: playerToUpdate.emailToVerify,
+		})
+	}
+
+	if result.mustAbort(batch.Mode) {
+		result.abort()
+
+		return result, nil
+	}
+
+	if batch.Mode == BatchTransactional {
+		err = s.storeAll(result, pending, func(batchPlayers []Player) error {
+			return s.storage.UpdateAll(ctx, batchPlayers)
+		})
+	} else {
+		s.updateOneByOne(ctx, result, pending)
+	}
+
+	if err != nil {
+		s.logger.Error("updating players", "error", err)
+
+		return nil, fmt.Errorf("unable to update players: %w", err)
+	}
+
+	for _, item := range pending {
+		if !result.isPending(item.index) {
+			continue
+		}
+
+		item.player.Version++
+
+		s.notifier.Notify(newUpdatePlayerEvent(item.player.ID))
+
+		if item.emailToVerify != "" {
+			s.sendEmailVerification(ctx, item.player, item.emailToVerify)
+		}
+	}
+
+	s.logger.Debug("players were updated in batch", slog.Int("players", len(pending)))
+
+	return result, nil
+}
+
+func (s *Service) updateOneByOne(ctx context.Context, result *BatchResult, pending []batchPlayer) {
+	for _, item := range pending {
+		updated, err := s.storage.Update(ctx, *item.player)
+
+		switch {
+		case errors.Is(err, ErrPlayerAlreadyExists):
+			result.fail(item.index, err)
+		case err != nil:
+			s.logger.Error("updating player", slog.Any("id", item.player.ID), slog.String("error", err.Error()))
+
+			result.fail(item.index, fmt.Errorf("unable to update player: %w", err))
+		case !updated:
+			result.fail(item.index, fmt.Errorf("unable to update player: %w", ErrPlayerVersionConflict))
+		default:
+			result.succeed(item.index, item.player)
+		}
+	}
+}
+
+// BatchDelete deletes the given players. In transactional mode every player is deleted or none of them,
+// in best effort mode the players are deleted even if other players fail. The players are read with a single query.
+func (s *Service) BatchDelete(ctx context.Context, batch BatchDeletePlayers) (*BatchResult, error) {
+	s.logger.Debug("starting to delete players in batch",
+		slog.Int("players", len(batch.Players)), slog.String("mode", string(batch.Mode)))
+
+	err := batch.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete players: %w", err)
+	}
+
+	result := newBatchResult(len(batch.Players))
+	playerIDs := make([]PlayerID, len(batch.Players))
+
+	for index, deletePlayer := range batch.Players {
+		playerIDs[index] = deletePlayer.ID
+	}
+
+	result.failRepeated(playerIDs)
+
+	storedPlayers, err := s.getBatchPlayers(ctx, result, playerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete players: %w", err)
+	}
+
+	pending := make([]batchPlayer, 0, len(batch.Players))
+
+	for index, deletePlayer := range batch.Players {
+		if !result.isPending(index) {
+			continue
+		}
+
+		player := storedPlayers[deletePlayer.ID]
+
+		if player.isStale(deletePlayer.ExpectedVersion) {
+			result.fail(index, fmt.Errorf("unable to delete player: %w", ErrPlayerVersionConflict))
+
+			continue
+		}
+
+		pending = append(pending, batchPlayer{index: index, player: player})
+	}
+
+	if result.mustAbort(batch.Mode) {
+		result.abort()
+
+		return result, nil
+	}
+
+	deletedAt := time.Now().UTC()
+
+	if batch.Mode == BatchTransactional {
+		err = s.storeAll(result, pending, func(batchPlayers []Player) error {
+			return s.storage.DeleteAll(ctx, batchPlayers, deletedAt)
+		})
+	} else {
+		s.deleteOneByOne(ctx, result, pending, deletedAt)
+	}
+
+	if err != nil {
+		s.logger.Error("deleting players", "error", err)
+
+		return nil, fmt.Errorf("unable to delete players: %w", err)
+	}
+
+	for _, item := range pending {
+		if result.isPending(item.index) {
+			s.notifier.Notify(newDeletePlayerEvent(*item.player.ID))
+		}
+	}
+
+	s.logger.Debug("players were deleted in batch", slog.Int("players", len(pending)))
+
+	return result, nil
+}
+
+func (s *Service) deleteOneByOne(ctx context.Context, result *BatchResult, pending []batchPlayer, deletedAt time.Time) {
+	for _, item := range pending {
+		deleted, err := s.storage.Delete(ctx, *item.player.ID, item.player.Version, deletedAt)
+
+		switch {
+		case err != nil:
+			s.logger.Error("deleting player", slog.Any("id", item.player.ID), slog.String("error", err.Error()))
+
+			result.fail(item.index, fmt.Errorf("unable to delete player: %w", err))
+		case !deleted:
+			result.fail(item.index, fmt.Errorf("unable to delete player: %w", ErrPlayerVersionConflict))
+		default:
+			result.succeed(item.index, item.player)
+		}
+	}
+}
+
+// storeAll stores the players of a transactional batch with the given storage function. If a player
+// fails, the other players are aborted.
+func (s *Service) storeAll(result *BatchResult, pending []batchPlayer, store func([]Player) error) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := store(toPlayers(pending))
+
+	var itemErr *BatchItemError
+	if errors.As(err, &itemErr) {
+		s.logger.Debug("player of the batch was changed concurrently", slog.Any("id", pending[itemErr.Index].player.ID))
+
+		result.fail(pending[itemErr.Index].index, itemErr.Err)
+		result.abort()
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		result.succeed(item.index, item.player)
+	}
+
+	return nil
+}
+
+// checkBatchUniqueness fails the players of the batch whose email or nickname is taken by a stored
+// player or by a previous player of the batch. Stored players are read with a single query.
+func (s *Service) checkBatchUniqueness(ctx context.Context, result *BatchResult, filters []PlayerFilter) error {
+	pendingFilters := result.pendingFilters(filters)
+	if len(pendingFilters) == 0 {
+		return nil
+	}
+
+	storedKeys, err := s.storage.GetPlayersWithEmailsOrNicknames(ctx, pendingFilters)
+	if err != nil {
+		s.logger.Error("checking if players with the given emails or nicknames already exist", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to check if players already exist: %w", err)
+	}
+
+	taken := newTakenKeys(storedKeys)
+
+	for index, filter := range filters {
+		if !result.isPending(index) || filter.isEmpty() {
+			continue
+		}
+
+		existResult := taken.check(filter)
+		if existResult.Exist() {
+			result.fail(index, existResult.toPlayerAlreadyExistsError())
+
+			continue
+		}
+
+		taken.take(filter)
+	}
+
+	return nil
+}
+
+// getBatchPlayers reads the players of the batch that did not fail with a single query, players
+// that do not exist fail with ErrPlayerDoesNotExist.
+func (s *Service) getBatchPlayers(ctx context.Context, result *BatchResult, playerIDs []PlayerID) (map[PlayerID]*Player, error) {
+	pendingIDs := result.pendingIDs(playerIDs)
+	if len(pendingIDs) == 0 {
+		return nil, nil
+	}
+
+	storedPlayers, err := s.storage.GetByIDs(ctx, pendingIDs)
+	if err != nil {
+		s.logger.Error("getting players by ids", slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	playersByID := make(map[PlayerID]*Player, len(storedPlayers))
+
+	for index := range storedPlayers {
+		playersByID[*storedPlayers[index].ID] = &storedPlayers[index]
+	}
+
+	for index, playerID := range playerIDs {
+		if result.isPending(index) && playersByID[playerID] == nil {
+			result.fail(index, ErrPlayerDoesNotExist)
+		}
+	}
+
+	return playersByID, nil
+}
+
+// Restore restores a deleted player if it is still within the retention window and
+// no other player took its email or nickname in the meantime.
+func (s *Service) Restore(ctx context.Context, playerID PlayerID) error {
+	s.logger.Debug("starting to restore player", slog.String("player_id", playerID.String()))
+
+	player, err := s.storage.GetDeletedByID(ctx, playerID)
+	if err != nil {
+		s.logger.Error("getting deleted player by id", slog.String("id", playerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to restore player: %w", err)
+	}
+
+	if player == nil {
+		return fmt.Errorf("unable to restore player: %w", ErrPlayerDoesNotExist)
+	}
+
+	if !player.canBeRestored(time.Now().UTC(), s.deletionRetention) {
+		return fmt.Errorf("unable to restore player: %w", ErrRestoreWindowExpired)
+	}
+
+	checkExistResult, err := s.doesThePlayerAlreadyExist(ctx, player.toPlayerFilter())
+	if err != nil {
+		return fmt.Errorf("unable to restore player: %w", err)
+	}
+
+	if checkExistResult.Exist() {
+		return checkExistResult.toPlayerAlreadyExistsError()
+	}
+
+	err = s.storage.Restore(ctx, playerID)
+	if errors.Is(err, ErrPlayerAlreadyExists) {
+		s.logger.Debug("player with the given email or nickname was stored concurrently", slog.String("id", playerID.String()))
+
+		return err
+	}
+
+	if err != nil {
+		s.logger.Error("restoring player", slog.String("id", playerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to restore player: %w", err)
+	}
+
+	s.logger.Debug("player was restored", slog.String("id", playerID.String()))
+
+	s.notifier.Notify(newRestorePlayerEvent(playerID))
+
+	return nil
+}
+
+// ChangeStatus moves the player to the given status if the status lifecycle allows it.
+func (s *Service) ChangeStatus(ctx context.Context, changeStatus ChangeStatus) error {
+	s.logger.Debug("starting to change player status",
+		slog.String("player_id", changeStatus.PlayerID.String()),
+		slog.String("status", string(changeStatus.Status)),
+	)
+
+	now := time.Now().UTC()
+
+	err := changeStatus.Validate(now)
+	if err != nil {
+		return fmt.Errorf("unable to change player status: %w", err)
+	}
+
+	player, err := s.Get(ctx, changeStatus.PlayerID)
+	if err != nil {
+		return fmt.Errorf("unable to change player status: %w", err)
+	}
+
+	currentStatus := player.Status.Current(now)
+	if !currentStatus.canChangeTo(changeStatus.Status) {
+		return fmt.Errorf("unable to change player status: %w", newInvalidStatusTransitionError(currentStatus, changeStatus.Status))
+	}
+
+	// the stored status is used to detect concurrent changes, it could be
+	// an expired suspension that was not processed yet.
+	change := changeStatus.toStatusChange(player.Status.Status, now)
+
+	changed, err := s.storage.ChangeStatus(ctx, change)
+	if err != nil {
+		s.logger.Error("changing player status", slog.String("id", changeStatus.PlayerID.String()), slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to change player status: %w", err)
+	}
+
+	if !changed {
+		return fmt.Errorf("unable to change player status: %w: status was changed by another request", ErrInvalidStatusTransition)
+	}
+
+	s.logger.Debug("player status was changed", slog.String("id", changeStatus.PlayerID.String()), slog.String("status", string(change.To)))
+
+	s.notifier.Notify(newStatusChangedEvent(change))
+
+	return nil
+}
+
+// ExpireSuspensions activates the players whose suspension expired and returns the number of activated players.
+func (s *Service) ExpireSuspensions(ctx context.Context) (int, error) {
+	change := newSuspensionExpiredChange(time.Now().UTC())
+
+	s.logger.Debug("starting to expire suspensions", slog.Time("now", change.DateCreated))
+
+	playerIDs, err := s.storage.ExpireSuspensions(ctx, change)
+	if err != nil {
+		s.logger.Error("expiring suspensions", slog.String("error", err.Error()))
+
+		return 0, fmt.Errorf("unable to expire suspensions: %w", err)
+	}
+
+	for _, playerID := range playerIDs {
+		playerChange := change
+		playerChange.PlayerID = &playerID
+
+		s.notifier.Notify(newStatusChangedEvent(playerChange))
+	}
+
+	if len(playerIDs) > 0 {
+		s.logger.Info("suspensions were expired", slog.Int("count", len(playerIDs)))
+	}
+
+	return len(playerIDs), nil
+}
+
+// PurgeDeletedPlayers physically removes the players deleted before the retention window
+// and returns the number of purged players.
+func (s *Service) PurgeDeletedPlayers(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().UTC().Add(-s.deletionRetention)
+
+	s.logger.Debug("starting to purge deleted players", slog.Time("deleted_before", deletedBefore))
+
+	var purged int
+
+	for {
+		purgedIDs, err := s.storage.PurgeDeletedBefore(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			s.logger.Error("purging deleted players", slog.String("error", err.Error()))
+
+			return purged, fmt.Errorf("unable to purge deleted players: %w", err)
+		}
+
+		for _, playerID := range purgedIDs {
+			s.notifier.Notify(newPurgePlayerEvent(playerID))
+		}
+
+		purged += len(purgedIDs)
+
+		if len(purgedIDs) < purgeBatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		s.logger.Info("deleted players were purged", slog.Int("count", purged))
+	}
+
+	return purged, nil
+}
+
+// PurgeExpiredIdempotencyKeys removes the expired idempotency keys and returns the number of removed keys.
+func (s *Service) PurgeExpiredIdempotencyKeys(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	s.logger.Debug("starting to purge expired idempotency keys", slog.Time("now", now))
+
+	purged, err := s.storage.DeleteExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		s.logger.Error("purging expired idempotency keys", slog.String("error", err.Error()))
+
+		return 0, fmt.Errorf("unable to purge expired idempotency keys: %w", err)
+	}
+
+	if purged > 0 {
+		s.logger.Info("expired idempotency keys were purged", slog.Int("count", purged))
+	}
+
+	return purged, nil
+}
+
+func (s *Service) doesThePlayerAlreadyExist(ctx context.Context, playerFilter PlayerFilter) (*PlayerExistResult, error) {
+	s.logger.Debug(
+		"checking if a player with the given email and nickname already exists",
+		slog.String("email", playerFilter.Email),
+		slog.String("nickname", playerFilter.Nickname),
+	)
+
+	playerWithEmailOrNickname, err := s.storage.GetPlayersWithEmailOrNickName(ctx, playerFilter)
+	if err != nil {
+		s.logger.Error(
+			"checking if a player with the given email or nickname already exists",
+			slog.String("email", playerFilter.Email),
+			slog.String("nickname", playerFilter.Nickname),
+			slog.String("error", err.Error()),
+		)
+
+		return nil, fmt.Errorf("unable to check if player already exists: %w", err)
+	}
+
+	return playerWithEmailOrNickname, nil
+}
+
+func (s *Service) List(ctx context.Context, searchCriteria SearchCriteria) (*SearchResult, error) {
+	s.logger.Debug("starting to search players", slog.Any("criteria", searchCriteria))
+
+	err := searchCriteria.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list players: %w", err)
+	}
+
+	if searchCriteria.isEmpty() && !searchCriteria.ListAll {
+		return newEmptySearchResult(), nil
+	}
+
+	err = searchCriteria.allowed()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list players: %w", err)
+	}
+
+	err = searchCriteria.normalizeCountry()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list players: %w", err)
+	}
+
+	searchCriteria.setDefaultPaginationIfEmpty()
+
+	fingerprint, err := searchCriteria.fingerprint()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list players: %w", err)
+	}
+
+	if searchCriteria.PageToken != "" {
+		searchCriteria.After, err = parsePageToken(s.pageTokenKey, fingerprint, searchCriteria.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list players: %w", err)
+		}
+	}
+
+	result, err := s.storage.Search(ctx, searchCriteria)
+	if err != nil {
+		s.logger.Error("searching players", slog.String("error", err.Error()))
+
+		return nil, fmt.Errorf("unable to list players: %w", err)
+	}
+
+	if result.Next != nil {
+		result.NextPageToken, err = newPageToken(s.pageTokenKey, fingerprint, *result.Next)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list players: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// ListCountries returns the ISO 3166-1 countries sorted by code with the number of players of each one.
+func (s *Service) ListCountries(ctx context.Context) ([]CountryPlayers, error) {
+	s.logger.Debug("starting to list countries")
+
+	counts, err := s.storage.CountPlayersByCountry(ctx)
+	if err != nil {
+		s.logger.Error("counting players by country", slog.String("error", err.Error()))
+
+		return nil, fmt.Errorf("unable to list countries: %w", err)
+	}
+
+	result := make([]CountryPlayers, 0, len(countries))
+
+	for _, country := range countries {
+		result = append(result, CountryPlayers{
+			Country: country,
+			Players: counts[country.Code],
+		})
+	}
+
+	return result, nil
+}
+
+// NormalizeCountries replaces the countries stored for the players with their ISO 3166-1
+// alpha-2 codes. Stored countries that are not known are reported and left as they are.
+func (s *Service) NormalizeCountries(ctx context.Context) (*CountryNormalization, error) {
+	s.logger.Debug("starting to normalize countries")
+
+	storedCountries, err := s.storage.GetStoredCountries(ctx)
+	if err != nil {
+		s.logger.Error("getting stored countries", slog.String("error", err.Error()))
+
+		return nil, fmt.Errorf("unable to normalize countries: %w", err)
+	}
+
+	result := CountryNormalization{
+		Unknown: make([]string, 0),
+	}
+
+	now := time.Now().UTC()
+
+	for _, storedCountry := range storedCountries {
+		code, ok := NormalizeCountry(storedCountry)
+		if !ok {
+			s.logger.Warn("stored country is not an ISO 3166-1 country", slog.String("country", storedCountry))
+
+			result.Unknown = append(result.Unknown, storedCountry)
+
+			continue
+		}
+
+		if code == storedCountry {
+			continue
+		}
+
+		replaced, err := s.storage.ReplaceCountry(ctx, storedCountry, code, now)
+		if err != nil {
+			s.logger.Error("replacing stored country", slog.String("country", storedCountry), slog.String("error", err.Error()))
+
+			return &result, fmt.Errorf("unable to normalize countries: %w", err)
+		}
+
+		s.logger.Info("country was normalized",
+			slog.String("from", storedCountry),
+			slog.String("to", code),
+			slog.Int("players", replaced))
+
+		result.Players += replaced
+	}
+
+	return &result, nil
+}
+
+// CanonicalizePlayers stores the canonical nickname and email of the players created before
+// they were compared by them. Players whose canonical forms are taken by another active player
+// are reported and left as they are, the oldest player keeps the nickname or email, so the
+// others must be renamed or deleted before running it again.
+func (s *Service) CanonicalizePlayers(ctx context.Context) (*Canonicalization, error) {
+	s.logger.Debug("starting to canonicalize players")
+
+	storedPlayers, err := s.storage.GetPlayersWithoutCanonicalKeys(ctx)
+	if err != nil {
+		s.logger.Error("getting players without canonical keys", slog.String("error", err.Error()))
+
+		return nil, fmt.Errorf("unable to canonicalize players: %w", err)
+	}
+
+	result := Canonicalization{
+		Collisions: make([]PlayerID, 0),
+	}
+
+	for _, player := range storedPlayers {
+		keys := PlayerKeys{
+			ID:                *player.ID,
+			CanonicalNickname: CanonicalNickname(player.Nickname),
+			CanonicalEmail:    CanonicalEmail(player.Email.Address),
+		}
+
+		err := s.storage.SaveCanonicalKeys(ctx, keys)
+
+		var alreadyExistsErr *PlayerAlreadyExistsError
+		if errors.As(err, &alreadyExistsErr) {
+			s.logger.Warn("canonical keys of the player are taken by another player",
+				slog.String("player_id", player.ID.String()),
+				slog.Bool("nickname", alreadyExistsErr.WasNickname),
+				slog.Bool("email", alreadyExistsErr.WasEmail))
+
+			result.Collisions = append(result.Collisions, *player.ID)
+
+			continue
+		}
+
+		if err != nil {
+			s.logger.Error("saving player canonical keys", slog.String("player_id", player.ID.String()), slog.String("error", err.Error()))
+
+			return &result, fmt.Errorf("unable to canonicalize players: %w", err)
+		}
+
+		result.Players++
+	}
+
+	return &result, nil
 }
@@ -0,0 +1,243 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle status of a player.
+type Status string
+
+// player statuses.
+const (
+	// StatusActive players can authenticate.
+	StatusActive Status = "active"
+	// StatusDisabled players cannot authenticate until they are activated again.
+	StatusDisabled Status = "disabled"
+	// StatusSuspended players cannot authenticate until the suspension expires or they are activated again.
+	StatusSuspended Status = "suspended"
+	// StatusBanned players cannot authenticate until they are activated again.
+	StatusBanned Status = "banned"
+)
+
+// statusTransitions contains the allowed status changes, keys are the current status.
+var statusTransitions = map[Status][]Status{
+	StatusActive:    {StatusDisabled, StatusSuspended, StatusBanned},
+	StatusDisabled:  {StatusActive, StatusBanned},
+	StatusSuspended: {StatusActive, StatusBanned},
+	StatusBanned:    {StatusActive},
+}
+
+// actor and reason used for automatic status changes.
+const (
+	systemActor             = "system"
+	suspensionExpiredReason = "suspension expired"
+)
+
+// PlayerStatus contains the status of a player and data about its last change.
+type PlayerStatus struct {
+	Status Status
+	// Reason why the status was changed.
+	Reason string
+	// Actor who changed the status.
+	Actor string
+	// SuspendedUntil date the suspension expires, only for suspended players.
+	SuspendedUntil *time.Time
+	// DateChanged date of the last status change, nil if the status never changed.
+	DateChanged *time.Time
+}
+
+// ChangeStatus contains data required to change the status of a player.
+type ChangeStatus struct {
+	PlayerID PlayerID
+	Status   Status
+	Reason   string
+	Actor    string
+	// SuspendedUntil is required to suspend players.
+	SuspendedUntil *time.Time
+}
+
+// StatusChange contains data about a status transition of a player.
+type StatusChange struct {
+	// PlayerID is nil when the change applies to many players.
+	PlayerID       *PlayerID
+	From           Status
+	To             Status
+	Reason         string
+	Actor          string
+	SuspendedUntil *time.Time
+	DateCreated    time.Time
+}
+
+// ChangeStatusResult standard response for changing the status of a Player.
+type ChangeStatusResult struct {
+	Err string
+}
+
+// status field names used in validation errors.
+const (
+	StatusField         = "status"
+	ReasonField         = "reason"
+	ActorField          = "actor"
+	SuspendedUntilField = "suspended_until"
+)
+
+var (
+	// ErrInvalidStatusTransition is returned when the player cannot move from its current status to the requested one.
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	// ErrPlayerIsNotActive is returned when a player with valid credentials is disabled, suspended or banned.
+	ErrPlayerIsNotActive = errors.New("player is not active")
+	ErrInvalidStatus     = newFieldError(StatusField, "status is not valid")
+	ErrEmptyReason       = newFieldError(ReasonField, "reason is empty")
+	ErrEmptyActor        = newFieldError(ActorField, "actor is empty")
+	ErrEmptySuspendedEnd = newFieldError(SuspendedUntilField, "suspended until is empty")
+	ErrPastSuspendedEnd  = newFieldError(SuspendedUntilField, "suspended until must be in the future")
+	ErrUnexpectedEnd     = newFieldError(SuspendedUntilField, "suspended until is only allowed to suspend players")
+)
+
+// limits of the status change data, they match the sizes of the status columns.
+const (
+	maxStatusReasonLength = 256
+	maxStatusActorLength  = 128
+)
+
+var (
+	statusReasonRules = fieldRules{
+		field:    ReasonField,
+		emptyErr: ErrEmptyReason,
+		rules:    []rule{maxLength("reason", maxStatusReasonLength)},
+	}
+	statusActorRules = fieldRules{
+		field:    ActorField,
+		emptyErr: ErrEmptyActor,
+		rules:    []rule{maxLength("actor", maxStatusActorLength)},
+	}
+)
+
+// IsValid indicates if the status is one of the known statuses.
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+
+	return ok
+}
+
+// canChangeTo indicates if the state machine allows moving from s to the given status.
+func (s Status) canChangeTo(status Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Current returns the effective status at the given date, an expired
+// suspension is active even if it was not processed yet.
+func (p PlayerStatus) Current(now time.Time) Status {
+	if p.Status == StatusSuspended && p.SuspendedUntil != nil && !p.SuspendedUntil.After(now) {
+		return StatusActive
+	}
+
+	if p.Status == "" {
+		return StatusActive
+	}
+
+	return p.Status
+}
+
+// Validate checks the requested status change is complete, the suspension end
+// is compared with the given date.
+func (c ChangeStatus) Validate(now time.Time) error {
+	var err error
+
+	if !c.Status.IsValid() {
+		err = errors.Join(err, ErrInvalidStatus)
+	}
+
+	switch {
+	case c.Reason != "":
+		err = errors.Join(err, statusReasonRules.validate(c.Reason))
+	case c.Status != StatusActive:
+		err = errors.Join(err, ErrEmptyReason)
+	}
+
+	err = errors.Join(err, statusActorRules.validate(c.Actor))
+
+	switch {
+	case c.Status == StatusSuspended && c.SuspendedUntil == nil:
+		err = errors.Join(err, ErrEmptySuspendedEnd)
+	case c.Status == StatusSuspended && !c.SuspendedUntil.After(now):
+		err = errors.Join(err, ErrPastSuspendedEnd)
+	case c.Status != StatusSuspended && c.SuspendedUntil != nil:
+		err = errors.Join(err, ErrUnexpectedEnd)
+	}
+
+	return err
+}
+
+func (c ChangeStatus) toStatusChange(from Status, now time.Time) StatusChange {
+	playerID := c.PlayerID
+
+	return StatusChange{
+		PlayerID:       &playerID,
+		From:           from,
+		To:             c.Status,
+		Reason:         c.Reason,
+		Actor:          c.Actor,
+		SuspendedUntil: c.SuspendedUntil,
+		DateCreated:    now,
+	}
+}
+
+func newSuspensionExpiredChange(now time.Time) StatusChange {
+	return StatusChange{
+		From:        StatusSuspended,
+		To:          StatusActive,
+		Reason:      suspensionExpiredReason,
+		Actor:       systemActor,
+		DateCreated: now,
+	}
+}
+
+func newInvalidStatusTransitionError(from, to Status) error {
+	return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusTransition, from, to)
+}
+
+// newChangeStatusResult create a new ChangeStatusResult.
+func newChangeStatusResult(err error) ChangeStatusResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return ChangeStatusResult{
+		Err: errmessage,
+	}
+}
+
+func newStatusChangedEvent(change StatusChange) NewEvent {
+	return NewEvent{
+		PlayerID: change.PlayerID.String(),
+		Event:    change.eventName(),
+	}
+}
+
+// eventName returns a distinct event for each transition.
+func (s StatusChange) eventName() string {
+	switch {
+	case s.From == StatusSuspended && s.To == StatusActive && s.Actor == systemActor:
+		return "player suspension expired"
+	case s.To == StatusActive:
+		return "player was activated"
+	case s.To == StatusDisabled:
+		return "player was disabled"
+	case s.To == StatusSuspended:
+		return "player was suspended"
+	case s.To == StatusBanned:
+		return "player was banned"
+	default:
+		return "player status was changed"
+	}
+}
@@ -0,0 +1,353 @@
+package players_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuspendPlayer(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusActive, nil)
+	suspendedUntil := time.Now().UTC().Add(24 * time.Hour)
+	changeStatus := players.ChangeStatus{
+		PlayerID:       *givenPlayerID,
+		Status:         players.StatusSuspended,
+		Reason:         "abusive language",
+		Actor:          "moderator",
+		SuspendedUntil: &suspendedUntil,
+	}
+
+	wantEvent := players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player was suspended",
+	}
+
+	isExpectedChange := func(change players.StatusChange) bool {
+		return *change.PlayerID == *givenPlayerID &&
+			change.From == players.StatusActive &&
+			change.To == players.StatusSuspended &&
+			change.Reason == "abusive language" &&
+			change.Actor == "moderator" &&
+			change.SuspendedUntil.Equal(suspendedUntil)
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangeStatus", ctx, mock.MatchedBy(isExpectedChange)).Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", wantEvent)
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.ChangeStatus(ctx, changeStatus)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestActivateBannedPlayerWithoutReason(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusBanned, nil)
+	changeStatus := players.ChangeStatus{
+		PlayerID: *givenPlayerID,
+		Status:   players.StatusActive,
+		Actor:    "moderator",
+	}
+
+	wantEvent := players.NewEvent{
+		PlayerID: givenPlayerID.String(),
+		Event:    "player was activated",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangeStatus", ctx, mock.AnythingOfType("players.StatusChange")).Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", wantEvent)
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.ChangeStatus(ctx, changeStatus)
+
+	// Then
+	assert.NoError(t, err)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestChangeStatusButInvalidTransition(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusBanned, nil)
+	changeStatus := players.ChangeStatus{
+		PlayerID: *givenPlayerID,
+		Status:   players.StatusDisabled,
+		Reason:   "requested by player",
+		Actor:    "support",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.ChangeStatus(ctx, changeStatus)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidStatusTransition)
+	storageMock.AssertNotCalled(t, "ChangeStatus", ctx, mock.Anything)
+}
+
+func TestChangeStatusOfExpiredSuspension(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	expiredSuspension := time.Now().UTC().Add(-time.Hour)
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusSuspended, &expiredSuspension)
+	changeStatus := players.ChangeStatus{
+		PlayerID: *givenPlayerID,
+		Status:   players.StatusDisabled,
+		Reason:   "requested by player",
+		Actor:    "support",
+	}
+
+	// the suspension expired, so the player is active, but the stored status is still suspended.
+	isExpectedChange := func(change players.StatusChange) bool {
+		return change.From == players.StatusSuspended && change.To == players.StatusDisabled
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangeStatus", ctx, mock.MatchedBy(isExpectedChange)).Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.ChangeStatus(ctx, changeStatus)
+
+	// Then
+	assert.NoError(t, err)
+	storageMock.AssertExpectations(t)
+}
+
+func TestChangeStatusButChangedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusActive, nil)
+	changeStatus := players.ChangeStatus{
+		PlayerID: *givenPlayerID,
+		Status:   players.StatusBanned,
+		Reason:   "cheating",
+		Actor:    "moderator",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, *givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("ChangeStatus", ctx, mock.AnythingOfType("players.StatusChange")).Return(false, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.ChangeStatus(ctx, changeStatus)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidStatusTransition)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestChangeStatusButInvalidData(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	pastDate := time.Now().UTC().Add(-time.Hour)
+	cases := map[string]struct {
+		changeStatus players.ChangeStatus
+		want         string
+	}{
+		"unknown_status": {
+			changeStatus: players.ChangeStatus{Status: "deleted", Reason: "any", Actor: "moderator"},
+			want:         "unable to change player status: status is not valid",
+		},
+		"empty_reason_and_actor": {
+			changeStatus: players.ChangeStatus{Status: players.StatusBanned},
+			want: `unable to change player status: reason is empty
+actor is empty`,
+		},
+		"too_long_reason_and_actor": {
+			changeStatus: players.ChangeStatus{
+				Status: players.StatusBanned, Reason: strings.Repeat("r", 257), Actor: strings.Repeat("a", 129),
+			},
+			want: `unable to change player status: reason is longer than 256 characters
+actor is longer than 128 characters`,
+		},
+		"suspension_without_end": {
+			changeStatus: players.ChangeStatus{Status: players.StatusSuspended, Reason: "any", Actor: "moderator"},
+			want:         "unable to change player status: suspended until is empty",
+		},
+		"suspension_in_the_past": {
+			changeStatus: players.ChangeStatus{
+				Status: players.StatusSuspended, Reason: "any", Actor: "moderator", SuspendedUntil: &pastDate,
+			},
+			want: "unable to change player status: suspended until must be in the future",
+		},
+		"end_without_suspension": {
+			changeStatus: players.ChangeStatus{
+				Status: players.StatusDisabled, Reason: "any", Actor: "moderator", SuspendedUntil: &pastDate,
+			},
+			want: "unable to change player status: suspended until is only allowed to suspend players",
+		},
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+
+			service, _ := unittests.NewPlayerServiceWithStorage(unittests.NewStorageMock())
+
+			// When
+			err := service.ChangeStatus(ctx, data.changeStatus)
+
+			// Then
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			assert.Equal(st, data.want, err.Error())
+		})
+	}
+}
+
+func TestExpireSuspensions(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	firstPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	secondPlayerID := unittests.PlayerIDFixture(t, "7d2b0e4c-1a3f-4d6e-9b8a-5c4f3e2d1a0b")
+
+	isExpiredChange := func(change players.StatusChange) bool {
+		return change.From == players.StatusSuspended &&
+			change.To == players.StatusActive &&
+			change.Actor == "system"
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("ExpireSuspensions", ctx, mock.MatchedBy(isExpiredChange)).
+		Return([]players.PlayerID{*firstPlayerID, *secondPlayerID}, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", players.NewEvent{PlayerID: firstPlayerID.String(), Event: "player suspension expired"})
+	notifierMock.On("Notify", players.NewEvent{PlayerID: secondPlayerID.String(), Event: "player suspension expired"})
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.ExpireSuspensions(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestAuthenticatePlayerButNotActive(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusBanned, nil)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerIsNotActive)
+	assert.Nil(t, got)
+}
+
+func TestAuthenticatePlayerWithExpiredSuspension(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.PlayerIDFixture(t, "b10d6af5-22f3-4db2-ade6-94cfcc819f91")
+	expiredSuspension := time.Now().UTC().Add(-time.Minute)
+	existingPlayer := playerWithStatusFixture(t, givenPlayerID, players.StatusSuspended, &expiredSuspension)
+	credentials := players.Credentials{
+		Login:    "focampo",
+		Password: "th1s1s@dummypwd",
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByEmailOrNickname", ctx, "focampo").Return(&existingPlayer, nil)
+	storageMock.On("SaveLoginAttempt", ctx, mock.AnythingOfType("players.LoginAttempt")).Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Verify", existingPlayer.Password, credentials.Password).Return(true)
+	hasherMock.On("NeedsRehash", existingPlayer.Password).Return(false)
+
+	service, _ := unittests.NewPlayerService(storageMock, hasherMock, unittests.NewNotifierMock())
+
+	// When
+	got, err := service.Authenticate(ctx, credentials)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, givenPlayerID, got)
+}
+
+func playerWithStatusFixture(
+	t *testing.T,
+	playerID *players.PlayerID,
+	status players.Status,
+	suspendedUntil *time.Time,
+) players.Player {
+	t.Helper()
+
+	player := existingPlayerToAuthenticateFixture(t, playerID)
+	player.Status = players.PlayerStatus{
+		Status:         status,
+		Reason:         "any reason",
+		Actor:          "moderator",
+		SuspendedUntil: suspendedUntil,
+	}
+
+	return player
+}
@@ -0,0 +1,39 @@
+package players
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// token field names used in validation errors.
+const (
+	TokenField = "token"
+)
+
+// tokenBytes random bytes of the tokens sent to players, 256 bits of entropy.
+const tokenBytes = 32
+
+var ErrEmptyToken = newFieldError(TokenField, "token is empty")
+
+// newToken generates a random token to send to a player.
+func newToken() (string, error) {
+	randomBytes := make([]byte, tokenBytes)
+
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// hashToken hashes the token to look it up in the storage. Tokens are random
+// and long enough, so a fast hash without salt is enough.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
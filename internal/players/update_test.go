@@ -10,6 +10,7 @@ import (
 	"github.com/fernandoocampo/players/internal/players"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdatePlayer(t *testing.T) {
@@ -17,12 +18,10 @@ func TestUpdatePlayer(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := unittests.NewPlayerID()
-	newPasswordValue := "newpwd"
 	dateCreated := time.Now().UTC()
 	updatePlayer := players.UpdatePlayer{
-		ID:       playerID,
-		Email:    unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
-		Password: &newPasswordValue,
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
 	}
 
 	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, dateCreated)
@@ -32,10 +31,13 @@ func TestUpdatePlayer(t *testing.T) {
 		FirstName:   "Fernando",
 		LastName:    "Ocampo",
 		Nickname:    "focampo",
-		Email:       *unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
-		Password:    []byte("$3a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
+		Email:       *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:    []byte("$2a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6"),
 		Country:     "Spain",
 		DateCreated: dateCreated,
+		Version:     4,
+		// the new email is used once it is verified.
+		PendingEmail: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
 	}
 
 	givenPlayerExistResult := players.PlayerExistResult{
@@ -43,20 +45,19 @@ func TestUpdatePlayer(t *testing.T) {
 		NicknameExist: false,
 	}
 
-	dummyHash := []byte("$3a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
-
-	hasherMock := unittests.NewHasherMock()
-	hasherMock.On("Hash", *updatePlayer.Password).Return(dummyHash, nil)
-
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
-	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(true, nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, nil, notifierMock, mailerMock, time.Hour)
 
 	// When
 	got, err := service.Update(ctx, updatePlayer)
@@ -73,12 +74,10 @@ func TestUpdatePlayerButErrorWhileUpdating(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := unittests.NewPlayerID()
-	newPasswordValue := "newpwd"
 	dateCreated := time.Now().UTC()
 	updatePlayer := players.UpdatePlayer{
-		ID:       playerID,
-		Email:    unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
-		Password: &newPasswordValue,
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
 	}
 
 	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, dateCreated)
@@ -90,22 +89,17 @@ func TestUpdatePlayerButErrorWhileUpdating(t *testing.T) {
 
 	saveError := errors.New("unexpected update error")
 
-	dummyHash := []byte("$3a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
-
 	want := "unable to update player: unexpected update error"
 
-	hasherMock := unittests.NewHasherMock()
-	hasherMock.On("Hash", *updatePlayer.Password).Return(dummyHash, nil)
-
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
-	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(saveError)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(false, saveError)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, _ := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
 
 	// When
 	player, err := service.Update(ctx, updatePlayer)
@@ -121,12 +115,10 @@ func TestUpdatePlayerWithEndpointSuccessfully(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := unittests.NewPlayerID()
-	newPasswordValue := "newpwd"
 	dateCreated := time.Now().UTC()
 	updatePlayer := players.UpdatePlayer{
-		ID:       playerID,
-		Email:    unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
-		Password: &newPasswordValue,
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
 	}
 
 	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, dateCreated)
@@ -138,20 +130,19 @@ func TestUpdatePlayerWithEndpointSuccessfully(t *testing.T) {
 		NicknameExist: false,
 	}
 
-	dummyHash := []byte("$3a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
-
-	hasherMock := unittests.NewHasherMock()
-	hasherMock.On("Hash", *updatePlayer.Password).Return(dummyHash, nil)
-
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
-	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(true, nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).Return(nil)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, logger := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).Return(nil)
+
+	service, logger := unittests.NewPlayerServiceWithEmailVerification(storageMock, nil, notifierMock, mailerMock, time.Hour)
 	updatePlayerEndpoint := players.MakeUpdatePlayerEndpoint(service, logger)
 
 	// When
@@ -167,12 +158,10 @@ func TestUpdatePlayerWithEndpointButError(t *testing.T) {
 	// Given
 	ctx := context.TODO()
 	playerID := unittests.NewPlayerID()
-	newPasswordValue := "newpwd"
 	dateCreated := time.Now().UTC()
 	updatePlayer := players.UpdatePlayer{
-		ID:       playerID,
-		Email:    unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
-		Password: &newPasswordValue,
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
 	}
 
 	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, dateCreated)
@@ -184,24 +173,19 @@ func TestUpdatePlayerWithEndpointButError(t *testing.T) {
 
 	saveError := errors.New("unexpected update error")
 
-	dummyHash := []byte("$3a$04$zedC.nDTul7ks4kELCsb4OldjunQoDkeMisEk822pY6XqtAJo1uo6")
-
 	wantedResult := players.UpdatePlayerResult{
 		Err: "unable to update player: unexpected update error",
 	}
 
-	hasherMock := unittests.NewHasherMock()
-	hasherMock.On("Hash", *updatePlayer.Password).Return(dummyHash, nil)
-
 	storageMock := unittests.NewStorageMock()
 	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
 	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&givenPlayerExistResult, nil)
-	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(saveError)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).Return(false, saveError)
 
 	notifierMock := unittests.NewNotifierMock()
 	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
 
-	service, logger := unittests.NewPlayerService(storageMock, hasherMock, notifierMock)
+	service, logger := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
 	updatePlayerEndpoint := players.MakeUpdatePlayerEndpoint(service, logger)
 
 	// When
@@ -225,5 +209,204 @@ func existingPlayerToUpdateFixture(t *testing.T, playerID *players.PlayerID, dat
 		Country:     "Spain",
 		DateCreated: dateTx,
 		DateUpdated: dateTx,
+		Version:     3,
+	}
+}
+
+func TestUpdatePlayerButExpectedVersionIsStale(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	newLastName := "Restrepo"
+	updatePlayer := players.UpdatePlayer{
+		ID:              playerID,
+		LastName:        &newLastName,
+		ExpectedVersion: 2,
+	}
+
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	got, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	assert.Nil(t, got)
+	storageMock.AssertNotCalled(t, "Update", ctx, mock.Anything)
+}
+
+func TestUpdatePlayerButChangedConcurrently(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	newLastName := "Restrepo"
+	updatePlayer := players.UpdatePlayer{
+		ID:              playerID,
+		LastName:        &newLastName,
+		ExpectedVersion: 3,
+	}
+
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+
+	// the write must be conditional on the version that was read.
+	isReadVersion := func(player players.Player) bool {
+		return player.Version == existingPlayer.Version
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+	storageMock.On("Update", ctx, mock.MatchedBy(isReadVersion)).Return(false, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	assert.Nil(t, got)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestUpdatePlayerAppliesOnlyMaskedPaths(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	updatePlayer := players.UpdatePlayer{
+		ID:         playerID,
+		FirstName:  players.NewString("Fernandito"),
+		LastName:   players.NewString("Restrepo"),
+		Country:    players.NewString("Colombia"),
+		UpdateMask: []string{"lastname", "country"},
+	}
+
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+
+	var storedPlayer players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).
+		Run(func(args mock.Arguments) { storedPlayer = args.Get(1).(players.Player) }).
+		Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	got, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Fernando", storedPlayer.FirstName)
+	assert.Equal(t, "Restrepo", storedPlayer.LastName)
+	assert.Equal(t, "CO", storedPlayer.Country)
+	assert.Equal(t, "Restrepo", got.LastName)
+}
+
+func TestUpdatePlayerClearsPendingEmail(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	updatePlayer := players.UpdatePlayer{
+		ID:         playerID,
+		UpdateMask: []string{"pending_email"},
+	}
+
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+	existingPlayer.PendingEmail = unittests.NewEmailAddress(t, "focampo@anotheremail.com")
+
+	var storedPlayer players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).
+		Run(func(args mock.Arguments) { storedPlayer = args.Get(1).(players.Player) }).
+		Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	_, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Nil(t, storedPlayer.PendingEmail)
+	assert.Equal(t, "focampo@anyemail.com", storedPlayer.Email.Address)
+}
+
+func TestUpdatePlayerButInvalidUpdateMask(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		updatePlayer players.UpdatePlayer
+		want         []*players.FieldError
+	}{
+		"unknown_paths": {
+			updatePlayer: players.UpdatePlayer{
+				UpdateMask: []string{"lastname", "password", "version"},
+				LastName:   players.NewString("Restrepo"),
+			},
+			want: []*players.FieldError{
+				{Field: "update_mask", Description: `path "password" is unknown`},
+				{Field: "update_mask", Description: `path "version" is unknown`},
+			},
+		},
+		"required_fields_cannot_be_cleared": {
+			updatePlayer: players.UpdatePlayer{
+				UpdateMask: []string{"firstname", "email"},
+			},
+			want: []*players.FieldError{
+				{Field: "firstname", Description: "first name is empty"},
+				{Field: "email", Description: "email is empty"},
+			},
+		},
+		"empty_value_without_mask_is_not_ignored": {
+			updatePlayer: players.UpdatePlayer{
+				Nickname: players.NewString(""),
+			},
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is empty"},
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			updatePlayer := data.updatePlayer
+			updatePlayer.ID = unittests.NewPlayerID()
+
+			storageMock := unittests.NewStorageMock()
+
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			got, err := service.Update(ctx, updatePlayer)
+
+			// Then
+			assert.Nil(st, got)
+			assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			assert.Equal(st, data.want, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "GetByID", mock.Anything, mock.Anything)
+		})
 	}
 }
@@ -0,0 +1,80 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+// update mask field names used in validation errors.
+const (
+	PendingEmailField = "pending_email"
+	UpdateMaskField   = "update_mask"
+)
+
+// updatablePaths are the player fields that can be part of an update mask.
+var updatablePaths = map[string]struct{}{
+	FirstNameField:    {},
+	LastNameField:     {},
+	NicknameField:     {},
+	EmailField:        {},
+	CountryField:      {},
+	PendingEmailField: {},
+}
+
+// validateUpdateMask returns an error for every path of the mask that is not an updatable field.
+func (u UpdatePlayer) validateUpdateMask() error {
+	var err error
+
+	for _, path := range u.UpdateMask {
+		if _, ok := updatablePaths[path]; !ok {
+			err = errors.Join(err, newFieldError(UpdateMaskField, fmt.Sprintf("path %q is unknown", path)))
+		}
+	}
+
+	return err
+}
+
+// masked returns the update with exactly the fields of the update mask. Fields in the mask
+// without value are cleared, required fields are then rejected as empty by the validation.
+func (u UpdatePlayer) masked() UpdatePlayer {
+	if len(u.UpdateMask) == 0 {
+		return u
+	}
+
+	masked := UpdatePlayer{
+		ID:              u.ID,
+		ExpectedVersion: u.ExpectedVersion,
+		UpdateMask:      u.UpdateMask,
+	}
+
+	for _, path := range u.UpdateMask {
+		switch path {
+		case FirstNameField:
+			masked.FirstName = valueOrEmpty(u.FirstName)
+		case LastNameField:
+			masked.LastName = valueOrEmpty(u.LastName)
+		case NicknameField:
+			masked.Nickname = valueOrEmpty(u.Nickname)
+		case CountryField:
+			masked.Country = valueOrEmpty(u.Country)
+		case EmailField:
+			masked.Email = &mail.Address{}
+			if u.Email != nil {
+				masked.Email = u.Email
+			}
+		case PendingEmailField:
+			masked.ClearPendingEmail = true
+		}
+	}
+
+	return masked
+}
+
+func valueOrEmpty(value *string) *string {
+	if value == nil {
+		return NewString("")
+	}
+
+	return value
+}
@@ -0,0 +1,222 @@
+package players
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of the player fields, max lengths match the size of the players table columns.
+const (
+	maxNameLength     = 64
+	maxNicknameLength = 64
+	maxEmailLength    = 128
+	minNicknameLength = 3
+	minPasswordLength = 8
+	// maxPasswordBytes is the longest password bcrypt can hash, it applies to every
+	// algorithm so passwords can be hashed again when the algorithm changes.
+	maxPasswordBytes = 72
+)
+
+// rule checks a field value and returns the description of the violation,
+// or an empty string if the value satisfies the rule.
+type rule func(value string) string
+
+// fieldRules declares the rules a player field must satisfy.
+type fieldRules struct {
+	field string
+	// emptyErr is the only violation reported for an empty value.
+	emptyErr *FieldError
+	rules    []rule
+}
+
+var nicknamePattern = regexp.MustCompile(`^[\p{L}\p{N}_.-]+$`)
+
+// reservedNicknames can't be taken by players because they could be used to impersonate
+// the staff. They are compared by their canonical form, so "Admin" or "аdmin" with a
+// cyrillic "а" are reserved too.
+var reservedNicknames = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"moderator":     {},
+	"mod":           {},
+	"root":          {},
+	"staff":         {},
+	"support":       {},
+	"system":        {},
+	"official":      {},
+	"anonymous":     {},
+	"null":          {},
+}
+
+var (
+	firstNameRules = fieldRules{
+		field:    FirstNameField,
+		emptyErr: ErrEmptyFirstName,
+		rules: []rule{
+			maxLength("first name", maxNameLength),
+			withoutControlCharacters("first name"),
+		},
+	}
+	lastNameRules = fieldRules{
+		field:    LastNameField,
+		emptyErr: ErrEmptyLastName,
+		rules: []rule{
+			maxLength("last name", maxNameLength),
+			withoutControlCharacters("last name"),
+		},
+	}
+	nicknameRules = fieldRules{
+		field:    NicknameField,
+		emptyErr: ErrEmptyNickname,
+		rules: []rule{
+			minLength("nickname", minNicknameLength),
+			maxLength("nickname", maxNicknameLength, CanonicalNickname),
+			matches(nicknamePattern, "nickname can only contain letters, digits, dots, dashes and underscores"),
+			notReservedNickname,
+		},
+	}
+	emailRules = fieldRules{
+		field:    EmailField,
+		emptyErr: ErrEmptyEmail,
+		rules: []rule{
+			maxLength("email", maxEmailLength, CanonicalEmail),
+			emailAddress,
+		},
+	}
+	countryRules = fieldRules{
+		field:    CountryField,
+		emptyErr: ErrEmptyCountry,
+		rules: []rule{
+			isValidCountry,
+		},
+	}
+	passwordRules = fieldRules{
+		field:    PasswordField,
+		emptyErr: ErrEmptyPassword,
+		rules: []rule{
+			minLength("password", minPasswordLength),
+			maxBytes("password", maxPasswordBytes),
+			containsAny("password must contain a letter", unicode.IsLetter),
+			containsAny("password must contain a digit", unicode.IsDigit),
+		},
+	}
+)
+
+// validate returns all the violations of the given value joined.
+func (f fieldRules) validate(value string) error {
+	if value == "" {
+		return f.emptyErr
+	}
+
+	var err error
+
+	for _, fieldRule := range f.rules {
+		if description := fieldRule(value); description != "" {
+			err = errors.Join(err, newFieldError(f.field, description))
+		}
+	}
+
+	return err
+}
+
+// validateOptional validates the given value only if it is present.
+func (f fieldRules) validateOptional(value *string) error {
+	if value == nil {
+		return nil
+	}
+
+	return f.validate(*value)
+}
+
+func minLength(name string, limit int) rule {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) < limit {
+			return fmt.Sprintf("%s is shorter than %d characters", name, limit)
+		}
+
+		return ""
+	}
+}
+
+// maxLength limits the characters of the value and of its canonical forms,
+// because the canonical forms are stored in columns of the same size.
+func maxLength(name string, limit int, canonicalForms ...func(string) string) rule {
+	return func(value string) string {
+		length := utf8.RuneCountInString(value)
+
+		for _, canonicalForm := range canonicalForms {
+			length = max(length, utf8.RuneCountInString(canonicalForm(value)))
+		}
+
+		if length > limit {
+			return fmt.Sprintf("%s is longer than %d characters", name, limit)
+		}
+
+		return ""
+	}
+}
+
+// maxBytes limits the bytes of the value, for values whose limit is not given in characters.
+func maxBytes(name string, limit int) rule {
+	return func(value string) string {
+		if len(value) > limit {
+			return fmt.Sprintf("%s is longer than %d bytes", name, limit)
+		}
+
+		return ""
+	}
+}
+
+func matches(pattern *regexp.Regexp, description string) rule {
+	return func(value string) string {
+		if !pattern.MatchString(value) {
+			return description
+		}
+
+		return ""
+	}
+}
+
+func containsAny(description string, isValid func(rune) bool) rule {
+	return func(value string) string {
+		if !strings.ContainsFunc(value, isValid) {
+			return description
+		}
+
+		return ""
+	}
+}
+
+func withoutControlCharacters(name string) rule {
+	return func(value string) string {
+		if strings.ContainsFunc(value, unicode.IsControl) {
+			return name + " contains control characters"
+		}
+
+		return ""
+	}
+}
+
+func notReservedNickname(value string) string {
+	if _, ok := reservedNicknames[CanonicalNickname(value)]; ok {
+		return "nickname is reserved"
+	}
+
+	return ""
+}
+
+// emailAddress accepts a bare address as defined by RFC 5322, display names
+// and angle brackets are rejected.
+func emailAddress(value string) string {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return "email is not a valid address"
+	}
+
+	return ""
+}
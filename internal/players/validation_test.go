@@ -0,0 +1,213 @@
+package players_test
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNewPlayerFieldRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		change func(newPlayer *players.NewPlayer)
+		want   []*players.FieldError
+	}{
+		"first_name_too_long": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.FirstName = strings.Repeat("a", 65) },
+			want: []*players.FieldError{
+				{Field: "firstname", Description: "first name is longer than 64 characters"},
+			},
+		},
+		"last_name_with_control_characters": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.LastName = "Oca\x00mpo" },
+			want: []*players.FieldError{
+				{Field: "lastname", Description: "last name contains control characters"},
+			},
+		},
+		"multibyte_name_with_max_length": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.FirstName = strings.Repeat("ñ", 64) },
+		},
+		"nickname_too_short": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "fo" },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is shorter than 3 characters"},
+			},
+		},
+		"nickname_too_long": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = strings.Repeat("f", 65) },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is longer than 64 characters"},
+			},
+		},
+		"nickname_whose_canonical_form_is_too_long": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = strings.Repeat("ß", 40) },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is longer than 64 characters"},
+			},
+		},
+		"nickname_with_invalid_characters": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "f ocampo!" },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname can only contain letters, digits, dots, dashes and underscores"},
+			},
+		},
+		"nickname_with_other_scripts": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "jugador_ñ.1" },
+		},
+		"reserved_nickname": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "Admin" },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is reserved"},
+			},
+		},
+		"reserved_nickname_with_lookalikes": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Nickname = "rооt" },
+			want: []*players.FieldError{
+				{Field: "nickname", Description: "nickname is reserved"},
+			},
+		},
+		"email_without_domain": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Email = mail.Address{Address: "focampo"} },
+			want: []*players.FieldError{
+				{Field: "email", Description: "email is not a valid address"},
+			},
+		},
+		"email_with_display_name": {
+			change: func(newPlayer *players.NewPlayer) {
+				newPlayer.Email = mail.Address{Address: "Fernando <focampo@anyemail.com>"}
+			},
+			want: []*players.FieldError{
+				{Field: "email", Description: "email is not a valid address"},
+			},
+		},
+		"email_too_long": {
+			change: func(newPlayer *players.NewPlayer) {
+				newPlayer.Email = mail.Address{Address: strings.Repeat("f", 64) + "@" + strings.Repeat("a", 60) + ".com"}
+			},
+			want: []*players.FieldError{
+				{Field: "email", Description: "email is longer than 128 characters"},
+			},
+		},
+		"country_is_not_iso": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Country = "Narnia" },
+			want: []*players.FieldError{
+				{Field: "country", Description: "country is not an ISO 3166-1 country"},
+			},
+		},
+		"country_alpha3_code": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Country = "col" },
+		},
+		"weak_password": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Password = "abc" },
+			want: []*players.FieldError{
+				{Field: "password", Description: "password is shorter than 8 characters"},
+				{Field: "password", Description: "password must contain a digit"},
+			},
+		},
+		"password_without_letters": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Password = "12345678" },
+			want: []*players.FieldError{
+				{Field: "password", Description: "password must contain a letter"},
+			},
+		},
+		"password_too_long": {
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Password = strings.Repeat("a1", 65) },
+			want: []*players.FieldError{
+				{Field: "password", Description: "password is longer than 72 bytes"},
+			},
+		},
+		"password_too_long_in_bytes": {
+			// 50 characters but 75 bytes.
+			change: func(newPlayer *players.NewPlayer) { newPlayer.Password = strings.Repeat("ñ1", 25) },
+			want: []*players.FieldError{
+				{Field: "password", Description: "password is longer than 72 bytes"},
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			newPlayer := validNewPlayerFixture(st)
+			data.change(&newPlayer)
+
+			// When
+			err := newPlayer.Validate()
+
+			// Then
+			assert.Equal(st, data.want, players.FieldErrors(err))
+
+			if data.want != nil {
+				assert.ErrorIs(st, err, players.ErrInvalidPlayerData)
+			}
+		})
+	}
+}
+
+func TestValidateNewPlayerReturnsAllViolations(t *testing.T) {
+	t.Parallel()
+	// Given
+	newPlayer := players.NewPlayer{
+		FirstName: strings.Repeat("F", 65),
+		Nickname:  "system",
+		Email:     mail.Address{Address: "focampo@"},
+		Password:  "password",
+		Country:   "Spain",
+	}
+
+	want := []*players.FieldError{
+		{Field: "firstname", Description: "first name is longer than 64 characters"},
+		{Field: "lastname", Description: "last name is empty"},
+		{Field: "nickname", Description: "nickname is reserved"},
+		{Field: "email", Description: "email is not a valid address"},
+		{Field: "password", Description: "password must contain a digit"},
+	}
+
+	// When
+	err := newPlayer.Validate()
+
+	// Then
+	assert.Equal(t, want, players.FieldErrors(err))
+}
+
+func TestValidateUpdatePlayerFieldRules(t *testing.T) {
+	t.Parallel()
+	// Given
+	updatePlayer := players.UpdatePlayer{
+		LastName: players.NewString(strings.Repeat("O", 65)),
+		Nickname: players.NewString("moderator"),
+		Email:    &mail.Address{Address: "focampo.anyemail.com"},
+	}
+
+	want := []*players.FieldError{
+		{Field: "lastname", Description: "last name is longer than 64 characters"},
+		{Field: "nickname", Description: "nickname is reserved"},
+		{Field: "email", Description: "email is not a valid address"},
+	}
+
+	// When
+	err := updatePlayer.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrInvalidPlayerData)
+	assert.Equal(t, want, players.FieldErrors(err))
+}
+
+func validNewPlayerFixture(t *testing.T) players.NewPlayer {
+	t.Helper()
+
+	return players.NewPlayer{
+		FirstName: "Fernando",
+		LastName:  "Ocampo",
+		Nickname:  "focampo",
+		Email:     *unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+		Password:  "th1s1s@dummypwd",
+		Country:   "Spain",
+	}
+}
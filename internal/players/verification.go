@@ -0,0 +1,142 @@
+package players
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+// VerifyEmail contains data required to verify the email of a player.
+type VerifyEmail struct {
+	Token string
+}
+
+// EmailVerificationToken is a single-use token to verify an email of a player. Only the
+// hash of the token is stored, the token itself is only sent to the email to verify.
+type EmailVerificationToken struct {
+	TokenHash string
+	PlayerID  PlayerID
+	// Email address the token was sent to.
+	Email       string
+	DateExpires time.Time
+	DateCreated time.Time
+}
+
+// EmailVerification contains data to store the verified email of a player.
+type EmailVerification struct {
+	PlayerID PlayerID
+	// Version of the player the token was checked against, the verification
+	// is not stored if the player was changed in the meantime.
+	Version int64
+	// Email verified, it replaces the current email of the player.
+	Email string
+	// PendingEmail still waiting to be verified after this verification, if any.
+	PendingEmail *mail.Address
+	// TokenHash of the token used, it is consumed with the verification.
+	TokenHash    string
+	DateVerified time.Time
+}
+
+// VerifyEmailResult standard response for verifying the email of a Player.
+type VerifyEmailResult struct {
+	Err string
+}
+
+// ErrInvalidVerificationToken is returned when the token does not exist, expired, was already
+// used or was sent to an email the player does not use anymore.
+var ErrInvalidVerificationToken = newFieldError(TokenField, "token is invalid or expired")
+
+// Validate checks the token is present.
+func (v VerifyEmail) Validate() error {
+	if v.Token == "" {
+		return ErrEmptyToken
+	}
+
+	return nil
+}
+
+func (v VerifyEmail) obfuscate() VerifyEmail {
+	obfuscated := v
+	obfuscated.Token = redactedValue
+
+	return obfuscated
+}
+
+// IsExpired indicates if the token cannot be used anymore at the given date.
+func (e EmailVerificationToken) IsExpired(now time.Time) bool {
+	return !e.DateExpires.After(now)
+}
+
+// toEmailVerification returns the verification of the token email for the given player. It returns
+// false if the player does not use the token email anymore, neither as current nor as pending email.
+func (e EmailVerificationToken) toEmailVerification(player Player, now time.Time) (EmailVerification, bool) {
+	verification := EmailVerification{
+		PlayerID:     *player.ID,
+		Version:      player.Version,
+		TokenHash:    e.TokenHash,
+		DateVerified: now,
+	}
+
+	tokenEmail := CanonicalEmail(e.Email)
+
+	switch {
+	case player.PendingEmail != nil && CanonicalEmail(player.PendingEmail.Address) == tokenEmail:
+		verification.Email = player.PendingEmail.Address
+	case CanonicalEmail(player.Email.Address) == tokenEmail:
+		verification.Email = player.Email.Address
+		verification.PendingEmail = player.PendingEmail
+	default:
+		return EmailVerification{}, false
+	}
+
+	return verification, true
+}
+
+// newEmailVerificationToken generates a random token and the EmailVerificationToken to store for it.
+func newEmailVerificationToken(playerID PlayerID, email string, now time.Time, ttl time.Duration) (string, EmailVerificationToken, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", EmailVerificationToken{}, err
+	}
+
+	verificationToken := EmailVerificationToken{
+		TokenHash:   hashToken(token),
+		PlayerID:    playerID,
+		Email:       email,
+		DateExpires: now.Add(ttl),
+		DateCreated: now,
+	}
+
+	return token, verificationToken, nil
+}
+
+func newEmailVerificationMail(player *Player, email, token string, expires time.Time) Mail {
+	return Mail{
+		To:      email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nuse this token to verify your email: %s\n\nIt expires at %s and can be used only once. "+
+				"If you did not use this email in your player account, you can ignore this message.",
+			player.Nickname, token, expires.Format(time.RFC3339),
+		),
+	}
+}
+
+// newVerifyEmailResult create a new VerifyEmailResult.
+func newVerifyEmailResult(err error) VerifyEmailResult {
+	var errmessage string
+	if err != nil {
+		errmessage = err.Error()
+	}
+
+	return VerifyEmailResult{
+		Err: errmessage,
+	}
+}
+
+func newEmailVerifiedEvent(playerID PlayerID) NewEvent {
+	return NewEvent{
+		PlayerID: playerID.String(),
+		Event:    "player email was verified",
+	}
+}
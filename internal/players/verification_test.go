@@ -0,0 +1,336 @@
+package players_test
+
+import (
+	"context"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/fernandoocampo/players/internal/appkit/unittests"
+	"github.com/fernandoocampo/players/internal/players"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePlayerSendsEmailVerification(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	newPlayer := unittests.NewPlayerFixture(t)
+	ttl := 24 * time.Hour
+
+	var savedToken players.EmailVerificationToken
+
+	var sentMail players.Mail
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("Save", ctx, mock.AnythingOfType("players.Player")).Return(nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).
+		Run(func(args mock.Arguments) { savedToken = args.Get(1).(players.EmailVerificationToken) }).
+		Return(nil)
+
+	hasherMock := unittests.NewHasherMock()
+	hasherMock.On("Hash", newPlayer.Password).Return([]byte("$2a$04$dummyhash"), nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).
+		Run(func(args mock.Arguments) { sentMail = args.Get(1).(players.Mail) }).
+		Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, hasherMock, notifierMock, mailerMock, ttl)
+
+	// When
+	player, err := service.Create(ctx, newPlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.False(t, player.EmailVerified)
+	assert.Nil(t, player.PendingEmail)
+	assert.Equal(t, *player.ID, savedToken.PlayerID)
+	assert.Equal(t, "focampo@anyemail.com", savedToken.Email)
+	assert.Equal(t, ttl, savedToken.DateExpires.Sub(savedToken.DateCreated))
+	assert.Equal(t, "focampo@anyemail.com", sentMail.To)
+	assert.Equal(t, hashToken(tokenFromMail(t, sentMail)), savedToken.TokenHash)
+}
+
+func TestUpdatePlayerEmailKeepsCurrentEmailUntilVerified(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+	existingPlayer.EmailVerified = true
+	updatePlayer := players.UpdatePlayer{
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anotheremail.com"),
+	}
+
+	var storedPlayer players.Player
+
+	var savedToken players.EmailVerificationToken
+
+	var sentMail players.Mail
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).
+		Run(func(args mock.Arguments) { storedPlayer = args.Get(1).(players.Player) }).
+		Return(true, nil)
+	storageMock.On("SaveEmailVerificationToken", ctx, mock.AnythingOfType("players.EmailVerificationToken")).
+		Run(func(args mock.Arguments) { savedToken = args.Get(1).(players.EmailVerificationToken) }).
+		Return(nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	mailerMock := unittests.NewMailerMock()
+	mailerMock.On("Send", ctx, mock.AnythingOfType("players.Mail")).
+		Run(func(args mock.Arguments) { sentMail = args.Get(1).(players.Mail) }).
+		Return(nil)
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, nil, notifierMock, mailerMock, time.Hour)
+
+	// When
+	got, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "focampo@anyemail.com", storedPlayer.Email.Address)
+	assert.True(t, storedPlayer.EmailVerified)
+	assert.Equal(t, updatePlayer.Email, storedPlayer.PendingEmail)
+	assert.Equal(t, updatePlayer.Email, got.PendingEmail)
+	assert.Equal(t, "focampo@anotheremail.com", savedToken.Email)
+	assert.Equal(t, "focampo@anotheremail.com", sentMail.To)
+	assert.Equal(t, hashToken(tokenFromMail(t, sentMail)), savedToken.TokenHash)
+}
+
+func TestUpdatePlayerWithCurrentEmailCancelsPendingEmail(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	playerID := unittests.NewPlayerID()
+	existingPlayer := existingPlayerToUpdateFixture(t, &playerID, time.Now().UTC())
+	existingPlayer.PendingEmail = unittests.NewEmailAddress(t, "focampo@anotheremail.com")
+	updatePlayer := players.UpdatePlayer{
+		ID:    playerID,
+		Email: unittests.NewEmailAddress(t, "focampo@anyemail.com"),
+	}
+
+	var storedPlayer players.Player
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetByID", ctx, playerID).Return(&existingPlayer, nil)
+	storageMock.On("GetPlayersWithEmailOrNickName", ctx, mock.AnythingOfType("players.PlayerFilter")).Return(&players.PlayerExistResult{}, nil)
+	storageMock.On("Update", ctx, mock.AnythingOfType("players.Player")).
+		Run(func(args mock.Arguments) { storedPlayer = args.Get(1).(players.Player) }).
+		Return(true, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+	notifierMock.On("Notify", mock.AnythingOfType("players.NewEvent"))
+
+	mailerMock := unittests.NewMailerMock()
+
+	service, _ := unittests.NewPlayerServiceWithEmailVerification(storageMock, nil, notifierMock, mailerMock, time.Hour)
+
+	// When
+	_, err := service.Update(ctx, updatePlayer)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "focampo@anyemail.com", storedPlayer.Email.Address)
+	assert.Nil(t, storedPlayer.PendingEmail)
+	storageMock.AssertNotCalled(t, "SaveEmailVerificationToken", mock.Anything, mock.Anything)
+	mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tokenEmail   string
+		pendingEmail string
+		want         players.EmailVerification
+	}{
+		"pending_email": {
+			tokenEmail:   "focampo@anotheremail.com",
+			pendingEmail: "focampo@anotheremail.com",
+			want: players.EmailVerification{
+				Email: "focampo@anotheremail.com",
+			},
+		},
+		"current_email_keeps_pending_email": {
+			tokenEmail:   "FOCAMPO@anyemail.com",
+			pendingEmail: "focampo@anotheremail.com",
+			want: players.EmailVerification{
+				Email:        "focampo@anyemail.com",
+				PendingEmail: &mail.Address{Address: "focampo@anotheremail.com"},
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			givenPlayerID := unittests.NewPlayerID()
+			existingPlayer := existingPlayerToUpdateFixture(st, &givenPlayerID, time.Now().UTC())
+			existingPlayer.PendingEmail = unittests.NewEmailAddress(st, data.pendingEmail)
+			verifyEmail := players.VerifyEmail{
+				Token: "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+			}
+			storedToken := players.EmailVerificationToken{
+				TokenHash:   hashToken(verifyEmail.Token),
+				PlayerID:    givenPlayerID,
+				Email:       data.tokenEmail,
+				DateExpires: time.Now().UTC().Add(time.Hour),
+				DateCreated: time.Now().UTC(),
+			}
+
+			var gotVerification players.EmailVerification
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetEmailVerificationToken", ctx, storedToken.TokenHash).Return(&storedToken, nil)
+			storageMock.On("GetByID", ctx, givenPlayerID).Return(&existingPlayer, nil)
+			storageMock.On("VerifyEmail", ctx, mock.AnythingOfType("players.EmailVerification")).
+				Run(func(args mock.Arguments) { gotVerification = args.Get(1).(players.EmailVerification) }).
+				Return(true, nil)
+
+			notifierMock := unittests.NewNotifierMock()
+			notifierMock.On("Notify", players.NewEvent{PlayerID: givenPlayerID.String(), Event: "player email was verified"})
+
+			service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+			// When
+			err := service.VerifyEmail(ctx, verifyEmail)
+
+			// Then
+			require.NoError(st, err)
+			assert.Equal(st, givenPlayerID, gotVerification.PlayerID)
+			assert.Equal(st, existingPlayer.Version, gotVerification.Version)
+			assert.Equal(st, storedToken.TokenHash, gotVerification.TokenHash)
+			assert.Equal(st, data.want.Email, gotVerification.Email)
+			assert.Equal(st, data.want.PendingEmail, gotVerification.PendingEmail)
+			notifierMock.AssertExpectations(st)
+		})
+	}
+}
+
+func TestVerifyEmailButInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		storedToken func(tokenHash string, playerID players.PlayerID) *players.EmailVerificationToken
+	}{
+		"unknown_token": {
+			storedToken: func(string, players.PlayerID) *players.EmailVerificationToken { return nil },
+		},
+		"expired_token": {
+			storedToken: func(tokenHash string, playerID players.PlayerID) *players.EmailVerificationToken {
+				return &players.EmailVerificationToken{
+					TokenHash:   tokenHash,
+					PlayerID:    playerID,
+					Email:       "focampo@anyemail.com",
+					DateExpires: time.Now().UTC().Add(-time.Minute),
+					DateCreated: time.Now().UTC().Add(-time.Hour),
+				}
+			},
+		},
+		"email_not_used_anymore": {
+			storedToken: func(tokenHash string, playerID players.PlayerID) *players.EmailVerificationToken {
+				return &players.EmailVerificationToken{
+					TokenHash:   tokenHash,
+					PlayerID:    playerID,
+					Email:       "focampo@oldemail.com",
+					DateExpires: time.Now().UTC().Add(time.Hour),
+					DateCreated: time.Now().UTC(),
+				}
+			},
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(st *testing.T) {
+			st.Parallel()
+			// Given
+			ctx := context.TODO()
+			givenPlayerID := unittests.NewPlayerID()
+			existingPlayer := existingPlayerToUpdateFixture(st, &givenPlayerID, time.Now().UTC())
+			verifyEmail := players.VerifyEmail{
+				Token: "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+			}
+			tokenHash := hashToken(verifyEmail.Token)
+
+			storageMock := unittests.NewStorageMock()
+			storageMock.On("GetEmailVerificationToken", ctx, tokenHash).Return(data.storedToken(tokenHash, givenPlayerID), nil)
+			storageMock.On("GetByID", ctx, givenPlayerID).Return(&existingPlayer, nil)
+
+			service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+			// When
+			err := service.VerifyEmail(ctx, verifyEmail)
+
+			// Then
+			assert.ErrorIs(st, err, players.ErrInvalidVerificationToken)
+			assert.Equal(st, []*players.FieldError{
+				{Field: "token", Description: "token is invalid or expired"},
+			}, players.FieldErrors(err))
+			storageMock.AssertNotCalled(st, "VerifyEmail", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestVerifyEmailButTokenAlreadyUsed(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	givenPlayerID := unittests.NewPlayerID()
+	existingPlayer := existingPlayerToUpdateFixture(t, &givenPlayerID, time.Now().UTC())
+	verifyEmail := players.VerifyEmail{
+		Token: "pV3qFv1o8Qm0Jtr8yZbq2Rkq4y5n3b3u8TQ3Gm0Hf0s",
+	}
+	storedToken := players.EmailVerificationToken{
+		TokenHash:   hashToken(verifyEmail.Token),
+		PlayerID:    givenPlayerID,
+		Email:       "focampo@anyemail.com",
+		DateExpires: time.Now().UTC().Add(time.Hour),
+	}
+
+	storageMock := unittests.NewStorageMock()
+	storageMock.On("GetEmailVerificationToken", ctx, storedToken.TokenHash).Return(&storedToken, nil)
+	storageMock.On("GetByID", ctx, givenPlayerID).Return(&existingPlayer, nil)
+	storageMock.On("VerifyEmail", ctx, mock.AnythingOfType("players.EmailVerification")).Return(false, nil)
+
+	notifierMock := unittests.NewNotifierMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorageAndNotifier(storageMock, notifierMock)
+
+	// When
+	err := service.VerifyEmail(ctx, verifyEmail)
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrPlayerVersionConflict)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestVerifyEmailButEmptyToken(t *testing.T) {
+	t.Parallel()
+	// Given
+	ctx := context.TODO()
+	storageMock := unittests.NewStorageMock()
+
+	service, _ := unittests.NewPlayerServiceWithStorage(storageMock)
+
+	// When
+	err := service.VerifyEmail(ctx, players.VerifyEmail{})
+
+	// Then
+	assert.ErrorIs(t, err, players.ErrEmptyToken)
+	storageMock.AssertNotCalled(t, "GetEmailVerificationToken", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,112 @@
+package players
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WorkerSetup encapsulates worker parameters.
+type WorkerSetup struct {
+	// Name identifies the worker in logs.
+	Name string
+	// Task is run every interval.
+	Task func(ctx context.Context) error
+	// Interval time between runs.
+	Interval time.Duration
+	Logger   *slog.Logger
+}
+
+// Worker runs a background task periodically, e.g. purging deleted players
+// or expiring suspensions.
+type Worker struct {
+	name     string
+	task     func(ctx context.Context) error
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// defaultWorkerInterval is used if no interval is given.
+const defaultWorkerInterval = time.Hour
+
+// NewWorker create a new worker instance.
+func NewWorker(setup WorkerSetup) *Worker {
+	if setup.Interval <= 0 {
+		setup.Interval = defaultWorkerInterval
+	}
+
+	newWorker := Worker{
+		name:     setup.Name,
+		task:     setup.Task,
+		interval: setup.Interval,
+		logger:   setup.Logger,
+	}
+
+	return &newWorker
+}
+
+// NewPurger create a worker that purges deleted players every interval.
+func NewPurger(service *Service, interval time.Duration, logger *slog.Logger) *Worker {
+	return NewWorker(WorkerSetup{
+		Name: "purger",
+		Task: func(ctx context.Context) error {
+			_, err := service.PurgeDeletedPlayers(ctx)
+
+			return err
+		},
+		Interval: interval,
+		Logger:   logger,
+	})
+}
+
+// NewSuspensionExpirer create a worker that activates players whose suspension expired every interval.
+func NewSuspensionExpirer(service *Service, interval time.Duration, logger *slog.Logger) *Worker {
+	return NewWorker(WorkerSetup{
+		Name: "suspension-expirer",
+		Task: func(ctx context.Context) error {
+			_, err := service.ExpireSuspensions(ctx)
+
+			return err
+		},
+		Interval: interval,
+		Logger:   logger,
+	})
+}
+
+// NewIdempotencyKeyPurger create a worker that removes expired idempotency keys every interval.
+func NewIdempotencyKeyPurger(service *Service, interval time.Duration, logger *slog.Logger) *Worker {
+	return NewWorker(WorkerSetup{
+		Name: "idempotency-key-purger",
+		Task: func(ctx context.Context) error {
+			_, err := service.PurgeExpiredIdempotencyKeys(ctx)
+
+			return err
+		},
+		Interval: interval,
+		Logger:   logger,
+	})
+}
+
+// Start runs the task every interval until the context is cancelled.
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Info("starting background worker", slog.String("worker", w.name), slog.Duration("interval", w.interval))
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				w.logger.Info("context was cancelled, ending background worker", slog.String("worker", w.name))
+
+				return
+			case <-ticker.C:
+				err := w.task(ctx)
+				if err != nil {
+					w.logger.Error("running background worker task", slog.String("worker", w.name), slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS login_attempts;
+
+ALTER TABLE players
+    DROP COLUMN IF EXISTS failed_login_attempts,
+    DROP COLUMN IF EXISTS locked_until;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE,
+    source VARCHAR(128) NOT NULL,
+    succeeded BOOLEAN NOT NULL,
+    date_created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_source_date_idx ON login_attempts (source, date_created);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,16 @@
+BEGIN;
+
+DELETE FROM players WHERE date_deleted IS NOT NULL;
+
+DROP INDEX IF EXISTS players_date_deleted_idx;
+DROP INDEX IF EXISTS players_active_email_idx;
+DROP INDEX IF EXISTS players_active_nickname_idx;
+
+ALTER TABLE players
+    ADD CONSTRAINT players_nickname_key UNIQUE (nickname),
+    ADD CONSTRAINT players_email_key UNIQUE (email);
+
+ALTER TABLE players
+    DROP COLUMN IF EXISTS date_deleted;
+
+COMMIT;
\ No newline at end of file
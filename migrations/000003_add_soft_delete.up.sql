@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS date_deleted TIMESTAMP;
+
+-- deleted players do not hold their email and nickname, so uniqueness
+-- is only enforced on players that are not deleted.
+ALTER TABLE players
+    DROP CONSTRAINT IF EXISTS players_nickname_key,
+    DROP CONSTRAINT IF EXISTS players_email_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS players_active_nickname_idx ON players (nickname) WHERE date_deleted IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS players_active_email_idx ON players (email) WHERE date_deleted IS NULL;
+CREATE INDEX IF NOT EXISTS players_date_deleted_idx ON players (date_deleted) WHERE date_deleted IS NOT NULL;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP TABLE IF EXISTS player_status_changes;
+
+DROP INDEX IF EXISTS players_suspended_until_idx;
+DROP INDEX IF EXISTS players_status_idx;
+
+ALTER TABLE players
+    DROP CONSTRAINT IF EXISTS players_status_check,
+    DROP COLUMN IF EXISTS date_status_changed,
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS status_actor,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,27 @@
+BEGIN;
+
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS status_reason VARCHAR(256),
+    ADD COLUMN IF NOT EXISTS status_actor VARCHAR(128),
+    ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS date_status_changed TIMESTAMP,
+    ADD CONSTRAINT players_status_check CHECK (status IN ('active', 'disabled', 'suspended', 'banned'));
+
+CREATE INDEX IF NOT EXISTS players_status_idx ON players (status);
+CREATE INDEX IF NOT EXISTS players_suspended_until_idx ON players (suspended_until) WHERE status = 'suspended';
+
+CREATE TABLE IF NOT EXISTS player_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    reason VARCHAR(256),
+    actor VARCHAR(128) NOT NULL,
+    suspended_until TIMESTAMP,
+    date_created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS player_status_changes_player_idx ON player_status_changes (player_id, date_created);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE players
+    DROP COLUMN IF EXISTS version;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- version is incremented on every change of the player, writes that
+-- expect an older version are rejected.
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS players_active_lookup_email_idx;
+DROP INDEX IF EXISTS players_active_lookup_nickname_idx;
+DROP INDEX IF EXISTS players_active_canonical_email_idx;
+DROP INDEX IF EXISTS players_active_canonical_nickname_idx;
+
+ALTER TABLE players
+    DROP COLUMN IF EXISTS canonical_email,
+    DROP COLUMN IF EXISTS canonical_nickname;
+
+COMMIT;
\ No newline at end of file
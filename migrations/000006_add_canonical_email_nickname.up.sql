@@ -0,0 +1,41 @@
+BEGIN;
+
+-- canonical forms are computed by the service, email and nickname keep the
+-- form given by the player to display it. They are stored instead of indexing
+-- an expression because the service folds cases and replaces confusable
+-- characters, which can't be reproduced by an immutable SQL function.
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS canonical_nickname VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS canonical_email VARCHAR(128);
+
+-- the canonical form of an ascii nickname or email is its lower case form, so
+-- existing players get it here unless another active player has the same one.
+-- The rest get it from the canonicalizeplayers command, which reports players
+-- that collide instead of failing here.
+UPDATE players p
+SET canonical_nickname = lower(p.nickname)
+WHERE p.canonical_nickname IS NULL
+    AND octet_length(p.nickname) = char_length(p.nickname)
+    AND (p.date_deleted IS NOT NULL OR NOT EXISTS (
+        SELECT 1 FROM players o
+        WHERE o.id <> p.id AND o.date_deleted IS NULL AND lower(o.nickname) = lower(p.nickname)));
+
+UPDATE players p
+SET canonical_email = lower(p.email)
+WHERE p.canonical_email IS NULL
+    AND octet_length(p.email) = char_length(p.email)
+    AND (p.date_deleted IS NOT NULL OR NOT EXISTS (
+        SELECT 1 FROM players o
+        WHERE o.id <> p.id AND o.date_deleted IS NULL AND lower(o.email) = lower(p.email)));
+
+-- Null values are not compared by unique indexes, the nickname and email indexes
+-- keep protecting players without canonical forms.
+CREATE UNIQUE INDEX IF NOT EXISTS players_active_canonical_nickname_idx ON players (canonical_nickname) WHERE date_deleted IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS players_active_canonical_email_idx ON players (canonical_email) WHERE date_deleted IS NULL;
+
+-- players are looked up by their canonical forms, or the lower case form of their
+-- values if they don't have them yet, so they can still authenticate.
+CREATE INDEX IF NOT EXISTS players_active_lookup_nickname_idx ON players (COALESCE(canonical_nickname, lower(nickname))) WHERE date_deleted IS NULL;
+CREATE INDEX IF NOT EXISTS players_active_lookup_email_idx ON players (COALESCE(canonical_email, lower(email))) WHERE date_deleted IS NULL;
+
+COMMIT;
\ No newline at end of file
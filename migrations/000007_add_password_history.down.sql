@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS player_password_history;
+
+COMMIT;
\ No newline at end of file
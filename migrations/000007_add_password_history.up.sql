@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS player_password_history (
+    id BIGSERIAL PRIMARY KEY,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    usrpwd TEXT NOT NULL,
+    date_created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS player_password_history_player_idx ON player_password_history (player_id, id);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE players
+    DROP COLUMN IF EXISTS pending_email,
+    DROP COLUMN IF EXISTS email_verified;
+
+COMMIT;
\ No newline at end of file
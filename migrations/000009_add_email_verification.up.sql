@@ -0,0 +1,19 @@
+BEGIN;
+
+-- existing players never verified their email, pending_email keeps a new email
+-- until it is verified, email is used until then.
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS pending_email VARCHAR(128);
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    token_hash TEXT PRIMARY KEY,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    email VARCHAR(128) NOT NULL,
+    date_expires TIMESTAMP NOT NULL,
+    date_created TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS email_verification_tokens_player_idx ON email_verification_tokens (player_id);
+
+COMMIT;
\ No newline at end of file
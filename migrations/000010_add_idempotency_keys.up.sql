@@ -0,0 +1,22 @@
+BEGIN;
+
+-- keys sent by clients to retry the creation of a player safely, they are scoped by
+-- the source of the request. The key is stored before the player, so the foreign key
+-- is checked when the transaction commits. Keys are removed with their player and
+-- when its password changes, so the hashed password is not kept after that.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    source VARCHAR(128) NOT NULL,
+    idempotency_key VARCHAR(128) NOT NULL,
+    fingerprint TEXT NOT NULL,
+    usrpwd TEXT NOT NULL,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+    reply JSONB NOT NULL,
+    date_expires TIMESTAMP NOT NULL,
+    date_created TIMESTAMP NOT NULL,
+    PRIMARY KEY (source, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_player_idx ON idempotency_keys (player_id);
+CREATE INDEX IF NOT EXISTS idempotency_keys_date_expires_idx ON idempotency_keys (date_expires);
+
+COMMIT;
\ No newline at end of file
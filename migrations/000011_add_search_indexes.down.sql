@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS players_country_idx;
+DROP INDEX IF EXISTS players_date_updated_idx;
+DROP INDEX IF EXISTS players_date_created_idx;
+DROP INDEX IF EXISTS players_canonical_nickname_prefix_idx;
+
+COMMIT;
\ No newline at end of file
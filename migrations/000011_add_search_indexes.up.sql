@@ -0,0 +1,12 @@
+BEGIN;
+
+-- search filters only look up players that are not deleted. The nickname prefix is
+-- compared with LIKE on the same lookup form players are compared by, which needs
+-- the pattern operator class to use the index. Names are filtered with ILIKE
+-- contains, which btree indexes can't serve.
+CREATE INDEX IF NOT EXISTS players_canonical_nickname_prefix_idx ON players (COALESCE(canonical_nickname, lower(nickname)) text_pattern_ops) WHERE date_deleted IS NULL;
+CREATE INDEX IF NOT EXISTS players_date_created_idx ON players (date_created) WHERE date_deleted IS NULL;
+CREATE INDEX IF NOT EXISTS players_date_updated_idx ON players (date_updated) WHERE date_deleted IS NULL;
+CREATE INDEX IF NOT EXISTS players_country_idx ON players (country) WHERE date_deleted IS NULL;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- the pg_trgm extension is kept, it could be used by other database objects.
+DROP INDEX IF EXISTS players_lastname_trgm_idx;
+DROP INDEX IF EXISTS players_firstname_trgm_idx;
+DROP INDEX IF EXISTS players_nickname_trgm_idx;
+
+COMMIT;
\ No newline at end of file
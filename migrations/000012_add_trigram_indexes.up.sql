@@ -0,0 +1,19 @@
+BEGIN;
+
+-- fuzzy searches compare nicknames and names with pg_trgm. The extension is optional:
+-- if it is not available or can't be created, the indexes are skipped and fuzzy
+-- searches fall back to ILIKE.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+    CREATE INDEX IF NOT EXISTS players_nickname_trgm_idx ON players USING GIN (nickname gin_trgm_ops) WHERE date_deleted IS NULL;
+    CREATE INDEX IF NOT EXISTS players_firstname_trgm_idx ON players USING GIN (firstname gin_trgm_ops) WHERE date_deleted IS NULL;
+    CREATE INDEX IF NOT EXISTS players_lastname_trgm_idx ON players USING GIN (lastname gin_trgm_ops) WHERE date_deleted IS NULL;
+EXCEPTION
+    WHEN OTHERS THEN
+        RAISE NOTICE 'pg_trgm is not available, fuzzy searches fall back to ILIKE: %', SQLERRM;
+END
+$$;
+
+COMMIT;
\ No newline at end of file
@@ -19,10 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PlayerHandler_CreatePlayer_FullMethodName  = "/players.PlayerHandler/CreatePlayer"
-	PlayerHandler_UpdatePlayer_FullMethodName  = "/players.PlayerHandler/UpdatePlayer"
-	PlayerHandler_DeletePlayer_FullMethodName  = "/players.PlayerHandler/DeletePlayer"
-	PlayerHandler_SearchPlayers_FullMethodName = "/players.PlayerHandler/SearchPlayers"
+	PlayerHandler_CreatePlayer_FullMethodName         = "/players.PlayerHandler/CreatePlayer"
+	PlayerHandler_UpdatePlayer_FullMethodName         = "/players.PlayerHandler/UpdatePlayer"
+	PlayerHandler_ChangePassword_FullMethodName       = "/players.PlayerHandler/ChangePassword"
+	PlayerHandler_RequestPasswordReset_FullMethodName = "/players.PlayerHandler/RequestPasswordReset"
+	PlayerHandler_ConfirmPasswordReset_FullMethodName = "/players.PlayerHandler/ConfirmPasswordReset"
+	PlayerHandler_VerifyEmail_FullMethodName          = "/players.PlayerHandler/VerifyEmail"
+	PlayerHandler_DeletePlayer_FullMethodName         = "/players.PlayerHandler/DeletePlayer"
+	PlayerHandler_BatchCreatePlayers_FullMethodName   = "/players.PlayerHandler/BatchCreatePlayers"
+	PlayerHandler_BatchUpdatePlayers_FullMethodName   = "/players.PlayerHandler/BatchUpdatePlayers"
+	PlayerHandler_BatchDeletePlayers_FullMethodName   = "/players.PlayerHandler/BatchDeletePlayers"
+	PlayerHandler_RestorePlayer_FullMethodName        = "/players.PlayerHandler/RestorePlayer"
+	PlayerHandler_SearchPlayers_FullMethodName        = "/players.PlayerHandler/SearchPlayers"
+	PlayerHandler_GetPlayer_FullMethodName            = "/players.PlayerHandler/GetPlayer"
+	PlayerHandler_AuthenticatePlayer_FullMethodName   = "/players.PlayerHandler/AuthenticatePlayer"
+	PlayerHandler_GetPlayerLockState_FullMethodName   = "/players.PlayerHandler/GetPlayerLockState"
+	PlayerHandler_UnlockPlayer_FullMethodName         = "/players.PlayerHandler/UnlockPlayer"
+	PlayerHandler_ActivatePlayer_FullMethodName       = "/players.PlayerHandler/ActivatePlayer"
+	PlayerHandler_DisablePlayer_FullMethodName        = "/players.PlayerHandler/DisablePlayer"
+	PlayerHandler_SuspendPlayer_FullMethodName        = "/players.PlayerHandler/SuspendPlayer"
+	PlayerHandler_BanPlayer_FullMethodName            = "/players.PlayerHandler/BanPlayer"
+	PlayerHandler_ListCountries_FullMethodName        = "/players.PlayerHandler/ListCountries"
 )
 
 // PlayerHandlerClient is the client API for PlayerHandler service.
@@ -35,10 +52,45 @@ type PlayerHandlerClient interface {
 	CreatePlayer(ctx context.Context, in *CreatePlayerRequest, opts ...grpc.CallOption) (*CreatePlayerReply, error)
 	// Update player
 	UpdatePlayer(ctx context.Context, in *UpdatePlayerRequest, opts ...grpc.CallOption) (*UpdatePlayerReply, error)
+	// Change the password of a player verifying the current one
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordReply, error)
+	// Send a single-use token to reset the password to the player with the given email
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetReply, error)
+	// Reset the password of a player with a token sent by RequestPasswordReset
+	ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*ConfirmPasswordResetReply, error)
+	// Verify the email of a player with a token sent when the email was set
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error)
 	// Delete Player
 	DeletePlayer(ctx context.Context, in *DeletePlayerRequest, opts ...grpc.CallOption) (*DeletePlayerReply, error)
+	// Create players in batch with a result per player
+	BatchCreatePlayers(ctx context.Context, in *BatchCreatePlayersRequest, opts ...grpc.CallOption) (*BatchCreatePlayersReply, error)
+	// Update players in batch with a result per player
+	BatchUpdatePlayers(ctx context.Context, in *BatchUpdatePlayersRequest, opts ...grpc.CallOption) (*BatchUpdatePlayersReply, error)
+	// Delete players in batch with a result per player
+	BatchDeletePlayers(ctx context.Context, in *BatchDeletePlayersRequest, opts ...grpc.CallOption) (*BatchDeletePlayersReply, error)
+	// Restore a deleted player within the retention window, admins only
+	RestorePlayer(ctx context.Context, in *RestorePlayerRequest, opts ...grpc.CallOption) (*RestorePlayerReply, error)
 	// Search players
 	SearchPlayers(ctx context.Context, in *SearchPlayersRequest, opts ...grpc.CallOption) (*SearchPlayersReply, error)
+	// Get player
+	GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*GetPlayerReply, error)
+	// Authenticate player, wrong credentials are UNAUTHENTICATED and the right credentials
+	// of a player that is not active are PERMISSION_DENIED.
+	AuthenticatePlayer(ctx context.Context, in *AuthenticatePlayerRequest, opts ...grpc.CallOption) (*AuthenticatePlayerReply, error)
+	// Get player lock state, admins only
+	GetPlayerLockState(ctx context.Context, in *GetPlayerLockStateRequest, opts ...grpc.CallOption) (*GetPlayerLockStateReply, error)
+	// Unlock player, admins only
+	UnlockPlayer(ctx context.Context, in *UnlockPlayerRequest, opts ...grpc.CallOption) (*UnlockPlayerReply, error)
+	// Activate a disabled, suspended or banned player, admins only
+	ActivatePlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error)
+	// Disable player, admins only
+	DisablePlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error)
+	// Suspend player until the given date, admins only
+	SuspendPlayer(ctx context.Context, in *SuspendPlayerRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error)
+	// Ban player, admins only
+	BanPlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error)
+	// List the supported ISO 3166-1 countries with their number of players
+	ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesReply, error)
 }
 
 type playerHandlerClient struct {
@@ -69,6 +121,46 @@ func (c *playerHandlerClient) UpdatePlayer(ctx context.Context, in *UpdatePlayer
 	return out, nil
 }
 
+func (c *playerHandlerClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPasswordResetReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest, opts ...grpc.CallOption) (*ConfirmPasswordResetReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPasswordResetReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_ConfirmPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playerHandlerClient) DeletePlayer(ctx context.Context, in *DeletePlayerRequest, opts ...grpc.CallOption) (*DeletePlayerReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeletePlayerReply)
@@ -79,6 +171,46 @@ func (c *playerHandlerClient) DeletePlayer(ctx context.Context, in *DeletePlayer
 	return out, nil
 }
 
+func (c *playerHandlerClient) BatchCreatePlayers(ctx context.Context, in *BatchCreatePlayersRequest, opts ...grpc.CallOption) (*BatchCreatePlayersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreatePlayersReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_BatchCreatePlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) BatchUpdatePlayers(ctx context.Context, in *BatchUpdatePlayersRequest, opts ...grpc.CallOption) (*BatchUpdatePlayersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdatePlayersReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_BatchUpdatePlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) BatchDeletePlayers(ctx context.Context, in *BatchDeletePlayersRequest, opts ...grpc.CallOption) (*BatchDeletePlayersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchDeletePlayersReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_BatchDeletePlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) RestorePlayer(ctx context.Context, in *RestorePlayerRequest, opts ...grpc.CallOption) (*RestorePlayerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePlayerReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_RestorePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playerHandlerClient) SearchPlayers(ctx context.Context, in *SearchPlayersRequest, opts ...grpc.CallOption) (*SearchPlayersReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SearchPlayersReply)
@@ -89,6 +221,96 @@ func (c *playerHandlerClient) SearchPlayers(ctx context.Context, in *SearchPlaye
 	return out, nil
 }
 
+func (c *playerHandlerClient) GetPlayer(ctx context.Context, in *GetPlayerRequest, opts ...grpc.CallOption) (*GetPlayerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlayerReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_GetPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) AuthenticatePlayer(ctx context.Context, in *AuthenticatePlayerRequest, opts ...grpc.CallOption) (*AuthenticatePlayerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthenticatePlayerReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_AuthenticatePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) GetPlayerLockState(ctx context.Context, in *GetPlayerLockStateRequest, opts ...grpc.CallOption) (*GetPlayerLockStateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlayerLockStateReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_GetPlayerLockState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) UnlockPlayer(ctx context.Context, in *UnlockPlayerRequest, opts ...grpc.CallOption) (*UnlockPlayerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockPlayerReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_UnlockPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) ActivatePlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePlayerStatusReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_ActivatePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) DisablePlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePlayerStatusReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_DisablePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) SuspendPlayer(ctx context.Context, in *SuspendPlayerRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePlayerStatusReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_SuspendPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) BanPlayer(ctx context.Context, in *ChangePlayerStatusRequest, opts ...grpc.CallOption) (*ChangePlayerStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePlayerStatusReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_BanPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerHandlerClient) ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCountriesReply)
+	err := c.cc.Invoke(ctx, PlayerHandler_ListCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerHandlerServer is the server API for PlayerHandler service.
 // All implementations must embed UnimplementedPlayerHandlerServer
 // for forward compatibility.
@@ -99,10 +321,45 @@ type PlayerHandlerServer interface {
 	CreatePlayer(context.Context, *CreatePlayerRequest) (*CreatePlayerReply, error)
 	// Update player
 	UpdatePlayer(context.Context, *UpdatePlayerRequest) (*UpdatePlayerReply, error)
+	// Change the password of a player verifying the current one
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordReply, error)
+	// Send a single-use token to reset the password to the player with the given email
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetReply, error)
+	// Reset the password of a player with a token sent by RequestPasswordReset
+	ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*ConfirmPasswordResetReply, error)
+	// Verify the email of a player with a token sent when the email was set
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error)
 	// Delete Player
 	DeletePlayer(context.Context, *DeletePlayerRequest) (*DeletePlayerReply, error)
+	// Create players in batch with a result per player
+	BatchCreatePlayers(context.Context, *BatchCreatePlayersRequest) (*BatchCreatePlayersReply, error)
+	// Update players in batch with a result per player
+	BatchUpdatePlayers(context.Context, *BatchUpdatePlayersRequest) (*BatchUpdatePlayersReply, error)
+	// Delete players in batch with a result per player
+	BatchDeletePlayers(context.Context, *BatchDeletePlayersRequest) (*BatchDeletePlayersReply, error)
+	// Restore a deleted player within the retention window, admins only
+	RestorePlayer(context.Context, *RestorePlayerRequest) (*RestorePlayerReply, error)
 	// Search players
 	SearchPlayers(context.Context, *SearchPlayersRequest) (*SearchPlayersReply, error)
+	// Get player
+	GetPlayer(context.Context, *GetPlayerRequest) (*GetPlayerReply, error)
+	// Authenticate player, wrong credentials are UNAUTHENTICATED and the right credentials
+	// of a player that is not active are PERMISSION_DENIED.
+	AuthenticatePlayer(context.Context, *AuthenticatePlayerRequest) (*AuthenticatePlayerReply, error)
+	// Get player lock state, admins only
+	GetPlayerLockState(context.Context, *GetPlayerLockStateRequest) (*GetPlayerLockStateReply, error)
+	// Unlock player, admins only
+	UnlockPlayer(context.Context, *UnlockPlayerRequest) (*UnlockPlayerReply, error)
+	// Activate a disabled, suspended or banned player, admins only
+	ActivatePlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error)
+	// Disable player, admins only
+	DisablePlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error)
+	// Suspend player until the given date, admins only
+	SuspendPlayer(context.Context, *SuspendPlayerRequest) (*ChangePlayerStatusReply, error)
+	// Ban player, admins only
+	BanPlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error)
+	// List the supported ISO 3166-1 countries with their number of players
+	ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesReply, error)
 	mustEmbedUnimplementedPlayerHandlerServer()
 }
 
@@ -119,12 +376,63 @@ func (UnimplementedPlayerHandlerServer) CreatePlayer(context.Context, *CreatePla
 func (UnimplementedPlayerHandlerServer) UpdatePlayer(context.Context, *UpdatePlayerRequest) (*UpdatePlayerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlayer not implemented")
 }
+func (UnimplementedPlayerHandlerServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedPlayerHandlerServer) RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*RequestPasswordResetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedPlayerHandlerServer) ConfirmPasswordReset(context.Context, *ConfirmPasswordResetRequest) (*ConfirmPasswordResetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPasswordReset not implemented")
+}
+func (UnimplementedPlayerHandlerServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
 func (UnimplementedPlayerHandlerServer) DeletePlayer(context.Context, *DeletePlayerRequest) (*DeletePlayerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePlayer not implemented")
 }
+func (UnimplementedPlayerHandlerServer) BatchCreatePlayers(context.Context, *BatchCreatePlayersRequest) (*BatchCreatePlayersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreatePlayers not implemented")
+}
+func (UnimplementedPlayerHandlerServer) BatchUpdatePlayers(context.Context, *BatchUpdatePlayersRequest) (*BatchUpdatePlayersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdatePlayers not implemented")
+}
+func (UnimplementedPlayerHandlerServer) BatchDeletePlayers(context.Context, *BatchDeletePlayersRequest) (*BatchDeletePlayersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeletePlayers not implemented")
+}
+func (UnimplementedPlayerHandlerServer) RestorePlayer(context.Context, *RestorePlayerRequest) (*RestorePlayerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePlayer not implemented")
+}
 func (UnimplementedPlayerHandlerServer) SearchPlayers(context.Context, *SearchPlayersRequest) (*SearchPlayersReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchPlayers not implemented")
 }
+func (UnimplementedPlayerHandlerServer) GetPlayer(context.Context, *GetPlayerRequest) (*GetPlayerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) AuthenticatePlayer(context.Context, *AuthenticatePlayerRequest) (*AuthenticatePlayerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticatePlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) GetPlayerLockState(context.Context, *GetPlayerLockStateRequest) (*GetPlayerLockStateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerLockState not implemented")
+}
+func (UnimplementedPlayerHandlerServer) UnlockPlayer(context.Context, *UnlockPlayerRequest) (*UnlockPlayerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockPlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) ActivatePlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivatePlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) DisablePlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisablePlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) SuspendPlayer(context.Context, *SuspendPlayerRequest) (*ChangePlayerStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendPlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) BanPlayer(context.Context, *ChangePlayerStatusRequest) (*ChangePlayerStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPlayer not implemented")
+}
+func (UnimplementedPlayerHandlerServer) ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountries not implemented")
+}
 func (UnimplementedPlayerHandlerServer) mustEmbedUnimplementedPlayerHandlerServer() {}
 func (UnimplementedPlayerHandlerServer) testEmbeddedByValue()                       {}
 
@@ -182,6 +490,78 @@ func _PlayerHandler_UpdatePlayer_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlayerHandler_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_ConfirmPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).ConfirmPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_ConfirmPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).ConfirmPasswordReset(ctx, req.(*ConfirmPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlayerHandler_DeletePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeletePlayerRequest)
 	if err := dec(in); err != nil {
@@ -200,6 +580,78 @@ func _PlayerHandler_DeletePlayer_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlayerHandler_BatchCreatePlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreatePlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).BatchCreatePlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_BatchCreatePlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).BatchCreatePlayers(ctx, req.(*BatchCreatePlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_BatchUpdatePlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdatePlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).BatchUpdatePlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_BatchUpdatePlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).BatchUpdatePlayers(ctx, req.(*BatchUpdatePlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_BatchDeletePlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeletePlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).BatchDeletePlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_BatchDeletePlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).BatchDeletePlayers(ctx, req.(*BatchDeletePlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_RestorePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).RestorePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_RestorePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).RestorePlayer(ctx, req.(*RestorePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlayerHandler_SearchPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchPlayersRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +670,168 @@ func _PlayerHandler_SearchPlayers_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlayerHandler_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).GetPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_GetPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).GetPlayer(ctx, req.(*GetPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_AuthenticatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticatePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).AuthenticatePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_AuthenticatePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).AuthenticatePlayer(ctx, req.(*AuthenticatePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_GetPlayerLockState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerLockStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).GetPlayerLockState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_GetPlayerLockState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).GetPlayerLockState(ctx, req.(*GetPlayerLockStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_UnlockPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).UnlockPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_UnlockPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).UnlockPlayer(ctx, req.(*UnlockPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_ActivatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePlayerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).ActivatePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_ActivatePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).ActivatePlayer(ctx, req.(*ChangePlayerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_DisablePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePlayerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).DisablePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_DisablePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).DisablePlayer(ctx, req.(*ChangePlayerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_SuspendPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).SuspendPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_SuspendPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).SuspendPlayer(ctx, req.(*SuspendPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_BanPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePlayerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).BanPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_BanPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).BanPlayer(ctx, req.(*ChangePlayerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerHandler_ListCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerHandlerServer).ListCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerHandler_ListCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerHandlerServer).ListCountries(ctx, req.(*ListCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlayerHandler_ServiceDesc is the grpc.ServiceDesc for PlayerHandler service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,14 +847,82 @@ var PlayerHandler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdatePlayer",
 			Handler:    _PlayerHandler_UpdatePlayer_Handler,
 		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _PlayerHandler_ChangePassword_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _PlayerHandler_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ConfirmPasswordReset",
+			Handler:    _PlayerHandler_ConfirmPasswordReset_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _PlayerHandler_VerifyEmail_Handler,
+		},
 		{
 			MethodName: "DeletePlayer",
 			Handler:    _PlayerHandler_DeletePlayer_Handler,
 		},
+		{
+			MethodName: "BatchCreatePlayers",
+			Handler:    _PlayerHandler_BatchCreatePlayers_Handler,
+		},
+		{
+			MethodName: "BatchUpdatePlayers",
+			Handler:    _PlayerHandler_BatchUpdatePlayers_Handler,
+		},
+		{
+			MethodName: "BatchDeletePlayers",
+			Handler:    _PlayerHandler_BatchDeletePlayers_Handler,
+		},
+		{
+			MethodName: "RestorePlayer",
+			Handler:    _PlayerHandler_RestorePlayer_Handler,
+		},
 		{
 			MethodName: "SearchPlayers",
 			Handler:    _PlayerHandler_SearchPlayers_Handler,
 		},
+		{
+			MethodName: "GetPlayer",
+			Handler:    _PlayerHandler_GetPlayer_Handler,
+		},
+		{
+			MethodName: "AuthenticatePlayer",
+			Handler:    _PlayerHandler_AuthenticatePlayer_Handler,
+		},
+		{
+			MethodName: "GetPlayerLockState",
+			Handler:    _PlayerHandler_GetPlayerLockState_Handler,
+		},
+		{
+			MethodName: "UnlockPlayer",
+			Handler:    _PlayerHandler_UnlockPlayer_Handler,
+		},
+		{
+			MethodName: "ActivatePlayer",
+			Handler:    _PlayerHandler_ActivatePlayer_Handler,
+		},
+		{
+			MethodName: "DisablePlayer",
+			Handler:    _PlayerHandler_DisablePlayer_Handler,
+		},
+		{
+			MethodName: "SuspendPlayer",
+			Handler:    _PlayerHandler_SuspendPlayer_Handler,
+		},
+		{
+			MethodName: "BanPlayer",
+			Handler:    _PlayerHandler_BanPlayer_Handler,
+		},
+		{
+			MethodName: "ListCountries",
+			Handler:    _PlayerHandler_ListCountries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/pb/players/players.proto",